@@ -1876,7 +1876,7 @@ func TestPrebuiltTools(t *testing.T) {
 			wantToolset: server.ToolsetConfigs{
 				"dataproc_tools": tools.ToolsetConfig{
 					Name:      "dataproc_tools",
-					ToolNames: []string{"list_clusters", "get_cluster", "list_jobs", "get_job"},
+					ToolNames: []string{"list_clusters", "get_cluster", "export_cluster_config", "create_cluster_from_config", "list_jobs", "get_job"},
 				},
 			},
 		},
@@ -1886,7 +1886,7 @@ func TestPrebuiltTools(t *testing.T) {
 			wantToolset: server.ToolsetConfigs{
 				"serverless_spark_tools": tools.ToolsetConfig{
 					Name:      "serverless_spark_tools",
-					ToolNames: []string{"list_batches", "get_batch", "cancel_batch", "create_pyspark_batch", "create_spark_batch", "get_session_template", "list_sessions", "get_session"},
+					ToolNames: []string{"list_batches", "get_batch", "get_batch_diagnostics", "get_batch_log_histogram", "get_batch_metrics", "get_batch_root_cause", "list_batch_staging_objects", "wait_for_batch", "cancel_batch", "create_pyspark_batch", "create_spark_batch", "create_spark_sql_batch", "resubmit_batch", "list_runtime_versions", "describe_source", "get_session_template", "list_session_templates", "list_sessions", "create_session", "get_session", "get_session_details_with_logs", "get_log_entry"},
 				},
 			},
 		},
@@ -175,6 +175,8 @@ import (
 	_ "github.com/googleapis/mcp-toolbox/internal/tools/dataplex/dataplexsearchaspecttypes"
 	_ "github.com/googleapis/mcp-toolbox/internal/tools/dataplex/dataplexsearchdqscans"
 	_ "github.com/googleapis/mcp-toolbox/internal/tools/dataplex/dataplexsearchentries"
+	_ "github.com/googleapis/mcp-toolbox/internal/tools/dataproc/dataproccreateclusterfromconfig"
+	_ "github.com/googleapis/mcp-toolbox/internal/tools/dataproc/dataprocexportclusterconfig"
 	_ "github.com/googleapis/mcp-toolbox/internal/tools/dataproc/dataprocgetcluster"
 	_ "github.com/googleapis/mcp-toolbox/internal/tools/dataproc/dataprocgetjob"
 	_ "github.com/googleapis/mcp-toolbox/internal/tools/dataproc/dataproclistclusters"
@@ -304,12 +306,26 @@ import (
 	_ "github.com/googleapis/mcp-toolbox/internal/tools/scylladb/scyllacql"
 	_ "github.com/googleapis/mcp-toolbox/internal/tools/serverlessspark/serverlesssparkcancelbatch"
 	_ "github.com/googleapis/mcp-toolbox/internal/tools/serverlessspark/serverlesssparkcreatepysparkbatch"
+	_ "github.com/googleapis/mcp-toolbox/internal/tools/serverlessspark/serverlesssparkcreatesession"
 	_ "github.com/googleapis/mcp-toolbox/internal/tools/serverlessspark/serverlesssparkcreatesparkbatch"
+	_ "github.com/googleapis/mcp-toolbox/internal/tools/serverlessspark/serverlesssparkcreatesparksqlbatch"
+	_ "github.com/googleapis/mcp-toolbox/internal/tools/serverlessspark/serverlesssparkdescribesource"
 	_ "github.com/googleapis/mcp-toolbox/internal/tools/serverlessspark/serverlesssparkgetbatch"
+	_ "github.com/googleapis/mcp-toolbox/internal/tools/serverlessspark/serverlesssparkgetbatchdiagnostics"
+	_ "github.com/googleapis/mcp-toolbox/internal/tools/serverlessspark/serverlesssparkgetbatchloghistogram"
+	_ "github.com/googleapis/mcp-toolbox/internal/tools/serverlessspark/serverlesssparkgetbatchmetrics"
+	_ "github.com/googleapis/mcp-toolbox/internal/tools/serverlessspark/serverlesssparkgetbatchrootcause"
+	_ "github.com/googleapis/mcp-toolbox/internal/tools/serverlessspark/serverlesssparkgetlogentry"
 	_ "github.com/googleapis/mcp-toolbox/internal/tools/serverlessspark/serverlesssparkgetsession"
+	_ "github.com/googleapis/mcp-toolbox/internal/tools/serverlessspark/serverlesssparkgetsessiondetailswithlogs"
 	_ "github.com/googleapis/mcp-toolbox/internal/tools/serverlessspark/serverlesssparkgetsessiontemplate"
 	_ "github.com/googleapis/mcp-toolbox/internal/tools/serverlessspark/serverlesssparklistbatches"
+	_ "github.com/googleapis/mcp-toolbox/internal/tools/serverlessspark/serverlesssparklistbatchstagingobjects"
+	_ "github.com/googleapis/mcp-toolbox/internal/tools/serverlessspark/serverlesssparklistruntimeversions"
 	_ "github.com/googleapis/mcp-toolbox/internal/tools/serverlessspark/serverlesssparklistsessions"
+	_ "github.com/googleapis/mcp-toolbox/internal/tools/serverlessspark/serverlesssparklistsessiontemplates"
+	_ "github.com/googleapis/mcp-toolbox/internal/tools/serverlessspark/serverlesssparkresubmitbatch"
+	_ "github.com/googleapis/mcp-toolbox/internal/tools/serverlessspark/serverlesssparkwaitforbatch"
 	_ "github.com/googleapis/mcp-toolbox/internal/tools/singlestore/singlestoreexecutesql"
 	_ "github.com/googleapis/mcp-toolbox/internal/tools/singlestore/singlestoresql"
 	_ "github.com/googleapis/mcp-toolbox/internal/tools/snowflake/snowflakeexecutesql"
@@ -128,7 +128,7 @@ func toolGetHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 // toolInvokeHandler handles the API request to invoke a specific Tool.
 func toolInvokeHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 	ctx, span := s.instrumentation.Tracer.Start(r.Context(), "toolbox/server/tool/invoke")
-	r = r.WithContext(ctx)
+	r = r.WithContext(util.WithInstrumentation(ctx, s.instrumentation))
 	ctx = util.WithLogger(r.Context(), s.logger)
 
 	toolName := chi.URLParam(r, "toolName")
@@ -672,9 +672,20 @@ func (s *Server) ServeStdio(ctx context.Context, stdin io.Reader, stdout io.Writ
 
 // Shutdown gracefully shuts down the server without interrupting any active
 // connections. It uses http.Server.Shutdown() and has the same functionality.
+// Once the server has shut down, any sources holding clients are closed.
 func (s *Server) Shutdown(ctx context.Context) error {
 	s.logger.DebugContext(ctx, "shutting down the server.")
-	return s.srv.Shutdown(ctx)
+	err := s.srv.Shutdown(ctx)
+	for name, source := range s.ResourceMgr.GetSourcesMap() {
+		closer, ok := source.(io.Closer)
+		if !ok {
+			continue
+		}
+		if cerr := closer.Close(); cerr != nil {
+			s.logger.WarnContext(ctx, fmt.Sprintf("failed to close source %q: %s", name, cerr))
+		}
+	}
+	return err
 }
 
 func (s *Server) Addr() string {
@@ -15,16 +15,22 @@ package cloudloggingadmin
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/url"
 	"slices"
 	"strings"
+	"sync"
 	"time"
 
 	"cloud.google.com/go/logging"
 	"cloud.google.com/go/logging/logadmin"
+	"cloud.google.com/go/storage"
 	"github.com/goccy/go-yaml"
 	"github.com/googleapis/mcp-toolbox/internal/sources"
 	"github.com/googleapis/mcp-toolbox/internal/util"
+	"github.com/googleapis/mcp-toolbox/internal/util/logiter"
 	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/google"
@@ -57,6 +63,9 @@ type Config struct {
 	Project                   string `yaml:"project" validate:"required"`
 	UseClientOAuth            bool   `yaml:"useClientOAuth"`
 	ImpersonateServiceAccount string `yaml:"impersonateServiceAccount"`
+	// DefaultLogLimit is the maximum number of log entries returned by the
+	// query logs tool when no limit is supplied. Zero means the tool default.
+	DefaultLogLimit int `yaml:"defaultLogLimit" validate:"gte=0"`
 }
 
 func (r Config) SourceConfigType() string {
@@ -88,6 +97,10 @@ func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.So
 			return nil, fmt.Errorf("error constructing client creator: %w", err)
 		}
 		setupClientCaching(s, baseClientCreator)
+		s.StorageClientCreator, err = newStorageClientCreator(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("error constructing storage client creator: %w", err)
+		}
 	} else {
 		client, tokenSource, err = initLogAdminConnection(ctx, tracer, r.Name, r.Project, r.ImpersonateServiceAccount)
 		if err != nil {
@@ -95,6 +108,14 @@ func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.So
 		}
 		s.Client = client
 		s.TokenSource = tokenSource
+		var storageTokenSource oauth2.TokenSource
+		if r.ImpersonateServiceAccount != "" {
+			storageTokenSource = tokenSource
+		}
+		s.storageClientInit, err = newStorageClientInit(ctx, storageTokenSource)
+		if err != nil {
+			return nil, fmt.Errorf("error constructing storage client initializer: %w", err)
+		}
 	}
 	return s, nil
 }
@@ -103,11 +124,24 @@ var _ sources.Source = &Source{}
 
 type LogAdminClientCreator func(tokenString string) (*logadmin.Client, error)
 
+// StorageClientCreator creates a Cloud Storage client that authenticates with
+// the given OAuth token. The caller must close it.
+type StorageClientCreator func(tokenString string) (*storage.Client, error)
+
 type Source struct {
 	Config
 	Client        *logadmin.Client
 	TokenSource   oauth2.TokenSource
 	ClientCreator LogAdminClientCreator
+	// StorageClient writes exported log entries to Cloud Storage. It is
+	// created on the first export, so that sources that never export don't
+	// need Cloud Storage access. It is unset if the source uses client
+	// OAuth, in which case StorageClientCreator creates a client for each
+	// export.
+	StorageClient        *storage.Client
+	StorageClientCreator StorageClientCreator
+	storageClientInit    func() (*storage.Client, error)
+	storageClientMu      sync.Mutex
 
 	// Caches for OAuth clients
 	logadminClientCache *sources.Cache
@@ -142,6 +176,11 @@ func (s *Source) GetProject() string {
 	return s.Project
 }
 
+// GetDefaultLogLimit returns the configured default log limit, or 0 if unset.
+func (s *Source) GetDefaultLogLimit() int {
+	return s.DefaultLogLimit
+}
+
 // getClient returns the appropriate client based on authentication mode
 func (s *Source) getClient(accessToken string) (*logadmin.Client, error) {
 	if s.UseClientOAuth {
@@ -156,6 +195,34 @@ func (s *Source) getClient(accessToken string) (*logadmin.Client, error) {
 	return s.Client, nil
 }
 
+// getStorageClient returns the Cloud Storage client to export log entries
+// with, and a function to release it.
+func (s *Source) getStorageClient(accessToken string) (*storage.Client, func(), error) {
+	if s.UseClientOAuth {
+		if s.StorageClientCreator == nil {
+			return nil, nil, fmt.Errorf("storage client creator is not initialized")
+		}
+		client, err := s.StorageClientCreator(accessToken)
+		if err != nil {
+			return nil, nil, err
+		}
+		return client, func() { client.Close() }, nil
+	}
+	s.storageClientMu.Lock()
+	defer s.storageClientMu.Unlock()
+	if s.StorageClient == nil && s.storageClientInit != nil {
+		client, err := s.storageClientInit()
+		if err != nil {
+			return nil, nil, err
+		}
+		s.StorageClient = client
+	}
+	if s.StorageClient == nil {
+		return nil, nil, fmt.Errorf("source storage client is not initialized")
+	}
+	return s.StorageClient, func() {}, nil
+}
+
 // ListLogNames lists all log names in the project
 func (s *Source) ListLogNames(ctx context.Context, limit int, accessToken string) ([]string, error) {
 	client, err := s.getClient(accessToken)
@@ -201,6 +268,14 @@ func (s *Source) ListResourceTypes(ctx context.Context, accessToken string) ([]s
 	return types, nil
 }
 
+// applicationIDLabel is the label of the Spark application ID of the log
+// entries written by Dataproc.
+const applicationIDLabel = "dataproc.googleapis.com/application_id"
+
+// maxPageSize is the largest page size the Cloud Logging API accepts when
+// listing entries.
+const maxPageSize = 1000
+
 // QueryLogsParams contains the parameters for querying logs
 type QueryLogsParams struct {
 	Filter      string
@@ -208,22 +283,73 @@ type QueryLogsParams struct {
 	StartTime   string
 	EndTime     string
 	Verbose     bool
-	Limit       int
+	// Limit is the maximum number of entries to return. Zero means no
+	// limit, which only ExportLogs should be used with.
+	Limit int
+	// TraceID restricts the query to entries of the given trace.
+	TraceID string
+	// ApplicationID restricts the query to entries of the given Spark
+	// application, e.g. one of the applications of a Dataproc batch.
+	ApplicationID string
+	// MinSeverity restricts the query to entries of at least the given
+	// severity, e.g. WARNING.
+	MinSeverity string
+	// Severity restricts the query to entries of exactly the given severity,
+	// e.g. ERROR but not CRITICAL.
+	Severity string
+	// LogName restricts the query to entries of the given log, e.g.
+	// dataproc.googleapis.com/yarn. It is URL-escaped in the filter.
+	LogName string
+	// Project overrides the source's project. If empty, the source's
+	// project is queried.
+	Project string
+	// IncludeLogURL adds a Logs Explorer link to each entry; see LogEntryURL.
+	IncludeLogURL bool
+	// IncludeLabels adds the entries' labels even if Verbose is unset, e.g.
+	// so that they can be grouped by a label.
+	IncludeLabels bool
 }
 
-// QueryLogs queries log entries based on the provided parameters
-func (s *Source) QueryLogs(ctx context.Context, params QueryLogsParams, accessToken string) ([]map[string]any, error) {
-	client, err := s.getClient(accessToken)
-	if err != nil {
-		return nil, err
-	}
+// formatTimestamp formats t as RFC 3339 in UTC, so that the timestamps of all
+// entries can be compared directly regardless of the zone they came in.
+func formatTimestamp(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
 
-	// Build filter
+// BuildFilter returns the Cloud Logging filter that QueryLogs and StreamLogs
+// run for params: the caller's filter and a clause for each other parameter
+// that is set, joined with AND.
+func (s *Source) BuildFilter(params QueryLogsParams) string {
 	var filterParts []string
 	if params.Filter != "" {
 		filterParts = append(filterParts, params.Filter)
 	}
 
+	project := s.Project
+	if params.Project != "" {
+		project = params.Project
+	}
+
+	if params.TraceID != "" {
+		filterParts = append(filterParts, fmt.Sprintf(`trace="projects/%s/traces/%s"`, project, params.TraceID))
+	}
+
+	if params.ApplicationID != "" {
+		filterParts = append(filterParts, fmt.Sprintf(`labels.%q=%q`, applicationIDLabel, params.ApplicationID))
+	}
+
+	if params.LogName != "" {
+		filterParts = append(filterParts, fmt.Sprintf(`logName="projects/%s/logs/%s"`, project, url.PathEscape(params.LogName)))
+	}
+
+	if params.MinSeverity != "" {
+		filterParts = append(filterParts, "severity>="+params.MinSeverity)
+	}
+
+	if params.Severity != "" {
+		filterParts = append(filterParts, "severity="+params.Severity)
+	}
+
 	// Add timestamp filter
 	startTime := params.StartTime
 	if startTime != "" {
@@ -234,34 +360,83 @@ func (s *Source) QueryLogs(ctx context.Context, params QueryLogsParams, accessTo
 		filterParts = append(filterParts, fmt.Sprintf(`timestamp<="%s"`, params.EndTime))
 	}
 
-	combinedFilter := strings.Join(filterParts, " AND ")
+	return strings.Join(filterParts, " AND ")
+}
+
+// QueryLogs queries log entries based on the provided parameters. If ctx's
+// deadline passes after some entries were read, they are returned without an
+// error; callers can tell with logiter.DeadlineExceeded.
+func (s *Source) QueryLogs(ctx context.Context, params QueryLogsParams, accessToken string) ([]map[string]any, error) {
+	var results []map[string]any
+	err := s.StreamLogs(ctx, params, accessToken, func(result map[string]any) error {
+		results = append(results, result)
+		return nil
+	})
+	if err != nil && !(len(results) > 0 && logiter.DeadlineExceeded(ctx)) {
+		return nil, err
+	}
+	if params.Verbose {
+		results = groupByTrace(results)
+	}
+	return results, nil
+}
+
+// StreamLogs queries log entries like QueryLogs, but calls fn with each entry
+// as it is read from the API instead of collecting them, so that large result
+// sets need not be held in memory. Entries are passed in query order; verbose
+// entries are not grouped by trace. If fn returns an error, StreamLogs stops
+// and returns it. If params.Limit is zero, every matching entry is streamed.
+// ctx is checked before each entry, so that a deadline stops the stream
+// promptly even while entries of a fetched page remain.
+func (s *Source) StreamLogs(ctx context.Context, params QueryLogsParams, accessToken string, fn func(map[string]any) error) error {
+	client, err := s.getClient(accessToken)
+	if err != nil {
+		return err
+	}
+
+	project := s.Project
+	if params.Project != "" {
+		project = params.Project
+	}
 
 	// Add opts
 	opts := []logadmin.EntriesOption{
-		logadmin.Filter(combinedFilter),
+		logadmin.Filter(s.BuildFilter(params)),
 	}
 
-	// Set order
+	if params.Project != "" {
+		opts = append(opts, logadmin.ProjectIDs([]string{params.Project}))
+	}
+
+	// Set order. The limit is applied to entries in this order, so the
+	// order must be set on the request for the server to return the right
+	// end of the time range; ascending is the server's default.
 	if params.NewestFirst {
 		opts = append(opts, logadmin.NewestFirst())
 	}
 
+	// Avoid fetching more entries than the limit.
+	if params.Limit > 0 {
+		opts = append(opts, logadmin.PageSize(int32(min(params.Limit, maxPageSize))))
+	} else {
+		opts = append(opts, logadmin.PageSize(maxPageSize))
+	}
+
 	// Set up iterator
 	it := client.Entries(ctx, opts...)
 
-	var results []map[string]any
-	for len(results) < params.Limit {
-		entry, err := it.Next()
+	for n := 0; params.Limit == 0 || n < params.Limit; n++ {
+		entry, err := logiter.Next(ctx, it)
 		if err == iterator.Done {
 			break
 		}
 		if err != nil {
-			return nil, fmt.Errorf("failed to iterate entries: %w", err)
+			return fmt.Errorf("failed to iterate entries: %w", err)
 		}
 
 		result := map[string]any{
 			"logName":   entry.LogName,
-			"timestamp": entry.Timestamp.Format(time.RFC3339),
+			"timestamp": formatTimestamp(entry.Timestamp),
 			"severity":  entry.Severity.String(),
 			"resource": map[string]any{
 				"type":   entry.Resource.Type,
@@ -273,13 +448,17 @@ func (s *Source) QueryLogs(ctx context.Context, params QueryLogsParams, accessTo
 			result["payload"] = entry.Payload
 		}
 
+		if params.IncludeLogURL {
+			result["logUrl"] = LogEntryURL(project, entry.LogName, entry.Timestamp)
+		}
+
+		if (params.Verbose || params.IncludeLabels) && len(entry.Labels) > 0 {
+			result["labels"] = entry.Labels
+		}
+
 		if params.Verbose {
 			result["insertId"] = entry.InsertID
 
-			if len(entry.Labels) > 0 {
-				result["labels"] = entry.Labels
-			}
-
 			if entry.HTTPRequest != nil {
 				httpRequestMap := map[string]any{
 					"status":   entry.HTTPRequest.Status,
@@ -319,9 +498,98 @@ func (s *Source) QueryLogs(ctx context.Context, params QueryLogsParams, accessTo
 				}
 			}
 		}
-		results = append(results, result)
+		if err := fn(result); err != nil {
+			return err
+		}
 	}
-	return results, nil
+	return nil
+}
+
+// ExportLogs writes the log entries matching params to the Cloud Storage
+// object gs://bucket/object as newline-delimited JSON, one entry per line in
+// query order, and returns the number of entries written. The entries are
+// streamed to the object as they are read, so any number can be exported;
+// params.Limit may be zero to export every matching entry. If the query
+// fails, the upload is abandoned and the object is not created.
+func (s *Source) ExportLogs(ctx context.Context, params QueryLogsParams, accessToken, bucket, object string) (int, error) {
+	client, release, err := s.getStorageClient(accessToken)
+	if err != nil {
+		return 0, err
+	}
+	defer release()
+
+	// Canceling the writer's context abandons the upload.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	w := client.Bucket(bucket).Object(object).NewWriter(ctx)
+	w.ContentType = "application/x-ndjson"
+	enc := json.NewEncoder(w)
+	count := 0
+	err = s.StreamLogs(ctx, params, accessToken, func(result map[string]any) error {
+		if err := enc.Encode(result); err != nil {
+			return fmt.Errorf("failed to write gs://%s/%s: %w", bucket, object, err)
+		}
+		count++
+		return nil
+	})
+	if err != nil {
+		cancel()
+		_ = w.Close()
+		return 0, err
+	}
+	if err := w.Close(); err != nil {
+		return 0, fmt.Errorf("failed to write gs://%s/%s: %w", bucket, object, err)
+	}
+	return count, nil
+}
+
+// SignExportURL returns a V4 signed URL with which anyone can download the
+// Cloud Storage object gs://bucket/object until expires, e.g. one written by
+// ExportLogs. Signing requires service account credentials: the URL is signed
+// locally with the account's private key if the credentials have one, and
+// otherwise with the IAM signBlob API, which requires
+// iam.serviceAccounts.signBlob on the account.
+func (s *Source) SignExportURL(accessToken, bucket, object string, expires time.Time) (string, error) {
+	client, release, err := s.getStorageClient(accessToken)
+	if err != nil {
+		return "", err
+	}
+	defer release()
+
+	u, err := client.Bucket(bucket).SignedURL(object, &storage.SignedURLOptions{
+		Method:  http.MethodGet,
+		Expires: expires,
+		Scheme:  storage.SigningSchemeV4,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to sign a URL for gs://%s/%s: %w", bucket, object, err)
+	}
+	return u, nil
+}
+
+// groupByTrace reorders results so that entries sharing a trace are adjacent.
+// Groups are ordered by their first entry, and entries keep their relative
+// order within a group. Entries without a trace are left in place relative to
+// the groups.
+func groupByTrace(results []map[string]any) []map[string]any {
+	var keys []string
+	groups := make(map[string][]map[string]any)
+	for i, result := range results {
+		key, ok := result["trace"].(string)
+		if !ok {
+			// Give each entry without a trace its own group.
+			key = fmt.Sprintf("\x00%d", i)
+		}
+		if _, seen := groups[key]; !seen {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], result)
+	}
+	grouped := make([]map[string]any, 0, len(results))
+	for _, key := range keys {
+		grouped = append(grouped, groups[key]...)
+	}
+	return grouped
 }
 
 func setupClientCaching(s *Source, baseCreator LogAdminClientCreator) {
@@ -437,3 +705,47 @@ func newLogAdminClientCreator(
 		return initLogAdminConnectionWithOAuthToken(ctx, tracer, project, name, userAgent, tokenString)
 	}, nil
 }
+
+func newStorageClientCreator(ctx context.Context) (StorageClientCreator, error) {
+	userAgent, err := util.UserAgentFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(tokenString string) (*storage.Client, error) {
+		ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: tokenString})
+		client, err := storage.NewClient(ctx, option.WithUserAgent(userAgent), option.WithTokenSource(ts))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create Cloud Storage client: %w", err)
+		}
+		return client, nil
+	}, nil
+}
+
+// newStorageClientInit returns a function that creates the Cloud Storage
+// client with tokenSource, or if it is nil, with default credentials that
+// have the storage scope.
+func newStorageClientInit(ctx context.Context, tokenSource oauth2.TokenSource) (func() (*storage.Client, error), error) {
+	userAgent, err := util.UserAgentFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return func() (*storage.Client, error) {
+		opts := []option.ClientOption{option.WithUserAgent(userAgent)}
+		if tokenSource != nil {
+			opts = append(opts, option.WithTokenSource(tokenSource))
+		} else {
+			cred, err := google.FindDefaultCredentials(ctx, storage.ScopeReadWrite)
+			if err != nil {
+				return nil, fmt.Errorf("failed to find default Google Cloud credentials with scope %q: %w", storage.ScopeReadWrite, err)
+			}
+			opts = append(opts, option.WithCredentials(cred))
+		}
+		client, err := storage.NewClient(ctx, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create Cloud Storage client: %w", err)
+		}
+		return client, nil
+	}, nil
+}
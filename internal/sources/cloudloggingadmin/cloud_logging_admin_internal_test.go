@@ -0,0 +1,82 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudloggingadmin
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestGroupByTrace(t *testing.T) {
+	entry := func(id, trace string) map[string]any {
+		e := map[string]any{"insertId": id}
+		if trace != "" {
+			e["trace"] = trace
+		}
+		return e
+	}
+	in := []map[string]any{
+		entry("1", "a"),
+		entry("2", "b"),
+		entry("3", ""),
+		entry("4", "a"),
+		entry("5", "b"),
+		entry("6", ""),
+	}
+	want := []map[string]any{
+		entry("1", "a"),
+		entry("4", "a"),
+		entry("2", "b"),
+		entry("5", "b"),
+		entry("3", ""),
+		entry("6", ""),
+	}
+	if diff := cmp.Diff(want, groupByTrace(in)); diff != "" {
+		t.Errorf("groupByTrace() diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestFormatTimestamp(t *testing.T) {
+	tcs := []struct {
+		desc string
+		in   time.Time
+		want string
+	}{
+		{
+			desc: "utc",
+			in:   time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+			want: "2026-01-02T03:04:05Z",
+		},
+		{
+			desc: "non-utc",
+			in:   time.Date(2026, 1, 2, 3, 4, 5, 0, time.FixedZone("PST", -8*60*60)),
+			want: "2026-01-02T11:04:05Z",
+		},
+		{
+			desc: "fractional seconds",
+			in:   time.Date(2026, 1, 2, 3, 4, 5, 123456000, time.FixedZone("CET", 60*60)),
+			want: "2026-01-02T02:04:05.123456Z",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := formatTimestamp(tc.in); got != tc.want {
+				t.Errorf("formatTimestamp() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
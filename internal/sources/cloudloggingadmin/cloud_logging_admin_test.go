@@ -15,12 +15,37 @@ package cloudloggingadmin_test
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	"cloud.google.com/go/logging/apiv2/loggingpb"
+	"cloud.google.com/go/logging/logadmin"
+	"cloud.google.com/go/storage"
 	"github.com/google/go-cmp/cmp"
 	"github.com/googleapis/mcp-toolbox/internal/server"
 	"github.com/googleapis/mcp-toolbox/internal/sources/cloudloggingadmin"
 	"github.com/googleapis/mcp-toolbox/internal/testutils"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/option"
+	monitoredres "google.golang.org/genproto/googleapis/api/monitoredres"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func TestParseFromYamlCloudLoggingAdmin(t *testing.T) {
@@ -81,6 +106,24 @@ func TestParseFromYamlCloudLoggingAdmin(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "with default log limit",
+			in: `
+			kind: source
+			name: my-instance
+			type: cloud-logging-admin
+			project: my-project
+			defaultLogLimit: 20
+			`,
+			want: server.SourceConfigs{
+				"my-instance": cloudloggingadmin.Config{
+					Name:            "my-instance",
+					Type:            cloudloggingadmin.SourceType,
+					Project:         "my-project",
+					DefaultLogLimit: 20,
+				},
+			},
+		},
 	}
 	for _, tc := range tcs {
 		t.Run(tc.desc, func(t *testing.T) {
@@ -135,3 +178,504 @@ func TestFailParseFromYaml(t *testing.T) {
 		})
 	}
 }
+
+// fakeLoggingServer records the ListLogEntries requests it receives and
+// returns its entries in a single page.
+type fakeLoggingServer struct {
+	loggingpb.UnimplementedLoggingServiceV2Server
+	mu      sync.Mutex
+	reqs    []*loggingpb.ListLogEntriesRequest
+	entries []*loggingpb.LogEntry
+}
+
+func (f *fakeLoggingServer) ListLogEntries(ctx context.Context, req *loggingpb.ListLogEntriesRequest) (*loggingpb.ListLogEntriesResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.reqs = append(f.reqs, req)
+	return &loggingpb.ListLogEntriesResponse{Entries: f.entries}, nil
+}
+
+// newFakeLoggingSource starts fake and returns a source whose client uses it.
+func newFakeLoggingSource(t *testing.T, fake *fakeLoggingServer) *cloudloggingadmin.Source {
+	t.Helper()
+	lis, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	srv := grpc.NewServer()
+	loggingpb.RegisterLoggingServiceV2Server(srv, fake)
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	ctx := context.Background()
+	client, err := logadmin.NewClient(ctx, "my-project",
+		option.WithEndpoint(lis.Addr().String()),
+		option.WithoutAuthentication(),
+		option.WithGRPCDialOption(grpc.WithTransportCredentials(insecure.NewCredentials())),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	t.Cleanup(func() { client.Close() })
+	return &cloudloggingadmin.Source{
+		Config: cloudloggingadmin.Config{Name: "my-instance", Type: cloudloggingadmin.SourceType, Project: "my-project"},
+		Client: client,
+	}
+}
+
+func TestQueryLogsRequest(t *testing.T) {
+	ctx := context.Background()
+	fake := &fakeLoggingServer{}
+	source := newFakeLoggingSource(t, fake)
+
+	tcs := []struct {
+		desc         string
+		params       cloudloggingadmin.QueryLogsParams
+		wantOrderBy  string
+		wantPageSize int32
+		// The client appends a default timestamp clause to the filter.
+		wantFilterPrefix string
+	}{
+		{
+			desc:         "oldest first",
+			params:       cloudloggingadmin.QueryLogsParams{Limit: 50},
+			wantOrderBy:  "",
+			wantPageSize: 50,
+		},
+		{
+			desc:         "newest first",
+			params:       cloudloggingadmin.QueryLogsParams{NewestFirst: true, Limit: 50},
+			wantOrderBy:  "timestamp desc",
+			wantPageSize: 50,
+		},
+		{
+			desc:         "limit above max page size",
+			params:       cloudloggingadmin.QueryLogsParams{NewestFirst: true, Limit: 5000},
+			wantOrderBy:  "timestamp desc",
+			wantPageSize: 1000,
+		},
+		{
+			desc:             "application ID",
+			params:           cloudloggingadmin.QueryLogsParams{Filter: `resource.type="cloud_dataproc_batch"`, ApplicationID: "application_1700000000000_0001", Limit: 50},
+			wantPageSize:     50,
+			wantFilterPrefix: `resource.type="cloud_dataproc_batch" AND labels."dataproc.googleapis.com/application_id"="application_1700000000000_0001"`,
+		},
+		{
+			desc:             "minimum severity",
+			params:           cloudloggingadmin.QueryLogsParams{Filter: `resource.type="cloud_dataproc_batch"`, MinSeverity: "WARNING", Limit: 50},
+			wantPageSize:     50,
+			wantFilterPrefix: `resource.type="cloud_dataproc_batch" AND severity>=WARNING AND `,
+		},
+		{
+			desc:             "exact severity",
+			params:           cloudloggingadmin.QueryLogsParams{Severity: "ERROR", Limit: 50},
+			wantPageSize:     50,
+			wantFilterPrefix: `severity=ERROR AND `,
+		},
+		{
+			desc:             "log name",
+			params:           cloudloggingadmin.QueryLogsParams{LogName: "dataproc.googleapis.com/yarn", Limit: 50},
+			wantPageSize:     50,
+			wantFilterPrefix: `logName="projects/my-project/logs/dataproc.googleapis.com%2Fyarn" AND `,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			fake.mu.Lock()
+			fake.reqs = nil
+			fake.mu.Unlock()
+
+			if _, err := source.QueryLogs(ctx, tc.params, ""); err != nil {
+				t.Fatalf("QueryLogs() error = %v", err)
+			}
+
+			fake.mu.Lock()
+			defer fake.mu.Unlock()
+			if len(fake.reqs) != 1 {
+				t.Fatalf("got %d requests, want 1", len(fake.reqs))
+			}
+			req := fake.reqs[0]
+			// An empty orderBy is the API's default, "timestamp asc".
+			if req.OrderBy != tc.wantOrderBy {
+				t.Errorf("got orderBy %q, want %q", req.OrderBy, tc.wantOrderBy)
+			}
+			if req.PageSize != tc.wantPageSize {
+				t.Errorf("got pageSize %d, want %d", req.PageSize, tc.wantPageSize)
+			}
+			if !strings.HasPrefix(req.Filter, tc.wantFilterPrefix) {
+				t.Errorf("got filter %q, want prefix %q", req.Filter, tc.wantFilterPrefix)
+			}
+		})
+	}
+}
+
+func TestBuildFilter(t *testing.T) {
+	source := newFakeLoggingSource(t, &fakeLoggingServer{})
+	tcs := []struct {
+		desc   string
+		params cloudloggingadmin.QueryLogsParams
+		want   string
+	}{
+		{
+			desc:   "empty",
+			params: cloudloggingadmin.QueryLogsParams{},
+			want:   "",
+		},
+		{
+			desc: "all clauses",
+			params: cloudloggingadmin.QueryLogsParams{
+				Filter:    `resource.type="cloud_dataproc_batch"`,
+				TraceID:   "0123456789abcdef0123456789abcdef",
+				LogName:   "dataproc.googleapis.com/yarn",
+				Severity:  "ERROR",
+				StartTime: "2026-01-02T03:00:00Z",
+				EndTime:   "2026-01-02T04:00:00Z",
+			},
+			want: `resource.type="cloud_dataproc_batch" AND trace="projects/my-project/traces/0123456789abcdef0123456789abcdef" AND logName="projects/my-project/logs/dataproc.googleapis.com%2Fyarn" AND severity=ERROR AND timestamp>="2026-01-02T03:00:00Z" AND timestamp<="2026-01-02T04:00:00Z"`,
+		},
+		{
+			desc:   "other project",
+			params: cloudloggingadmin.QueryLogsParams{LogName: "stdout", Project: "other-project"},
+			want:   `logName="projects/other-project/logs/stdout"`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := source.BuildFilter(tc.params); got != tc.want {
+				t.Errorf("BuildFilter() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestStreamLogs(t *testing.T) {
+	ctx := context.Background()
+	fake := &fakeLoggingServer{}
+	for _, id := range []string{"a", "b", "c", "d"} {
+		fake.entries = append(fake.entries, &loggingpb.LogEntry{
+			LogName:   "projects/my-project/logs/my-log",
+			InsertId:  id,
+			Timestamp: timestamppb.New(time.Date(2025, 12, 9, 0, 0, 0, 0, time.UTC)),
+			Resource:  &monitoredres.MonitoredResource{Type: "global"},
+			Payload:   &loggingpb.LogEntry_TextPayload{TextPayload: "entry " + id},
+		})
+	}
+	source := newFakeLoggingSource(t, fake)
+
+	errStop := errors.New("stop")
+	var got []any
+	err := source.StreamLogs(ctx, cloudloggingadmin.QueryLogsParams{Limit: 10}, "", func(result map[string]any) error {
+		got = append(got, result["payload"])
+		if len(got) == 2 {
+			return errStop
+		}
+		return nil
+	})
+	if !errors.Is(err, errStop) {
+		t.Fatalf("StreamLogs() error = %v, want %v", err, errStop)
+	}
+	if diff := cmp.Diff([]any{"entry a", "entry b"}, got); diff != "" {
+		t.Errorf("incorrect streamed entries (-want +got):\n%s", diff)
+	}
+
+	results, err := source.QueryLogs(ctx, cloudloggingadmin.QueryLogsParams{Limit: 3}, "")
+	if err != nil {
+		t.Fatalf("QueryLogs() error = %v", err)
+	}
+	if len(results) != 3 {
+		t.Errorf("QueryLogs() returned %d entries, want 3", len(results))
+	}
+}
+
+func TestQueryLogsIncludeLabels(t *testing.T) {
+	ctx := context.Background()
+	labels := map[string]string{"dataproc.googleapis.com/process_id": "executor-1"}
+	fake := &fakeLoggingServer{entries: []*loggingpb.LogEntry{{
+		LogName:   "projects/my-project/logs/my-log",
+		Timestamp: timestamppb.New(time.Date(2025, 12, 9, 0, 0, 0, 0, time.UTC)),
+		Resource:  &monitoredres.MonitoredResource{Type: "cloud_dataproc_batch"},
+		Labels:    labels,
+	}}}
+	source := newFakeLoggingSource(t, fake)
+
+	for _, tc := range []struct {
+		desc   string
+		params cloudloggingadmin.QueryLogsParams
+		want   any
+	}{
+		{desc: "default", params: cloudloggingadmin.QueryLogsParams{Limit: 1}},
+		{desc: "include labels", params: cloudloggingadmin.QueryLogsParams{Limit: 1, IncludeLabels: true}, want: labels},
+		{desc: "verbose", params: cloudloggingadmin.QueryLogsParams{Limit: 1, Verbose: true}, want: labels},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			results, err := source.QueryLogs(ctx, tc.params, "")
+			if err != nil {
+				t.Fatalf("QueryLogs() error = %v", err)
+			}
+			if len(results) != 1 {
+				t.Fatalf("QueryLogs() returned %d entries, want 1", len(results))
+			}
+			if diff := cmp.Diff(tc.want, results[0]["labels"]); diff != "" {
+				t.Errorf("incorrect labels (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// fakeStorageServer records the objects uploaded to it, or fails uploads
+// with status if it is set.
+type fakeStorageServer struct {
+	mu      sync.Mutex
+	status  int
+	objects map[string]string
+}
+
+func (f *fakeStorageServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.status != 0 {
+		http.Error(w, `{"error":{"code":403,"message":"permission denied"}}`, f.status)
+		return
+	}
+	_, params, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	mr := multipart.NewReader(r.Body, params["boundary"])
+	var metadata struct {
+		Bucket      string `json:"bucket"`
+		Name        string `json:"name"`
+		ContentType string `json:"contentType"`
+	}
+	part, err := mr.NextPart()
+	if err == nil {
+		err = json.NewDecoder(part).Decode(&metadata)
+	}
+	if err == nil {
+		part, err = mr.NextPart()
+	}
+	var media []byte
+	if err == nil {
+		media, err = io.ReadAll(part)
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if f.objects == nil {
+		f.objects = make(map[string]string)
+	}
+	f.objects["gs://"+metadata.Bucket+"/"+metadata.Name+" "+metadata.ContentType] = string(media)
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(metadata)
+}
+
+func TestExportLogs(t *testing.T) {
+	ctx := context.Background()
+	fake := &fakeLoggingServer{}
+	for _, id := range []string{"a", "b", "c"} {
+		fake.entries = append(fake.entries, &loggingpb.LogEntry{
+			LogName:   "projects/my-project/logs/my-log",
+			Timestamp: timestamppb.New(time.Date(2025, 12, 9, 0, 0, 0, 0, time.UTC)),
+			Resource:  &monitoredres.MonitoredResource{Type: "global"},
+			Payload:   &loggingpb.LogEntry_TextPayload{TextPayload: "entry " + id},
+		})
+	}
+	source := newFakeLoggingSource(t, fake)
+
+	tcs := []struct {
+		desc        string
+		limit       int
+		status      int
+		wantCount   int
+		wantObjects map[string]string
+		wantErr     bool
+	}{
+		{
+			desc:      "all entries",
+			wantCount: 3,
+			wantObjects: map[string]string{
+				"gs://my-bucket/logs/export.ndjson application/x-ndjson": `{"logName":"projects/my-project/logs/my-log","payload":"entry a","resource":{"labels":null,"type":"global"},"severity":"Default","timestamp":"2025-12-09T00:00:00Z"}
+{"logName":"projects/my-project/logs/my-log","payload":"entry b","resource":{"labels":null,"type":"global"},"severity":"Default","timestamp":"2025-12-09T00:00:00Z"}
+{"logName":"projects/my-project/logs/my-log","payload":"entry c","resource":{"labels":null,"type":"global"},"severity":"Default","timestamp":"2025-12-09T00:00:00Z"}
+`,
+			},
+		},
+		{
+			desc:      "limit",
+			limit:     1,
+			wantCount: 1,
+			wantObjects: map[string]string{
+				"gs://my-bucket/logs/export.ndjson application/x-ndjson": `{"logName":"projects/my-project/logs/my-log","payload":"entry a","resource":{"labels":null,"type":"global"},"severity":"Default","timestamp":"2025-12-09T00:00:00Z"}
+`,
+			},
+		},
+		{
+			desc:    "permission denied",
+			status:  http.StatusForbidden,
+			wantErr: true,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			storageFake := &fakeStorageServer{status: tc.status}
+			srv := httptest.NewServer(storageFake)
+			t.Cleanup(srv.Close)
+			storageClient, err := storage.NewClient(ctx, option.WithEndpoint(srv.URL+"/storage/v1/"), option.WithoutAuthentication())
+			if err != nil {
+				t.Fatalf("failed to create storage client: %v", err)
+			}
+			t.Cleanup(func() { storageClient.Close() })
+			source.StorageClient = storageClient
+
+			count, err := source.ExportLogs(ctx, cloudloggingadmin.QueryLogsParams{Limit: tc.limit}, "", "my-bucket", "logs/export.ndjson")
+			if tc.wantErr {
+				var gErr *googleapi.Error
+				if !errors.As(err, &gErr) || gErr.Code != tc.status {
+					t.Fatalf("ExportLogs() error = %v, want *googleapi.Error with code %d", err, tc.status)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ExportLogs() error = %v", err)
+			}
+			if count != tc.wantCount {
+				t.Errorf("ExportLogs() = %d, want %d", count, tc.wantCount)
+			}
+			if diff := cmp.Diff(tc.wantObjects, storageFake.objects); diff != "" {
+				t.Errorf("incorrect uploaded objects (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// endlessLoggingServer returns a full page of entries, and a token for
+// another, for every ListLogEntries request, after delay. If block is set, it
+// instead waits for the request to be cancelled.
+func TestSignExportURL(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	creds, err := json.Marshal(map[string]string{
+		"type":           "service_account",
+		"client_email":   "exporter@my-project.iam.gserviceaccount.com",
+		"private_key_id": "key-1",
+		"private_key":    string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})),
+		"token_uri":      "https://oauth2.googleapis.com/token",
+	})
+	if err != nil {
+		t.Fatalf("failed to marshal credentials: %v", err)
+	}
+	storageClient, err := storage.NewClient(context.Background(), option.WithAuthCredentialsJSON(option.ServiceAccount, creds))
+	if err != nil {
+		t.Fatalf("failed to create storage client: %v", err)
+	}
+	t.Cleanup(func() { storageClient.Close() })
+	source := &cloudloggingadmin.Source{StorageClient: storageClient}
+
+	got, err := source.SignExportURL("", "my-bucket", "logs/export.ndjson", time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatalf("SignExportURL() error = %v", err)
+	}
+	u, err := url.Parse(got)
+	if err != nil {
+		t.Fatalf("failed to parse signed URL %q: %v", got, err)
+	}
+	if want := "https://storage.googleapis.com/my-bucket/logs/export.ndjson"; u.Scheme+"://"+u.Host+u.Path != want {
+		t.Errorf("got signed URL %q, want it to be for %q", got, want)
+	}
+	q := u.Query()
+	if got, want := q.Get("X-Goog-Algorithm"), "GOOG4-RSA-SHA256"; got != want {
+		t.Errorf("got X-Goog-Algorithm %q, want %q", got, want)
+	}
+	if got := q.Get("X-Goog-Credential"); !strings.HasPrefix(got, "exporter@my-project.iam.gserviceaccount.com/") {
+		t.Errorf("got X-Goog-Credential %q, want the service account's", got)
+	}
+	if q.Get("X-Goog-Signature") == "" {
+		t.Errorf("got signed URL %q without a signature", got)
+	}
+}
+
+type endlessLoggingServer struct {
+	loggingpb.UnimplementedLoggingServiceV2Server
+	delay time.Duration
+	block bool
+}
+
+func (f *endlessLoggingServer) ListLogEntries(ctx context.Context, req *loggingpb.ListLogEntriesRequest) (*loggingpb.ListLogEntriesResponse, error) {
+	if f.block {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	time.Sleep(f.delay)
+	entries := make([]*loggingpb.LogEntry, req.GetPageSize())
+	for i := range entries {
+		entries[i] = &loggingpb.LogEntry{
+			LogName:   "projects/my-project/logs/my-log",
+			Timestamp: timestamppb.New(time.Date(2025, 12, 9, 0, 0, 0, 0, time.UTC)),
+			Resource:  &monitoredres.MonitoredResource{Type: "global"},
+			Payload:   &loggingpb.LogEntry_TextPayload{TextPayload: "message"},
+		}
+	}
+	return &loggingpb.ListLogEntriesResponse{Entries: entries, NextPageToken: "more"}, nil
+}
+
+func TestQueryLogsDeadline(t *testing.T) {
+	tcs := []struct {
+		desc    string
+		logging *endlessLoggingServer
+		wantErr bool
+	}{
+		{desc: "partial result", logging: &endlessLoggingServer{delay: 20 * time.Millisecond}},
+		{desc: "no entries before deadline", logging: &endlessLoggingServer{block: true}, wantErr: true},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			lis, err := net.Listen("tcp", "localhost:0")
+			if err != nil {
+				t.Fatalf("failed to listen: %v", err)
+			}
+			srv := grpc.NewServer()
+			loggingpb.RegisterLoggingServiceV2Server(srv, tc.logging)
+			go func() { _ = srv.Serve(lis) }()
+			t.Cleanup(srv.Stop)
+
+			client, err := logadmin.NewClient(context.Background(), "my-project",
+				option.WithEndpoint(lis.Addr().String()),
+				option.WithoutAuthentication(),
+				option.WithGRPCDialOption(grpc.WithTransportCredentials(insecure.NewCredentials())),
+			)
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+			t.Cleanup(func() { client.Close() })
+			source := &cloudloggingadmin.Source{
+				Config: cloudloggingadmin.Config{Name: "my-instance", Type: cloudloggingadmin.SourceType, Project: "my-project"},
+				Client: client,
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+			defer cancel()
+			start := time.Now()
+			// Reading all of the entries would take 100 pages, i.e. 2s.
+			got, err := source.QueryLogs(ctx, cloudloggingadmin.QueryLogsParams{Limit: 100000}, "")
+			if elapsed := time.Since(start); elapsed > time.Second {
+				t.Errorf("QueryLogs() took %s after a 200ms deadline", elapsed)
+			}
+			if tc.wantErr {
+				if err == nil {
+					t.Errorf("QueryLogs() returned %d entries, want error", len(got))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("QueryLogs() error = %v", err)
+			}
+			if len(got) == 0 || len(got) >= 100000 {
+				t.Errorf("QueryLogs() returned %d entries, want those read before the deadline", len(got))
+			}
+		})
+	}
+}
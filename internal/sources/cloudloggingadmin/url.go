@@ -0,0 +1,40 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudloggingadmin
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// logEntryURLWindow is how far either side of an entry's timestamp the Logs
+// Explorer view linked by LogEntryURL extends.
+const logEntryURLWindow = 1 * time.Minute
+
+// LogEntryURL builds a URL to the Logs Explorer in the Google Cloud Console
+// showing the log logName around the given timestamp, with the cursor at that
+// timestamp.
+func LogEntryURL(projectID, logName string, timestamp time.Time) string {
+	query := fmt.Sprintf("logName=%q", logName)
+	start := timestamp.Add(-logEntryURLWindow).UTC().Format(time.RFC3339Nano)
+	end := timestamp.Add(logEntryURLWindow).UTC().Format(time.RFC3339Nano)
+	cursor := timestamp.UTC().Format(time.RFC3339Nano)
+
+	v := url.Values{}
+	v.Add("project", projectID)
+	return fmt.Sprintf("https://console.cloud.google.com/logs/query;query=%s;cursorTimestamp=%s;startTime=%s;endTime=%s?%s",
+		url.PathEscape(query), cursor, start, end, v.Encode())
+}
@@ -0,0 +1,31 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudloggingadmin_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/googleapis/mcp-toolbox/internal/sources/cloudloggingadmin"
+)
+
+func TestLogEntryURL(t *testing.T) {
+	ts := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	got := cloudloggingadmin.LogEntryURL("my-project", "projects/my-project/logs/app", ts)
+	want := "https://console.cloud.google.com/logs/query;query=logName=%22projects%2Fmy-project%2Flogs%2Fapp%22;cursorTimestamp=2026-01-02T03:04:05Z;startTime=2026-01-02T03:03:05Z;endTime=2026-01-02T03:05:05Z?project=my-project"
+	if got != want {
+		t.Errorf("LogEntryURL() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataproc
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"strings"
+
+	"cloud.google.com/go/dataproc/v2/apiv1/dataprocpb"
+	"github.com/goccy/go-yaml"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// ParseClusterConfigYAML parses a cluster config in the format produced by
+// ClusterConfigYAML. Unknown fields and output-only fields are rejected.
+func ParseClusterConfigYAML(s string) (*dataprocpb.ClusterConfig, error) {
+	jsonBytes, err := yaml.YAMLToJSON([]byte(s))
+	if err != nil {
+		return nil, fmt.Errorf("config is not valid YAML: %w", err)
+	}
+	config := &dataprocpb.ClusterConfig{}
+	if err := protojson.Unmarshal(jsonBytes, config); err != nil {
+		return nil, fmt.Errorf("config is not a valid cluster config: %w", err)
+	}
+	if fields := outputOnlyFields(config); len(fields) > 0 {
+		return nil, fmt.Errorf("config sets output-only fields: %s", strings.Join(fields, ", "))
+	}
+	return config, nil
+}
+
+// outputOnlyFields returns the paths of the fields cleared by stripOutputOnly
+// that are set in config.
+func outputOnlyFields(config *dataprocpb.ClusterConfig) []string {
+	var fields []string
+	for _, g := range instanceGroups(config) {
+		if len(g.ig.GetInstanceNames()) > 0 {
+			fields = append(fields, g.path+".instanceNames")
+		}
+		if len(g.ig.GetInstanceReferences()) > 0 {
+			fields = append(fields, g.path+".instanceReferences")
+		}
+		if g.ig.GetIsPreemptible() {
+			fields = append(fields, g.path+".isPreemptible")
+		}
+		if g.ig.GetManagedGroupConfig() != nil {
+			fields = append(fields, g.path+".managedGroupConfig")
+		}
+		if len(g.ig.GetInstanceFlexibilityPolicy().GetInstanceSelectionResults()) > 0 {
+			fields = append(fields, g.path+".instanceFlexibilityPolicy.instanceSelectionResults")
+		}
+	}
+	if len(config.GetEndpointConfig().GetHttpPorts()) > 0 {
+		fields = append(fields, "endpointConfig.httpPorts")
+	}
+	if config.GetLifecycleConfig().GetIdleStartTime() != nil {
+		fields = append(fields, "lifecycleConfig.idleStartTime")
+	}
+	return fields
+}
+
+// CreateClusterFromConfig starts creating a cluster with the given name and
+// config, labeled with the source's default labels. It returns the name of the create operation and the console URL of
+// the cluster, without waiting for the cluster to be running.
+func (s *Source) CreateClusterFromConfig(ctx context.Context, clusterName string, config *dataprocpb.ClusterConfig) (map[string]any, error) {
+	req := &dataprocpb.CreateClusterRequest{
+		ProjectId: s.Project,
+		Region:    s.Region,
+		Cluster: &dataprocpb.Cluster{
+			ProjectId:   s.Project,
+			ClusterName: clusterName,
+			Config:      config,
+			Labels:      maps.Clone(s.DefaultLabels),
+		},
+	}
+	op, err := s.GetClusterControllerClient().CreateCluster(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cluster: %w", err)
+	}
+	return map[string]any{
+		"operation":  op.Name(),
+		"consoleUrl": ClusterConsoleURL(s.Project, s.Region, clusterName),
+	}, nil
+}
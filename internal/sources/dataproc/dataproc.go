@@ -19,6 +19,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	dataproc "cloud.google.com/go/dataproc/v2/apiv1"
@@ -30,6 +31,10 @@ import (
 	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/api/iterator"
 	"google.golang.org/api/option"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/encoding/protojson"
 )
 
@@ -45,7 +50,7 @@ func init() {
 }
 
 func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
-	actual := Config{Name: name}
+	actual := Config{Name: name, MaxRetries: defaultMaxRetries}
 	if err := decoder.DecodeContext(ctx, &actual); err != nil {
 		return nil, err
 	}
@@ -57,8 +62,25 @@ type Config struct {
 	Type    string `yaml:"type" validate:"required"`
 	Project string `yaml:"project" validate:"required"`
 	Region  string `yaml:"region" validate:"required"`
+	// CredentialsFile is the path to a credentials JSON file to use instead of
+	// Application Default Credentials.
+	CredentialsFile string `yaml:"credentialsFile"`
+	// Endpoint overrides the region-derived API endpoint, e.g. to use a
+	// private endpoint or a fake server for testing.
+	Endpoint string `yaml:"endpoint"`
+	// Insecure connects to Endpoint without TLS or authentication, e.g. to
+	// use a local fake server. It requires Endpoint to be set.
+	Insecure bool `yaml:"insecure"`
+	// MaxRetries is the number of times a transient error is retried by the
+	// calls that get or list clusters and jobs.
+	MaxRetries int `yaml:"maxRetries" validate:"gte=0"`
+	// DefaultLabels are added to the clusters created by tools.
+	DefaultLabels map[string]string `yaml:"defaultLabels"`
 }
 
+// maxLabels is the maximum number of labels Dataproc accepts on a cluster.
+const maxLabels = 32
+
 func (r Config) SourceConfigType() string {
 	return SourceType
 }
@@ -68,20 +90,52 @@ func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.So
 	if err != nil {
 		return nil, fmt.Errorf("error in User Agent retrieval: %s", err)
 	}
+	if err := util.ValidateLabels(r.DefaultLabels, maxLabels); err != nil {
+		return nil, fmt.Errorf("invalid defaultLabels: %w", err)
+	}
 	endpoint := fmt.Sprintf("%s-dataproc.googleapis.com:443", r.Region)
-	client, err := dataproc.NewClusterControllerClient(ctx, option.WithEndpoint(endpoint), option.WithUserAgent(ua))
+	if r.Endpoint != "" {
+		endpoint = r.Endpoint
+	}
+	opts := []option.ClientOption{option.WithEndpoint(endpoint), option.WithUserAgent(ua)}
+	if r.Insecure {
+		// Never send plaintext to the default endpoint.
+		if r.Endpoint == "" {
+			return nil, fmt.Errorf("insecure requires endpoint to be set")
+		}
+		opts = append(opts,
+			option.WithoutAuthentication(),
+			option.WithGRPCDialOption(grpc.WithTransportCredentials(insecure.NewCredentials())),
+		)
+	}
+	if r.CredentialsFile != "" {
+		creds, err := sources.CredentialsFromFile(ctx, r.CredentialsFile)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, option.WithCredentials(creds))
+	}
+	client, err := dataproc.NewClusterControllerClient(ctx, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create dataproc client: %w", err)
 	}
-	opsClient, err := longrunning.NewOperationsClient(ctx, option.WithEndpoint(endpoint), option.WithUserAgent(ua))
+	opsClient, err := longrunning.NewOperationsClient(ctx, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create longrunning client: %w", err)
 	}
-	jobClient, err := dataproc.NewJobControllerClient(ctx, option.WithEndpoint(endpoint), option.WithUserAgent(ua))
+	jobClient, err := dataproc.NewJobControllerClient(ctx, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create dataproc job client: %w", err)
 	}
 
+	// Only calls that read are retried; retrying a create could create a
+	// second cluster. ListClusters retries in listClusters instead, resuming
+	// at the page that failed.
+	retry := retryOption(r.MaxRetries)
+	client.CallOptions.GetCluster = append(client.CallOptions.GetCluster, retry)
+	jobClient.CallOptions.GetJob = append(jobClient.CallOptions.GetJob, retry)
+	jobClient.CallOptions.ListJobs = append(jobClient.CallOptions.ListJobs, retry)
+
 	s := &Source{
 		Config:    r,
 		Client:    client,
@@ -98,6 +152,9 @@ type Source struct {
 	Client    *dataproc.ClusterControllerClient
 	OpsClient *longrunning.OperationsClient
 	JobClient *dataproc.JobControllerClient
+
+	closeOnce sync.Once
+	closeErr  error
 }
 
 func (s *Source) SourceType() string {
@@ -108,6 +165,14 @@ func (s *Source) ToConfig() sources.SourceConfig {
 	return s.Config
 }
 
+func (s *Source) GetProject() string {
+	return s.Project
+}
+
+func (s *Source) GetRegion() string {
+	return s.Region
+}
+
 func (s *Source) GetClusterControllerClient() *dataproc.ClusterControllerClient {
 	return s.Client
 }
@@ -120,8 +185,28 @@ func (s *Source) GetJobControllerClient() *dataproc.JobControllerClient {
 	return s.JobClient
 }
 
+// Close closes the underlying gRPC clients. It is safe to call Close more than
+// once; subsequent calls return the result of the first.
 func (s *Source) Close() error {
-	return errors.Join(s.Client.Close(), s.OpsClient.Close(), s.JobClient.Close())
+	s.closeOnce.Do(func() {
+		s.closeErr = errors.Join(s.Client.Close(), s.OpsClient.Close(), s.JobClient.Close())
+	})
+	return s.closeErr
+}
+
+// HealthCheck verifies that the Dataproc API is reachable with the configured
+// endpoint and credentials by making a lightweight list call.
+func (s *Source) HealthCheck(ctx context.Context) error {
+	req := &dataprocpb.ListClustersRequest{
+		ProjectId: s.Project,
+		Region:    s.Region,
+		PageSize:  1,
+	}
+	it := s.GetClusterControllerClient().ListClusters(ctx, req)
+	if _, err := it.Next(); err != nil && err != iterator.Done {
+		return fmt.Errorf("dataproc health check failed: %w", err)
+	}
+	return nil
 }
 
 // ListClustersResponse is the response from the list clusters API.
@@ -142,8 +227,6 @@ type Cluster struct {
 
 // ListClusters executes the list clusters operation.
 func (s *Source) ListClusters(ctx context.Context, pageSize *int, pageToken, filter string) (any, error) {
-	client := s.GetClusterControllerClient()
-
 	req := &dataprocpb.ListClustersRequest{
 		ProjectId: s.Project,
 		Region:    s.Region,
@@ -159,18 +242,27 @@ func (s *Source) ListClusters(ctx context.Context, pageSize *int, pageToken, fil
 		req.Filter = filter
 	}
 
-	it := client.ListClusters(ctx, req)
-	ps := 0
-	if pageSize != nil {
-		ps = *pageSize
-	}
-	pager := iterator.NewPager(it, ps, req.PageToken)
+	it := s.listClusters(ctx, req)
 
+	// Collect the clusters of one response, as an iterator.Pager would. The
+	// API may return fewer clusters than the page size, but the page must
+	// still end with the response: the next page token follows it, so any
+	// clusters left over from it would never be returned.
 	clusterPbs := []*dataprocpb.Cluster{}
-	nextPageToken, err := pager.NextPage(&clusterPbs)
-	if err != nil {
-		return nil, fmt.Errorf("failed to list clusters: %w", err)
+	for {
+		clusterPb, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list clusters: %w", err)
+		}
+		clusterPbs = append(clusterPbs, clusterPb)
+		if it.PageInfo().Remaining() == 0 {
+			break
+		}
 	}
+	nextPageToken := it.PageInfo().Token
 
 	clusters, err := ToClusters(clusterPbs, s.Region)
 	if err != nil {
@@ -180,6 +272,35 @@ func (s *Source) ListClusters(ctx context.Context, pageSize *int, pageToken, fil
 	return ListClustersResponse{Clusters: clusters, NextPageToken: nextPageToken}, nil
 }
 
+// SummarizeClusters counts the clusters matching filter by state, e.g.
+// {"RUNNING": 3, "ERROR": 1}, paging through all of them. If pageSize is
+// non-nil, it sets the size of the pages fetched, so that large listings can
+// take fewer round-trips.
+func (s *Source) SummarizeClusters(ctx context.Context, pageSize *int, filter string) (map[string]int, error) {
+	req := &dataprocpb.ListClustersRequest{
+		ProjectId: s.Project,
+		Region:    s.Region,
+		Filter:    filter,
+	}
+	if pageSize != nil {
+		req.PageSize = int32(*pageSize)
+	}
+
+	counts := map[string]int{}
+	it := s.listClusters(ctx, req)
+	for {
+		clusterPb, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list clusters: %w", err)
+		}
+		counts[clusterPb.GetStatus().GetState().String()]++
+	}
+	return counts, nil
+}
+
 // ToClusters converts a slice of protobuf Cluster messages to a slice of Cluster structs.
 func ToClusters(clusterPbs []*dataprocpb.Cluster, region string) ([]Cluster, error) {
 	clusters := make([]Cluster, 0, len(clusterPbs))
@@ -226,7 +347,39 @@ func (s *Source) GetCluster(ctx context.Context, clusterName string) (any, error
 	if err != nil {
 		return nil, fmt.Errorf("failed to get cluster: %w", err)
 	}
+	return s.clusterResult(clusterPb)
+}
+
+// GetClusterByUUID gets the cluster with the given UUID. Since cluster names
+// may be reused after deletion, the UUID identifies a cluster unambiguously.
+//
+// The list clusters API cannot filter by UUID, so this lists the clusters in
+// the region and returns the match. A NotFound error is returned if there is
+// no cluster with the given UUID.
+func (s *Source) GetClusterByUUID(ctx context.Context, clusterUUID string) (any, error) {
+	req := &dataprocpb.ListClustersRequest{
+		ProjectId: s.Project,
+		Region:    s.Region,
+	}
+	it := s.listClusters(ctx, req)
+	for {
+		clusterPb, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list clusters: %w", err)
+		}
+		if clusterPb.ClusterUuid == clusterUUID {
+			return s.clusterResult(clusterPb)
+		}
+	}
+	return nil, status.Errorf(codes.NotFound, "no cluster with UUID %q found in projects/%s/regions/%s", clusterUUID, s.Project, s.Region)
+}
 
+// clusterResult wraps the JSON representation of clusterPb with its console
+// and logs URLs.
+func (s *Source) clusterResult(clusterPb *dataprocpb.Cluster) (any, error) {
 	jsonBytes, err := protojson.Marshal(clusterPb)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal cluster to JSON: %w", err)
@@ -16,12 +16,26 @@ package dataproc_test
 
 import (
 	"context"
+	"fmt"
+	"net"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"testing"
 
+	dataprocapi "cloud.google.com/go/dataproc/v2/apiv1"
+	"cloud.google.com/go/dataproc/v2/apiv1/dataprocpb"
+	longrunning "cloud.google.com/go/longrunning/autogen"
+	"cloud.google.com/go/longrunning/autogen/longrunningpb"
 	"github.com/google/go-cmp/cmp"
 	"github.com/googleapis/mcp-toolbox/internal/server"
 	"github.com/googleapis/mcp-toolbox/internal/sources/dataproc"
 	"github.com/googleapis/mcp-toolbox/internal/testutils"
+	"github.com/googleapis/mcp-toolbox/internal/util"
+	"google.golang.org/api/option"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/testing/protocmp"
 )
 
 func TestParseFromYamlDataproc(t *testing.T) {
@@ -41,10 +55,95 @@ func TestParseFromYamlDataproc(t *testing.T) {
 			`,
 			want: server.SourceConfigs{
 				"my-instance": dataproc.Config{
-					Name:    "my-instance",
-					Type:    dataproc.SourceType,
-					Project: "my-project",
-					Region:  "my-region",
+					Name:       "my-instance",
+					Type:       dataproc.SourceType,
+					Project:    "my-project",
+					Region:     "my-region",
+					MaxRetries: 3,
+				},
+			},
+		},
+		{
+			desc: "with credentials file",
+			in: `
+				kind: source
+				name: my-instance
+				type: dataproc
+				project: my-project
+				region: my-region
+				credentialsFile: /etc/toolbox/key.json
+			`,
+			want: server.SourceConfigs{
+				"my-instance": dataproc.Config{
+					Name:            "my-instance",
+					Type:            dataproc.SourceType,
+					Project:         "my-project",
+					Region:          "my-region",
+					CredentialsFile: "/etc/toolbox/key.json",
+					MaxRetries:      3,
+				},
+			},
+		},
+		{
+			desc: "with endpoint",
+			in: `
+				kind: source
+				name: my-instance
+				type: dataproc
+				project: my-project
+				region: my-region
+				endpoint: dataproc.example.com:443
+			`,
+			want: server.SourceConfigs{
+				"my-instance": dataproc.Config{
+					Name:       "my-instance",
+					Type:       dataproc.SourceType,
+					Project:    "my-project",
+					Region:     "my-region",
+					Endpoint:   "dataproc.example.com:443",
+					MaxRetries: 3,
+				},
+			},
+		},
+		{
+			desc: "retries disabled",
+			in: `
+				kind: source
+				name: my-instance
+				type: dataproc
+				project: my-project
+				region: my-region
+				maxRetries: 0
+			`,
+			want: server.SourceConfigs{
+				"my-instance": dataproc.Config{
+					Name:       "my-instance",
+					Type:       dataproc.SourceType,
+					Project:    "my-project",
+					Region:     "my-region",
+					MaxRetries: 0,
+				},
+			},
+		},
+		{
+			desc: "with default labels",
+			in: `
+				kind: source
+				name: my-instance
+				type: dataproc
+				project: my-project
+				region: my-region
+				defaultLabels:
+				  team: data-eng
+			`,
+			want: server.SourceConfigs{
+				"my-instance": dataproc.Config{
+					Name:          "my-instance",
+					Type:          dataproc.SourceType,
+					Project:       "my-project",
+					Region:        "my-region",
+					MaxRetries:    3,
+					DefaultLabels: map[string]string{"team": "data-eng"},
 				},
 			},
 		},
@@ -115,3 +214,328 @@ func TestFailParseFromYaml(t *testing.T) {
 		})
 	}
 }
+
+func TestCloseIdempotent(t *testing.T) {
+	ctx := context.Background()
+	opts := []option.ClientOption{option.WithEndpoint("localhost:0"), option.WithoutAuthentication()}
+	client, err := dataprocapi.NewClusterControllerClient(ctx, opts...)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	opsClient, err := longrunning.NewOperationsClient(ctx, opts...)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	jobClient, err := dataprocapi.NewJobControllerClient(ctx, opts...)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	s := &dataproc.Source{Client: client, OpsClient: opsClient, JobClient: jobClient}
+	if err := s.Close(); err != nil {
+		t.Fatalf("first Close() returned error: %s", err)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatalf("second Close() returned error: %s", err)
+	}
+}
+
+func TestInitializeMissingCredentialsFile(t *testing.T) {
+	ctx := util.WithUserAgent(context.Background(), "test")
+	path := filepath.Join(t.TempDir(), "missing.json")
+	cfg := dataproc.Config{Name: "my-instance", Type: dataproc.SourceType, Project: "my-project", Region: "my-region", CredentialsFile: path}
+	_, err := cfg.Initialize(ctx, nil)
+	if err == nil || !strings.Contains(err.Error(), "failed to read credentials file") {
+		t.Fatalf("Initialize() error = %v, want credentials file read error", err)
+	}
+}
+
+func TestInitializeInvalidDefaultLabels(t *testing.T) {
+	ctx := util.WithUserAgent(context.Background(), "test")
+	cfg := dataproc.Config{Name: "my-instance", Type: dataproc.SourceType, Project: "my-project", Region: "my-region", DefaultLabels: map[string]string{"team": "Data Eng"}}
+	_, err := cfg.Initialize(ctx, nil)
+	if err == nil || !strings.Contains(err.Error(), `invalid defaultLabels: invalid value "Data Eng" for label "team"`) {
+		t.Fatalf("Initialize() error = %v, want invalid defaultLabels error", err)
+	}
+}
+
+func TestInitializeInsecureRequiresEndpoint(t *testing.T) {
+	ctx := util.WithUserAgent(context.Background(), "test")
+	cfg := dataproc.Config{Name: "my-instance", Type: dataproc.SourceType, Project: "my-project", Region: "my-region", Insecure: true}
+	_, err := cfg.Initialize(ctx, nil)
+	if err == nil || !strings.Contains(err.Error(), "insecure requires endpoint") {
+		t.Fatalf("Initialize() error = %v, want insecure without endpoint error", err)
+	}
+}
+
+// fakeClusterController returns a single cluster from ListClusters.
+type fakeClusterController struct {
+	dataprocpb.UnimplementedClusterControllerServer
+}
+
+func (f *fakeClusterController) ListClusters(ctx context.Context, req *dataprocpb.ListClustersRequest) (*dataprocpb.ListClustersResponse, error) {
+	return &dataprocpb.ListClustersResponse{
+		Clusters: []*dataprocpb.Cluster{{ProjectId: req.ProjectId, ClusterName: "my-cluster"}},
+	}, nil
+}
+
+func TestInitializeInsecureEndpoint(t *testing.T) {
+	lis, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	srv := grpc.NewServer()
+	dataprocpb.RegisterClusterControllerServer(srv, &fakeClusterController{})
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	ctx := util.WithUserAgent(context.Background(), "test")
+	cfg := dataproc.Config{
+		Name:     "my-instance",
+		Type:     dataproc.SourceType,
+		Project:  "my-project",
+		Region:   "my-region",
+		Endpoint: lis.Addr().String(),
+		Insecure: true,
+	}
+	s, err := cfg.Initialize(ctx, nil)
+	if err != nil {
+		t.Fatalf("Initialize() error = %v", err)
+	}
+	source := s.(*dataproc.Source)
+	t.Cleanup(func() { source.Close() })
+
+	resp, err := source.ListClusters(ctx, nil, "", "")
+	if err != nil {
+		t.Fatalf("ListClusters() error = %v", err)
+	}
+	clusters := resp.(dataproc.ListClustersResponse).Clusters
+	if len(clusters) != 1 || clusters[0].Name != "projects/my-project/regions/my-region/clusters/my-cluster" {
+		t.Errorf("ListClusters() = %+v, want my-cluster", clusters)
+	}
+}
+
+// pagedClusterController returns the clusters with the given states from
+// ListClusters, two per page unless the request sets a page size, and at most
+// maxPageSize per page if it is set. It records the requested page sizes.
+type pagedClusterController struct {
+	dataprocpb.UnimplementedClusterControllerServer
+	states      []dataprocpb.ClusterStatus_State
+	maxPageSize int
+	pageSizes   []int32
+}
+
+func (f *pagedClusterController) ListClusters(ctx context.Context, req *dataprocpb.ListClustersRequest) (*dataprocpb.ListClustersResponse, error) {
+	start := 0
+	if req.PageToken != "" {
+		start, _ = strconv.Atoi(req.PageToken)
+	}
+	f.pageSizes = append(f.pageSizes, req.PageSize)
+	pageSize := 2
+	if req.PageSize > 0 {
+		pageSize = int(req.PageSize)
+	}
+	if f.maxPageSize > 0 {
+		pageSize = min(pageSize, f.maxPageSize)
+	}
+	end := min(start+pageSize, len(f.states))
+	resp := &dataprocpb.ListClustersResponse{}
+	for i, state := range f.states[start:end] {
+		resp.Clusters = append(resp.Clusters, &dataprocpb.Cluster{
+			ClusterName: fmt.Sprintf("cluster-%d", start+i),
+			Status:      &dataprocpb.ClusterStatus{State: state},
+		})
+	}
+	if end < len(f.states) {
+		resp.NextPageToken = strconv.Itoa(end)
+	}
+	return resp, nil
+}
+
+func TestSummarizeClusters(t *testing.T) {
+	lis, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	srv := grpc.NewServer()
+	controller := &pagedClusterController{states: []dataprocpb.ClusterStatus_State{
+		dataprocpb.ClusterStatus_RUNNING,
+		dataprocpb.ClusterStatus_ERROR,
+		dataprocpb.ClusterStatus_RUNNING,
+		dataprocpb.ClusterStatus_STOPPED,
+		dataprocpb.ClusterStatus_RUNNING,
+	}}
+	dataprocpb.RegisterClusterControllerServer(srv, controller)
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	ctx := util.WithUserAgent(context.Background(), "test")
+	cfg := dataproc.Config{
+		Name:     "my-instance",
+		Type:     dataproc.SourceType,
+		Project:  "my-project",
+		Region:   "my-region",
+		Endpoint: lis.Addr().String(),
+		Insecure: true,
+	}
+	s, err := cfg.Initialize(ctx, nil)
+	if err != nil {
+		t.Fatalf("Initialize() error = %v", err)
+	}
+	source := s.(*dataproc.Source)
+	t.Cleanup(func() { source.Close() })
+
+	want := map[string]int{"RUNNING": 3, "ERROR": 1, "STOPPED": 1}
+	pageSize := 3
+	tcs := []struct {
+		desc          string
+		pageSize      *int
+		wantPageSizes []int32
+	}{
+		{desc: "default page size", wantPageSizes: []int32{0, 0, 0}},
+		{desc: "page size", pageSize: &pageSize, wantPageSizes: []int32{3, 3}},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			controller.pageSizes = nil
+			got, err := source.SummarizeClusters(ctx, tc.pageSize, "")
+			if err != nil {
+				t.Fatalf("SummarizeClusters() error = %v", err)
+			}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("SummarizeClusters() mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantPageSizes, controller.pageSizes); diff != "" {
+				t.Errorf("incorrect requested page sizes (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestListClustersShortPages(t *testing.T) {
+	lis, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	srv := grpc.NewServer()
+	controller := &pagedClusterController{
+		states:      make([]dataprocpb.ClusterStatus_State, 5),
+		maxPageSize: 2,
+	}
+	dataprocpb.RegisterClusterControllerServer(srv, controller)
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	ctx := util.WithUserAgent(context.Background(), "test")
+	cfg := dataproc.Config{
+		Name:     "my-instance",
+		Type:     dataproc.SourceType,
+		Project:  "my-project",
+		Region:   "my-region",
+		Endpoint: lis.Addr().String(),
+		Insecure: true,
+	}
+	s, err := cfg.Initialize(ctx, nil)
+	if err != nil {
+		t.Fatalf("Initialize() error = %v", err)
+	}
+	source := s.(*dataproc.Source)
+	t.Cleanup(func() { source.Close() })
+
+	// The server returns fewer clusters than requested, so each page ends
+	// with a response rather than at the page size.
+	pageSize := 3
+	var got []string
+	var pageLens []int
+	pageToken := ""
+	for {
+		resp, err := source.ListClusters(ctx, &pageSize, pageToken, "")
+		if err != nil {
+			t.Fatalf("ListClusters() error = %v", err)
+		}
+		page := resp.(dataproc.ListClustersResponse)
+		pageLens = append(pageLens, len(page.Clusters))
+		for _, c := range page.Clusters {
+			got = append(got, path.Base(c.Name))
+		}
+		if page.NextPageToken == "" {
+			break
+		}
+		pageToken = page.NextPageToken
+	}
+	want := []string{"cluster-0", "cluster-1", "cluster-2", "cluster-3", "cluster-4"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("listed clusters mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]int{2, 2, 1}, pageLens); diff != "" {
+		t.Errorf("page lengths mismatch (-want +got):\n%s", diff)
+	}
+}
+
+// createClusterController records the CreateCluster request and returns a
+// pending operation.
+type createClusterController struct {
+	dataprocpb.UnimplementedClusterControllerServer
+	req *dataprocpb.CreateClusterRequest
+}
+
+func (f *createClusterController) CreateCluster(ctx context.Context, req *dataprocpb.CreateClusterRequest) (*longrunningpb.Operation, error) {
+	f.req = req
+	return &longrunningpb.Operation{Name: "projects/my-project/regions/my-region/operations/my-op"}, nil
+}
+
+func TestCreateClusterFromConfig(t *testing.T) {
+	lis, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	srv := grpc.NewServer()
+	controller := &createClusterController{}
+	dataprocpb.RegisterClusterControllerServer(srv, controller)
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	ctx := util.WithUserAgent(context.Background(), "test")
+	cfg := dataproc.Config{
+		Name:          "my-instance",
+		Type:          dataproc.SourceType,
+		Project:       "my-project",
+		Region:        "my-region",
+		Endpoint:      lis.Addr().String(),
+		Insecure:      true,
+		DefaultLabels: map[string]string{"team": "data-eng"},
+	}
+	s, err := cfg.Initialize(ctx, nil)
+	if err != nil {
+		t.Fatalf("Initialize() error = %v", err)
+	}
+	source := s.(*dataproc.Source)
+	t.Cleanup(func() { source.Close() })
+
+	config := &dataprocpb.ClusterConfig{
+		MasterConfig: &dataprocpb.InstanceGroupConfig{NumInstances: 1},
+	}
+	got, err := source.CreateClusterFromConfig(ctx, "my-clone", config)
+	if err != nil {
+		t.Fatalf("CreateClusterFromConfig() error = %v", err)
+	}
+	want := map[string]any{
+		"operation":  "projects/my-project/regions/my-region/operations/my-op",
+		"consoleUrl": dataproc.ClusterConsoleURL("my-project", "my-region", "my-clone"),
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("CreateClusterFromConfig() mismatch (-want +got):\n%s", diff)
+	}
+	wantReq := &dataprocpb.CreateClusterRequest{
+		ProjectId: "my-project",
+		Region:    "my-region",
+		Cluster: &dataprocpb.Cluster{
+			ProjectId:   "my-project",
+			ClusterName: "my-clone",
+			Config:      config,
+			Labels:      map[string]string{"team": "data-eng"},
+		},
+	}
+	if diff := cmp.Diff(wantReq, controller.req, protocmp.Transform()); diff != "" {
+		t.Errorf("incorrect request (-want +got):\n%s", diff)
+	}
+}
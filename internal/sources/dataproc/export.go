@@ -0,0 +1,113 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataproc
+
+import (
+	"context"
+	"fmt"
+
+	"cloud.google.com/go/dataproc/v2/apiv1/dataprocpb"
+	"github.com/goccy/go-yaml"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// ExportClusterConfig gets the named cluster and returns its config as YAML
+// that can be used to create a new cluster. Fields populated by the server,
+// such as instance names, are omitted.
+func (s *Source) ExportClusterConfig(ctx context.Context, clusterName string) (map[string]any, error) {
+	req := &dataprocpb.GetClusterRequest{
+		ProjectId:   s.Project,
+		Region:      s.Region,
+		ClusterName: clusterName,
+	}
+	clusterPb, err := s.GetClusterControllerClient().GetCluster(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cluster: %w", err)
+	}
+	config, err := ClusterConfigYAML(clusterPb.GetConfig())
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{
+		"clusterName": clusterPb.GetClusterName(),
+		"config":      config,
+	}, nil
+}
+
+// ClusterConfigYAML serializes config as YAML, using the JSON field names of
+// the REST API, after stripping its output-only fields.
+func ClusterConfigYAML(config *dataprocpb.ClusterConfig) (string, error) {
+	config = proto.Clone(config).(*dataprocpb.ClusterConfig)
+	stripOutputOnly(config)
+
+	jsonBytes, err := protojson.Marshal(config)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal cluster config to JSON: %w", err)
+	}
+	yamlBytes, err := yaml.JSONToYAML(jsonBytes)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal cluster config to YAML: %w", err)
+	}
+	return string(yamlBytes), nil
+}
+
+// stripOutputOnly clears the fields of config that are set by the server and
+// would be rejected or ignored when creating a cluster.
+func stripOutputOnly(config *dataprocpb.ClusterConfig) {
+	for _, g := range instanceGroups(config) {
+		stripInstanceGroup(g.ig)
+	}
+	if ec := config.GetEndpointConfig(); ec != nil {
+		ec.HttpPorts = nil
+	}
+	if lc := config.GetLifecycleConfig(); lc != nil {
+		lc.IdleStartTime = nil
+	}
+}
+
+// namedInstanceGroup is an instance group config and its path within a
+// cluster config.
+type namedInstanceGroup struct {
+	path string
+	ig   *dataprocpb.InstanceGroupConfig
+}
+
+// instanceGroups returns the instance group configs in config, including
+// those of auxiliary node groups.
+func instanceGroups(config *dataprocpb.ClusterConfig) []namedInstanceGroup {
+	groups := []namedInstanceGroup{
+		{"masterConfig", config.GetMasterConfig()},
+		{"workerConfig", config.GetWorkerConfig()},
+		{"secondaryWorkerConfig", config.GetSecondaryWorkerConfig()},
+	}
+	for i, g := range config.GetAuxiliaryNodeGroups() {
+		groups = append(groups, namedInstanceGroup{fmt.Sprintf("auxiliaryNodeGroups[%d].nodeGroup.nodeGroupConfig", i), g.GetNodeGroup().GetNodeGroupConfig()})
+	}
+	return groups
+}
+
+func stripInstanceGroup(ig *dataprocpb.InstanceGroupConfig) {
+	if ig == nil {
+		return
+	}
+	ig.InstanceNames = nil
+	ig.InstanceReferences = nil
+	ig.IsPreemptible = false
+	ig.ManagedGroupConfig = nil
+	if p := ig.GetInstanceFlexibilityPolicy(); p != nil {
+		p.InstanceSelectionResults = nil
+	}
+}
@@ -0,0 +1,150 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataproc
+
+import (
+	"strings"
+	"testing"
+
+	"cloud.google.com/go/dataproc/v2/apiv1/dataprocpb"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestClusterConfigYAML(t *testing.T) {
+	config := &dataprocpb.ClusterConfig{
+		ConfigBucket: "my-bucket",
+		MasterConfig: &dataprocpb.InstanceGroupConfig{
+			NumInstances:   1,
+			MachineTypeUri: "n1-standard-4",
+			InstanceNames:  []string{"my-cluster-m"},
+			InstanceReferences: []*dataprocpb.InstanceReference{
+				{InstanceName: "my-cluster-m", InstanceId: "123"},
+			},
+		},
+		SecondaryWorkerConfig: &dataprocpb.InstanceGroupConfig{
+			NumInstances:  2,
+			IsPreemptible: true,
+			InstanceNames: []string{"my-cluster-sw-a", "my-cluster-sw-b"},
+			ManagedGroupConfig: &dataprocpb.ManagedGroupConfig{
+				InstanceGroupManagerName: "my-cluster-sw",
+			},
+		},
+		SoftwareConfig: &dataprocpb.SoftwareConfig{
+			ImageVersion: "2.2-debian12",
+			Properties:   map[string]string{"spark:spark.executor.cores": "2"},
+		},
+		EndpointConfig: &dataprocpb.EndpointConfig{
+			EnableHttpPortAccess: true,
+			HttpPorts:            map[string]string{"YARN ResourceManager": "https://example.com/yarn/"},
+		},
+		LifecycleConfig: &dataprocpb.LifecycleConfig{
+			IdleStartTime: timestamppb.Now(),
+		},
+	}
+
+	got, err := ClusterConfigYAML(config)
+	if err != nil {
+		t.Fatalf("ClusterConfigYAML() error = %v", err)
+	}
+	want := `configBucket: my-bucket
+masterConfig:
+  numInstances: 1
+  machineTypeUri: n1-standard-4
+secondaryWorkerConfig:
+  numInstances: 2
+softwareConfig:
+  imageVersion: 2.2-debian12
+  properties:
+    spark:spark.executor.cores: "2"
+lifecycleConfig: {}
+endpointConfig:
+  enableHttpPortAccess: true
+`
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("incorrect YAML (-want +got):\n%s", diff)
+	}
+	if len(config.GetMasterConfig().GetInstanceNames()) == 0 {
+		t.Errorf("ClusterConfigYAML() modified its argument")
+	}
+}
+
+func TestParseClusterConfigYAML(t *testing.T) {
+	tcs := []struct {
+		desc       string
+		in         string
+		want       *dataprocpb.ClusterConfig
+		wantSubstr string
+	}{
+		{
+			desc: "exported config",
+			in: `configBucket: my-bucket
+masterConfig:
+  numInstances: 1
+  machineTypeUri: n1-standard-4
+softwareConfig:
+  properties:
+    spark:spark.executor.cores: "2"
+`,
+			want: &dataprocpb.ClusterConfig{
+				ConfigBucket: "my-bucket",
+				MasterConfig: &dataprocpb.InstanceGroupConfig{NumInstances: 1, MachineTypeUri: "n1-standard-4"},
+				SoftwareConfig: &dataprocpb.SoftwareConfig{
+					Properties: map[string]string{"spark:spark.executor.cores": "2"},
+				},
+			},
+		},
+		{
+			desc:       "invalid yaml",
+			in:         "masterConfig: [",
+			wantSubstr: "config is not valid YAML",
+		},
+		{
+			desc:       "unknown field",
+			in:         "masterConfig:\n  numInstance: 1\n",
+			wantSubstr: "config is not a valid cluster config",
+		},
+		{
+			desc: "output-only fields",
+			in: `masterConfig:
+  instanceNames: [my-cluster-m]
+secondaryWorkerConfig:
+  isPreemptible: true
+endpointConfig:
+  httpPorts:
+    Jupyter: https://example.com/jupyter/
+`,
+			wantSubstr: "config sets output-only fields: masterConfig.instanceNames, secondaryWorkerConfig.isPreemptible, endpointConfig.httpPorts",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := ParseClusterConfigYAML(tc.in)
+			if tc.wantSubstr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantSubstr) {
+					t.Fatalf("expected error containing %q, got %v", tc.wantSubstr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseClusterConfigYAML() error = %v", err)
+			}
+			if diff := cmp.Diff(tc.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("incorrect config (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
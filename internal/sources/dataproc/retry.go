@@ -0,0 +1,144 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataproc
+
+import (
+	"context"
+	"time"
+
+	"cloud.google.com/go/dataproc/v2/apiv1/dataprocpb"
+	"github.com/googleapis/gax-go/v2"
+	"google.golang.org/api/iterator"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// defaultMaxRetries is the default number of times a transient error is
+// retried.
+const defaultMaxRetries = 3
+
+// retryBaseDelay is the delay before the first retry; it doubles with each
+// subsequent retry.
+const retryBaseDelay = 500 * time.Millisecond
+
+// clusterIterator is the subset of *dataproc.ClusterIterator used to list
+// clusters.
+type clusterIterator interface {
+	Next() (*dataprocpb.Cluster, error)
+	PageInfo() *iterator.PageInfo
+}
+
+// retryingClusterIterator wraps a clusterIterator, retrying transient errors
+// with exponential backoff.
+//
+// An iterator returns the same error forever once it fails, so a retry
+// restarts the listing at the page that failed to load, using newIterator.
+// Clusters that were already returned are not returned again.
+type retryingClusterIterator struct {
+	ctx         context.Context
+	it          clusterIterator
+	newIterator func(pageToken string) clusterIterator
+	maxRetries  int
+	baseDelay   time.Duration
+}
+
+func (r *retryingClusterIterator) Next() (*dataprocpb.Cluster, error) {
+	for attempt := 0; ; attempt++ {
+		cluster, err := r.it.Next()
+		if err == nil || err == iterator.Done || !isRetryable(err) || attempt >= r.maxRetries {
+			return cluster, err
+		}
+		delay := retryDelay(err, r.baseDelay<<attempt)
+		select {
+		case <-r.ctx.Done():
+			return nil, r.ctx.Err()
+		case <-time.After(delay):
+		}
+		r.it = r.newIterator(r.it.PageInfo().Token)
+	}
+}
+
+func (r *retryingClusterIterator) PageInfo() *iterator.PageInfo {
+	return r.it.PageInfo()
+}
+
+// isRetryable reports whether err is a transient gRPC error, including
+// throttling by a project's quota.
+func isRetryable(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted:
+		return true
+	default:
+		return false
+	}
+}
+
+// retryDelay returns how long to wait before retrying err: backoff, or longer
+// if the server asked to wait longer with a RetryInfo detail, as it does when
+// a quota is exhausted.
+func retryDelay(err error, backoff time.Duration) time.Duration {
+	st, ok := status.FromError(err)
+	if !ok {
+		return backoff
+	}
+	for _, d := range st.Details() {
+		if info, ok := d.(*errdetails.RetryInfo); ok && info.GetRetryDelay() != nil {
+			return max(backoff, info.GetRetryDelay().AsDuration())
+		}
+	}
+	return backoff
+}
+
+// retryer is a gax.Retryer that retries transient errors with exponential
+// backoff, up to maxRetries times.
+type retryer struct {
+	maxRetries int
+	baseDelay  time.Duration
+	attempt    int
+}
+
+func (r *retryer) Retry(err error) (time.Duration, bool) {
+	if !isRetryable(err) || r.attempt >= r.maxRetries {
+		return 0, false
+	}
+	delay := retryDelay(err, r.baseDelay<<r.attempt)
+	r.attempt++
+	return delay, true
+}
+
+// retryOption returns a call option that retries transient errors up to
+// maxRetries times.
+func retryOption(maxRetries int) gax.CallOption {
+	return gax.WithRetry(func() gax.Retryer {
+		return &retryer{maxRetries: maxRetries, baseDelay: retryBaseDelay}
+	})
+}
+
+// listClusters returns an iterator over the clusters matching req that retries
+// transient errors up to the source's configured number of times.
+func (s *Source) listClusters(ctx context.Context, req *dataprocpb.ListClustersRequest) clusterIterator {
+	newIterator := func(pageToken string) clusterIterator {
+		req.PageToken = pageToken
+		return s.GetClusterControllerClient().ListClusters(ctx, req)
+	}
+	return &retryingClusterIterator{
+		ctx:         ctx,
+		it:          newIterator(req.PageToken),
+		newIterator: newIterator,
+		maxRetries:  s.MaxRetries,
+		baseDelay:   retryBaseDelay,
+	}
+}
@@ -0,0 +1,188 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataproc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/dataproc/v2/apiv1/dataprocpb"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/iterator"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// fakeClusterIterator returns a cluster for each non-empty name in names and an
+// error for each entry of errs with the same index, then iterator.Done.
+type fakeClusterIterator struct {
+	names    []string
+	errs     []error
+	pageInfo iterator.PageInfo
+}
+
+func (f *fakeClusterIterator) Next() (*dataprocpb.Cluster, error) {
+	if len(f.names) == 0 {
+		return nil, iterator.Done
+	}
+	name, err := f.names[0], f.errs[0]
+	f.names, f.errs = f.names[1:], f.errs[1:]
+	if err != nil {
+		return nil, err
+	}
+	return &dataprocpb.Cluster{ClusterName: name}, nil
+}
+
+func (f *fakeClusterIterator) PageInfo() *iterator.PageInfo {
+	return &f.pageInfo
+}
+
+func TestRetryingClusterIterator(t *testing.T) {
+	unavailable := status.Error(codes.Unavailable, "unavailable")
+	deadline := status.Error(codes.DeadlineExceeded, "deadline exceeded")
+	exhausted := status.Error(codes.ResourceExhausted, "quota exceeded")
+	denied := status.Error(codes.PermissionDenied, "denied")
+
+	tcs := []struct {
+		desc        string
+		names       []string
+		errs        []error
+		maxRetries  int
+		want        []string
+		wantErr     error
+		wantRetries int
+	}{
+		{
+			desc:  "no errors",
+			names: []string{"a", "b"},
+			errs:  []error{nil, nil},
+			want:  []string{"a", "b"},
+		},
+		{
+			desc:        "transient error then success",
+			names:       []string{"a", "", "", "b"},
+			errs:        []error{nil, unavailable, deadline, nil},
+			maxRetries:  3,
+			want:        []string{"a", "b"},
+			wantRetries: 2,
+		},
+		{
+			desc:        "quota error then success",
+			names:       []string{"a", "", "b"},
+			errs:        []error{nil, exhausted, nil},
+			maxRetries:  3,
+			want:        []string{"a", "b"},
+			wantRetries: 1,
+		},
+		{
+			desc:       "non-retryable error",
+			names:      []string{"a", "", "b"},
+			errs:       []error{nil, denied, nil},
+			maxRetries: 3,
+			want:       []string{"a"},
+			wantErr:    denied,
+		},
+		{
+			desc:        "retries exhausted",
+			names:       []string{"a", "", "", "b"},
+			errs:        []error{nil, unavailable, unavailable, nil},
+			maxRetries:  1,
+			want:        []string{"a"},
+			wantErr:     unavailable,
+			wantRetries: 1,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			fake := &fakeClusterIterator{names: tc.names, errs: tc.errs}
+			fake.pageInfo.Token = "page-token"
+			var gotTokens []string
+			it := &retryingClusterIterator{
+				ctx: context.Background(),
+				it:  fake,
+				newIterator: func(pageToken string) clusterIterator {
+					gotTokens = append(gotTokens, pageToken)
+					return fake
+				},
+				maxRetries: tc.maxRetries,
+			}
+
+			var got []string
+			var err error
+			for {
+				var cluster *dataprocpb.Cluster
+				cluster, err = it.Next()
+				if err != nil {
+					break
+				}
+				got = append(got, cluster.ClusterName)
+			}
+			if tc.wantErr == nil && err != iterator.Done {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tc.wantErr != nil && !errors.Is(err, tc.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("incorrect clusters (-want +got):\n%s", diff)
+			}
+			if len(gotTokens) != tc.wantRetries {
+				t.Errorf("got %d retries, want %d", len(gotTokens), tc.wantRetries)
+			}
+			for _, tok := range gotTokens {
+				if tok != "page-token" {
+					t.Errorf("retry restarted at page token %q, want %q", tok, "page-token")
+				}
+			}
+		})
+	}
+}
+
+// quotaError returns a RESOURCE_EXHAUSTED error asking to retry after delay.
+func quotaError(t *testing.T, delay time.Duration) error {
+	t.Helper()
+	st, err := status.New(codes.ResourceExhausted, "quota exceeded").WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(delay)})
+	if err != nil {
+		t.Fatalf("WithDetails() failed: %v", err)
+	}
+	return st.Err()
+}
+
+func TestRetryer(t *testing.T) {
+	r := &retryer{maxRetries: 3, baseDelay: time.Second}
+
+	// Backoff doubles with each retry, unless the server asks for longer.
+	steps := []struct {
+		err       error
+		wantDelay time.Duration
+		wantRetry bool
+	}{
+		{status.Error(codes.PermissionDenied, "denied"), 0, false},
+		{status.Error(codes.Unavailable, "unavailable"), time.Second, true},
+		{quotaError(t, 30*time.Second), 30 * time.Second, true},
+		{quotaError(t, time.Second), 4 * time.Second, true},
+		{status.Error(codes.Unavailable, "unavailable"), 0, false},
+	}
+	for i, step := range steps {
+		delay, retry := r.Retry(step.err)
+		if delay != step.wantDelay || retry != step.wantRetry {
+			t.Errorf("step %d: Retry(%v) = (%v, %t), want (%v, %t)", i, step.err, delay, retry, step.wantDelay, step.wantRetry)
+		}
+	}
+}
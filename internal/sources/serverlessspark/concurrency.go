@@ -0,0 +1,49 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serverlessspark
+
+import "sync"
+
+// defaultMaxConcurrency is the number of concurrent requests tools that fan
+// out across locations or resources make if maxConcurrency is unset.
+const defaultMaxConcurrency = 4
+
+// GetMaxConcurrency returns the maximum number of concurrent requests a tool
+// that fans out may make.
+func (s *Source) GetMaxConcurrency() int {
+	if s.MaxConcurrency == 0 {
+		return defaultMaxConcurrency
+	}
+	return s.MaxConcurrency
+}
+
+// forEachConcurrently calls fn with each index in [0, n), with at most limit
+// calls running at once, and returns when all calls have returned.
+func forEachConcurrently(n, limit int, fn func(i int)) {
+	sem := make(chan struct{}, max(limit, 1))
+	var wg sync.WaitGroup
+	for i := range n {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			fn(i)
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,59 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serverlessspark
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestForEachConcurrently(t *testing.T) {
+	const n, limit = 20, 3
+	var running, peak atomic.Int32
+	var mu sync.Mutex
+	called := make(map[int]bool)
+	forEachConcurrently(n, limit, func(i int) {
+		cur := running.Add(1)
+		for {
+			p := peak.Load()
+			if cur <= p || peak.CompareAndSwap(p, cur) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		running.Add(-1)
+		mu.Lock()
+		called[i] = true
+		mu.Unlock()
+	})
+	if len(called) != n {
+		t.Errorf("fn called with %d distinct indices, want %d", len(called), n)
+	}
+	if got := peak.Load(); got > limit {
+		t.Errorf("peak concurrency = %d, want at most %d", got, limit)
+	}
+}
+
+func TestGetMaxConcurrency(t *testing.T) {
+	if got := (&Source{}).GetMaxConcurrency(); got != defaultMaxConcurrency {
+		t.Errorf("GetMaxConcurrency() = %d with maxConcurrency unset, want %d", got, defaultMaxConcurrency)
+	}
+	s := &Source{Config: Config{MaxConcurrency: 8}}
+	if got := s.GetMaxConcurrency(); got != 8 {
+		t.Errorf("GetMaxConcurrency() = %d, want 8", got)
+	}
+}
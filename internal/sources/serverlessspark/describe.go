@@ -0,0 +1,61 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serverlessspark
+
+import (
+	"maps"
+	"slices"
+)
+
+// SourceDescription is what a source resolves its configuration to, for
+// debugging. It contains no secrets.
+type SourceDescription struct {
+	Project        string `json:"project"`
+	Location       string `json:"location"`
+	UniverseDomain string `json:"universeDomain"`
+	// Endpoint is the gRPC endpoint the Dataproc clients connect to.
+	Endpoint string `json:"endpoint"`
+	// BaseURL is the base URL of the equivalent Dataproc REST API.
+	BaseURL   string `json:"baseUrl"`
+	UserAgent string `json:"userAgent"`
+	// UsesCredentialsFile is set if a credentials file is configured instead
+	// of Application Default Credentials. Its path is not described, since
+	// it says where a key lives on the server.
+	UsesCredentialsFile bool     `json:"usesCredentialsFile"`
+	AllowedLocations    []string `json:"allowedLocations,omitempty"`
+	// CustomHeaders are the names of the configured custom headers, whose
+	// values may be secret.
+	CustomHeaders []string `json:"customHeaders,omitempty"`
+}
+
+// Describe returns the project, location, and endpoints that the source
+// resolves to.
+func (s *Source) Describe() SourceDescription {
+	var headers []string
+	if len(s.CustomHeaders) > 0 {
+		headers = slices.Sorted(maps.Keys(s.CustomHeaders))
+	}
+	return SourceDescription{
+		Project:             s.Project,
+		Location:            s.Location,
+		UniverseDomain:      universeDomainOrDefault(s.UniverseDomain),
+		Endpoint:            regionalEndpoint(s.Location, s.UniverseDomain),
+		BaseURL:             restEndpoint(s.UniverseDomain),
+		UserAgent:           s.userAgent,
+		UsesCredentialsFile: s.CredentialsFile != "",
+		AllowedLocations:    s.AllowedLocations,
+		CustomHeaders:       headers,
+	}
+}
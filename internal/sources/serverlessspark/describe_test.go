@@ -0,0 +1,72 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serverlessspark
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDescribe(t *testing.T) {
+	tcs := []struct {
+		desc   string
+		config Config
+		want   SourceDescription
+	}{
+		{
+			desc:   "defaults",
+			config: Config{Project: "my-project", Location: "us-central1"},
+			want: SourceDescription{
+				Project:        "my-project",
+				Location:       "us-central1",
+				UniverseDomain: "googleapis.com",
+				Endpoint:       "us-central1-dataproc.googleapis.com:443",
+				BaseURL:        "https://dataproc.googleapis.com/v1",
+				UserAgent:      "genai-toolbox/1.0.0",
+			},
+		},
+		{
+			desc: "configured",
+			config: Config{
+				Project:          "my-project",
+				Location:         "europe-west1",
+				UniverseDomain:   "example.goog",
+				CredentialsFile:  "/etc/creds.json",
+				AllowedLocations: []string{"europe-west1", "us-central1"},
+				CustomHeaders:    map[string]string{"X-Proxy-Token": "secret", "X-Goog-User-Project": "other-project"},
+			},
+			want: SourceDescription{
+				Project:             "my-project",
+				Location:            "europe-west1",
+				UniverseDomain:      "example.goog",
+				Endpoint:            "europe-west1-dataproc.example.goog:443",
+				BaseURL:             "https://dataproc.example.goog/v1",
+				UserAgent:           "genai-toolbox/1.0.0",
+				UsesCredentialsFile: true,
+				AllowedLocations:    []string{"europe-west1", "us-central1"},
+				CustomHeaders:       []string{"X-Goog-User-Project", "X-Proxy-Token"},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			s := &Source{Config: tc.config, userAgent: "genai-toolbox/1.0.0"}
+			if diff := cmp.Diff(tc.want, s.Describe()); diff != "" {
+				t.Errorf("incorrect description (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
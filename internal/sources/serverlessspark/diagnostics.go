@@ -0,0 +1,177 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serverlessspark
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"cloud.google.com/go/dataproc/v2/apiv1/dataprocpb"
+)
+
+// DefaultDiagnosticsMaxBytes is the default limit on the number of bytes of
+// diagnostics returned by GetBatchDiagnostics.
+const DefaultDiagnosticsMaxBytes = 64 * 1024
+
+// MaxDiagnosticsMaxBytes bounds the number of bytes of diagnostics that can be
+// requested from GetBatchDiagnostics, so that a single call cannot return an
+// unbounded amount of content.
+const MaxDiagnosticsMaxBytes = 1024 * 1024
+
+// GetBatchDiagnostics gets the batch with the given full resource name and
+// reads its diagnostics from Cloud Storage, returning at most maxBytes of
+// content; see ReadDiagnostics. If the batch has no diagnostics, the result
+// contains a message explaining why instead.
+func (s *Source) GetBatchDiagnostics(ctx context.Context, name string, maxBytes int) (map[string]any, error) {
+	batchPb, err := s.GetBatchControllerClient().GetBatch(ctx, &dataprocpb.GetBatchRequest{Name: name})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get batch: %w", err)
+	}
+	state := batchPb.GetState().String()
+	uri := batchPb.GetRuntimeInfo().GetDiagnosticOutputUri()
+	if uri == "" {
+		return map[string]any{
+			"name":    name,
+			"state":   state,
+			"message": fmt.Sprintf("Diagnostics are not available for this batch in state %s. Dataproc writes diagnostics for batches that have finished running, typically ones that failed.", state),
+		}, nil
+	}
+
+	bucket, object, err := parseGCSURI(uri)
+	if err != nil {
+		return nil, err
+	}
+	r, err := s.GetStorageClient().Bucket(bucket).Object(object).NewReader(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read diagnostics %s: %w", uri, err)
+	}
+	defer r.Close()
+
+	result, err := ReadDiagnostics(r, maxBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read diagnostics %s: %w", uri, err)
+	}
+	result["name"] = name
+	result["state"] = state
+	result["diagnosticOutputUri"] = uri
+	return result, nil
+}
+
+// parseGCSURI splits a gs://bucket/object URI into its bucket and object.
+func parseGCSURI(uri string) (string, string, error) {
+	bucket, object, ok := strings.Cut(strings.TrimPrefix(uri, "gs://"), "/")
+	if !strings.HasPrefix(uri, "gs://") || !ok || bucket == "" || object == "" {
+		return "", "", fmt.Errorf("invalid Cloud Storage URI %q", uri)
+	}
+	return bucket, object, nil
+}
+
+// ReadDiagnostics reads diagnostics from r, returning at most maxBytes of
+// content.
+//
+// Diagnostics are normally a gzipped tarball, in which case the result lists
+// the files in it under "files", each with its name, size, and as much of its
+// content as fits in what is left of maxBytes. Binary files are listed without
+// content. Otherwise, the result contains the content of r under "content". In
+// both cases "truncated" reports whether any content was left out.
+func ReadDiagnostics(r io.Reader, maxBytes int) (map[string]any, error) {
+	br := bufio.NewReader(r)
+	if magic, _ := br.Peek(2); len(magic) == 2 && magic[0] == 0x1f && magic[1] == 0x8b {
+		gz, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress diagnostics: %w", err)
+		}
+		defer gz.Close()
+		return readDiagnosticsArchive(tar.NewReader(gz), maxBytes)
+	}
+
+	content, truncated, err := readText(br, maxBytes)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{"content": content, "truncated": truncated}, nil
+}
+
+func readDiagnosticsArchive(tr *tar.Reader, maxBytes int) (map[string]any, error) {
+	files := []map[string]any{}
+	remaining := maxBytes
+	truncated := false
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read diagnostics archive: %w", err)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		file := map[string]any{"name": hdr.Name, "size": hdr.Size}
+		files = append(files, file)
+		if hdr.Size == 0 {
+			continue
+		}
+		if remaining == 0 {
+			truncated = true
+			continue
+		}
+		content, fileTruncated, err := readText(tr, remaining)
+		if err == errBinary {
+			file["binary"] = true
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s from diagnostics archive: %w", hdr.Name, err)
+		}
+		file["content"] = content
+		remaining -= len(content)
+		if fileTruncated {
+			file["truncated"] = true
+			truncated = true
+		}
+	}
+	return map[string]any{"files": files, "truncated": truncated}, nil
+}
+
+// errBinary is returned by readText for content that is not UTF-8 text.
+var errBinary = errors.New("content is not text")
+
+// readText reads at most n bytes of text from r, reporting whether there was
+// more. A multi-byte character cut off by the limit is dropped.
+func readText(r io.Reader, n int) (string, bool, error) {
+	buf, err := io.ReadAll(io.LimitReader(r, int64(n)+1))
+	if err != nil {
+		return "", false, err
+	}
+	truncated := len(buf) > n
+	if truncated {
+		buf = buf[:n]
+		for i := 0; i < utf8.UTFMax-1 && len(buf) > 0 && !utf8.Valid(buf); i++ {
+			buf = buf[:len(buf)-1]
+		}
+	}
+	if !utf8.Valid(buf) {
+		return "", false, errBinary
+	}
+	return string(buf), truncated, nil
+}
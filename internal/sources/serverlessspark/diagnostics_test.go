@@ -0,0 +1,121 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serverlessspark_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/mcp-toolbox/internal/sources/serverlessspark"
+)
+
+// tarball returns a gzipped tarball containing the given files, in order.
+func tarball(t *testing.T, files ...[2]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for _, f := range files {
+		if err := tw.WriteHeader(&tar.Header{Name: f[0], Mode: 0o644, Size: int64(len(f[1])), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatalf("failed to write header: %v", err)
+		}
+		if _, err := tw.Write([]byte(f[1])); err != nil {
+			t.Fatalf("failed to write file: %v", err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatalf("failed to close tar writer: %v", err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatalf("failed to close gzip writer: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestReadDiagnostics(t *testing.T) {
+	tcs := []struct {
+		desc     string
+		in       []byte
+		maxBytes int
+		want     map[string]any
+	}{
+		{
+			desc:     "text",
+			in:       []byte("driver failed"),
+			maxBytes: 100,
+			want:     map[string]any{"content": "driver failed", "truncated": false},
+		},
+		{
+			desc:     "truncated text",
+			in:       []byte("driver failed"),
+			maxBytes: 6,
+			want:     map[string]any{"content": "driver", "truncated": true},
+		},
+		{
+			desc:     "truncated multi-byte character",
+			in:       []byte("abcé"),
+			maxBytes: 4,
+			want:     map[string]any{"content": "abc", "truncated": true},
+		},
+		{
+			desc:     "tarball",
+			in:       tarball(t, [2]string{"driver.log", "OOM"}, [2]string{"empty.txt", ""}, [2]string{"core", "\xff\xfe\x00"}),
+			maxBytes: 100,
+			want: map[string]any{
+				"files": []map[string]any{
+					{"name": "driver.log", "size": int64(3), "content": "OOM"},
+					{"name": "empty.txt", "size": int64(0)},
+					{"name": "core", "size": int64(3), "binary": true},
+				},
+				"truncated": false,
+			},
+		},
+		{
+			desc:     "truncated tarball",
+			in:       tarball(t, [2]string{"a.log", "aaaa"}, [2]string{"b.log", "bbbb"}, [2]string{"c.log", "cccc"}),
+			maxBytes: 6,
+			want: map[string]any{
+				"files": []map[string]any{
+					{"name": "a.log", "size": int64(4), "content": "aaaa"},
+					{"name": "b.log", "size": int64(4), "content": "bb", "truncated": true},
+					{"name": "c.log", "size": int64(4)},
+				},
+				"truncated": true,
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := serverlessspark.ReadDiagnostics(bytes.NewReader(tc.in), tc.maxBytes)
+			if err != nil {
+				t.Fatalf("ReadDiagnostics() error = %v", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("incorrect result (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestReadDiagnosticsBinary(t *testing.T) {
+	_, err := serverlessspark.ReadDiagnostics(bytes.NewReader([]byte("\xff\xfe\x00")), 100)
+	if err == nil || !strings.Contains(err.Error(), "not text") {
+		t.Errorf("ReadDiagnostics() error = %v, want not text error", err)
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serverlessspark
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"cloud.google.com/go/dataproc/v2/apiv1/dataprocpb"
+	"cloud.google.com/go/longrunning/autogen/longrunningpb"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// createBatchRequest returns the request to create batch. If batchID is empty,
+// Dataproc generates one.
+func createBatchRequest(project, location, batchID string, batch *dataprocpb.Batch, requestID string) *dataprocpb.CreateBatchRequest {
+	return &dataprocpb.CreateBatchRequest{
+		Parent:    fmt.Sprintf("projects/%s/locations/%s", project, location),
+		Batch:     batch,
+		BatchId:   batchID,
+		RequestId: requestID,
+	}
+}
+
+func createSessionRequest(project, location, sessionID string, session *dataprocpb.Session) *dataprocpb.CreateSessionRequest {
+	return &dataprocpb.CreateSessionRequest{
+		Parent:    fmt.Sprintf("projects/%s/locations/%s", project, location),
+		Session:   session,
+		SessionId: sessionID,
+	}
+}
+
+func cancelOperationRequest(project, location, operation string) *longrunningpb.CancelOperationRequest {
+	return &longrunningpb.CancelOperationRequest{
+		Name: fmt.Sprintf("projects/%s/locations/%s/operations/%s", project, location, operation),
+	}
+}
+
+// CreateBatchDryRun describes the request CreateBatch would send for batch,
+// without sending it. An empty universeDomain means googleapis.com.
+func CreateBatchDryRun(universeDomain, project, location, batchID string, batch *dataprocpb.Batch, requestID string) (map[string]any, error) {
+	req := createBatchRequest(project, location, batchID, batch, requestID)
+	url := fmt.Sprintf("%s/%s/batches", restEndpoint(universeDomain), req.Parent)
+	if batchID == "" {
+		return dryRunResult(req.Parent, url, req)
+	}
+	return dryRunResult(fmt.Sprintf("%s/batches/%s", req.Parent, batchID), url+"?batchId="+batchID, req)
+}
+
+// CreateSessionDryRun describes the request CreateSession would send for
+// session, without sending it. An empty universeDomain means googleapis.com.
+func CreateSessionDryRun(universeDomain, project, location, sessionID string, session *dataprocpb.Session) (map[string]any, error) {
+	req := createSessionRequest(project, location, sessionID, session)
+	name := fmt.Sprintf("%s/sessions/%s", req.Parent, sessionID)
+	return dryRunResult(name, fmt.Sprintf("%s/%s/sessions?sessionId=%s", restEndpoint(universeDomain), req.Parent, sessionID), req)
+}
+
+// CancelOperationDryRun describes the request CancelOperation would send for
+// operation, without sending it. An empty universeDomain means googleapis.com.
+func CancelOperationDryRun(universeDomain, project, location, operation string) (map[string]any, error) {
+	req := cancelOperationRequest(project, location, operation)
+	return dryRunResult(req.Name, fmt.Sprintf("%s/%s:cancel", restEndpoint(universeDomain), req.Name), req)
+}
+
+// dryRunResult returns the result of a dry run: the resource a request targets,
+// the REST method and URL it corresponds to, and the request itself.
+func dryRunResult(resourceName, url string, req proto.Message) (map[string]any, error) {
+	jsonBytes, err := protojson.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request to JSON: %w", err)
+	}
+	var request map[string]any
+	if err := json.Unmarshal(jsonBytes, &request); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal request JSON: %w", err)
+	}
+	return map[string]any{
+		"dryRun":       true,
+		"resourceName": resourceName,
+		"method":       "POST",
+		"url":          url,
+		"request":      request,
+	}, nil
+}
@@ -0,0 +1,130 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serverlessspark_test
+
+import (
+	"testing"
+
+	"cloud.google.com/go/dataproc/v2/apiv1/dataprocpb"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/mcp-toolbox/internal/sources/serverlessspark"
+)
+
+func TestDryRun(t *testing.T) {
+	tcs := []struct {
+		desc string
+		fn   func() (map[string]any, error)
+		want map[string]any
+	}{
+		{
+			desc: "create batch",
+			fn: func() (map[string]any, error) {
+				batch := &dataprocpb.Batch{
+					BatchConfig: &dataprocpb.Batch_PysparkBatch{
+						PysparkBatch: &dataprocpb.PySparkBatch{MainPythonFileUri: "gs://bucket/main.py"},
+					},
+				}
+				return serverlessspark.CreateBatchDryRun("", "my-project", "us-central1", "", batch, "")
+			},
+			want: map[string]any{
+				"dryRun":       true,
+				"resourceName": "projects/my-project/locations/us-central1",
+				"method":       "POST",
+				"url":          "https://dataproc.googleapis.com/v1/projects/my-project/locations/us-central1/batches",
+				"request": map[string]any{
+					"parent": "projects/my-project/locations/us-central1",
+					"batch": map[string]any{
+						"pysparkBatch": map[string]any{"mainPythonFileUri": "gs://bucket/main.py"},
+					},
+				},
+			},
+		},
+		{
+			desc: "create batch with request ID",
+			fn: func() (map[string]any, error) {
+				return serverlessspark.CreateBatchDryRun("", "my-project", "us-central1", "", &dataprocpb.Batch{}, "my-request")
+			},
+			want: map[string]any{
+				"dryRun":       true,
+				"resourceName": "projects/my-project/locations/us-central1",
+				"method":       "POST",
+				"url":          "https://dataproc.googleapis.com/v1/projects/my-project/locations/us-central1/batches",
+				"request": map[string]any{
+					"parent":    "projects/my-project/locations/us-central1",
+					"batch":     map[string]any{},
+					"requestId": "my-request",
+				},
+			},
+		},
+		{
+			desc: "create batch with batch ID",
+			fn: func() (map[string]any, error) {
+				return serverlessspark.CreateBatchDryRun("", "my-project", "us-central1", "my-batch", &dataprocpb.Batch{}, "")
+			},
+			want: map[string]any{
+				"dryRun":       true,
+				"resourceName": "projects/my-project/locations/us-central1/batches/my-batch",
+				"method":       "POST",
+				"url":          "https://dataproc.googleapis.com/v1/projects/my-project/locations/us-central1/batches?batchId=my-batch",
+				"request": map[string]any{
+					"parent":  "projects/my-project/locations/us-central1",
+					"batch":   map[string]any{},
+					"batchId": "my-batch",
+				},
+			},
+		},
+		{
+			desc: "cancel operation",
+			fn: func() (map[string]any, error) {
+				return serverlessspark.CancelOperationDryRun("", "my-project", "us-central1", "my-op")
+			},
+			want: map[string]any{
+				"dryRun":       true,
+				"resourceName": "projects/my-project/locations/us-central1/operations/my-op",
+				"method":       "POST",
+				"url":          "https://dataproc.googleapis.com/v1/projects/my-project/locations/us-central1/operations/my-op:cancel",
+				"request": map[string]any{
+					"name": "projects/my-project/locations/us-central1/operations/my-op",
+				},
+			},
+		},
+		{
+			desc: "cancel operation in another universe",
+			fn: func() (map[string]any, error) {
+				return serverlessspark.CancelOperationDryRun("example.goog", "my-project", "us-central1", "my-op")
+			},
+			want: map[string]any{
+				"dryRun":       true,
+				"resourceName": "projects/my-project/locations/us-central1/operations/my-op",
+				"method":       "POST",
+				"url":          "https://dataproc.example.goog/v1/projects/my-project/locations/us-central1/operations/my-op:cancel",
+				"request": map[string]any{
+					"name": "projects/my-project/locations/us-central1/operations/my-op",
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := tc.fn()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("incorrect dry run result (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serverlessspark
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// defaultUniverseDomain is the universe domain of the public Google Cloud,
+// used if the source does not configure one.
+const defaultUniverseDomain = "googleapis.com"
+
+var universeDomainRe = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]*[a-z0-9])?(\.[a-z0-9]([a-z0-9-]*[a-z0-9])?)+$`)
+
+// validateUniverseDomain checks that a configured universe domain is a bare
+// domain name, without a scheme, port or path.
+func validateUniverseDomain(universeDomain string) error {
+	if universeDomain != "" && !universeDomainRe.MatchString(universeDomain) {
+		return fmt.Errorf("universeDomain must be a domain name, e.g. %q: %q", defaultUniverseDomain, universeDomain)
+	}
+	return nil
+}
+
+func universeDomainOrDefault(universeDomain string) string {
+	if universeDomain == "" {
+		return defaultUniverseDomain
+	}
+	return universeDomain
+}
+
+// regionalEndpoint returns the gRPC endpoint of the Dataproc API in location.
+func regionalEndpoint(location, universeDomain string) string {
+	return fmt.Sprintf("%s-dataproc.%s:443", location, universeDomainOrDefault(universeDomain))
+}
+
+// restEndpoint returns the base URL of the Dataproc REST API, used to describe
+// the target of a dry run.
+func restEndpoint(universeDomain string) string {
+	return fmt.Sprintf("https://dataproc.%s/v1", universeDomainOrDefault(universeDomain))
+}
@@ -0,0 +1,175 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serverlessspark
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/googleapis/mcp-toolbox/internal/util"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// APIError is a structured error returned by the Dataproc API. It carries the
+// fields of the standard Google API error envelope so that callers can
+// distinguish e.g. PERMISSION_DENIED from NOT_FOUND.
+type APIError struct {
+	// Code is the HTTP status code corresponding to the error, e.g. 403.
+	Code int `json:"code"`
+	// Status is the canonical error status, e.g. PERMISSION_DENIED.
+	Status string `json:"status"`
+	// Message is the developer-facing error message.
+	Message string `json:"message"`
+
+	err error
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("%s (%d): %s", e.Status, e.Code, e.Message)
+}
+
+func (e *APIError) Unwrap() error {
+	return e.err
+}
+
+// QuotaError is an APIError for an exceeded quota or rate limit, i.e. a
+// RESOURCE_EXHAUSTED (429) error. Callers should back off before retrying.
+type QuotaError struct {
+	*APIError
+	// RetryAfter is how long the API asked callers to wait before retrying, or
+	// zero if it did not say.
+	RetryAfter time.Duration `json:"retryAfter,omitempty"`
+}
+
+func (e *QuotaError) Error() string {
+	if e.RetryAfter > 0 {
+		return fmt.Sprintf("%s; retry after %s", e.APIError.Error(), e.RetryAfter)
+	}
+	return e.APIError.Error()
+}
+
+func (e *QuotaError) Unwrap() error {
+	return e.APIError
+}
+
+// ToAPIError converts err into an *APIError if it carries a Google API error
+// status, or into a *QuotaError if that status is RESOURCE_EXHAUSTED.
+// Otherwise, err is returned unchanged.
+func ToAPIError(err error) error {
+	if err == nil {
+		return nil
+	}
+	// Unwrap to the error carrying the status, so that the message does not
+	// include any context added by wrapping.
+	var grpcErr interface{ GRPCStatus() *status.Status }
+	if !errors.As(err, &grpcErr) {
+		return err
+	}
+	st := grpcErr.GRPCStatus()
+	if st == nil || st.Code() == codes.OK {
+		return err
+	}
+	apiErr := &APIError{
+		Code:    httpStatusFromCode(st.Code()),
+		Status:  codeNames[st.Code()],
+		Message: st.Message(),
+		err:     err,
+	}
+	if st.Code() == codes.ResourceExhausted {
+		return &QuotaError{APIError: apiErr, RetryAfter: retryDelay(st)}
+	}
+	return apiErr
+}
+
+// retryDelay returns the retry delay in the RetryInfo details of st, or zero if
+// there is none.
+func retryDelay(st *status.Status) time.Duration {
+	for _, d := range st.Details() {
+		if info, ok := d.(*errdetails.RetryInfo); ok && info.GetRetryDelay() != nil {
+			return info.GetRetryDelay().AsDuration()
+		}
+	}
+	return 0
+}
+
+// ProcessError converts an error from the Dataproc API into a ToolboxError.
+// Quota errors are reported to the agent as such, with the retry delay the API
+// asked for, if any, so that it backs off rather than retrying immediately;
+// other errors are handled by util.ProcessGcpError. Errors need not have been
+// converted with ToAPIError.
+func ProcessError(err error) util.ToolboxError {
+	var quotaErr *QuotaError
+	if errors.As(err, &quotaErr) {
+		return util.NewAgentError("quota exceeded, back off before retrying", err)
+	}
+	if errors.As(ToAPIError(err), &quotaErr) {
+		return util.NewAgentError("quota exceeded, back off before retrying", quotaErr)
+	}
+	return util.ProcessGcpError(err)
+}
+
+// codeNames maps gRPC codes to the status names used in the Google API error
+// envelope, which differ from codes.Code.String().
+var codeNames = map[codes.Code]string{
+	codes.Canceled:           "CANCELLED",
+	codes.Unknown:            "UNKNOWN",
+	codes.InvalidArgument:    "INVALID_ARGUMENT",
+	codes.DeadlineExceeded:   "DEADLINE_EXCEEDED",
+	codes.NotFound:           "NOT_FOUND",
+	codes.AlreadyExists:      "ALREADY_EXISTS",
+	codes.PermissionDenied:   "PERMISSION_DENIED",
+	codes.ResourceExhausted:  "RESOURCE_EXHAUSTED",
+	codes.FailedPrecondition: "FAILED_PRECONDITION",
+	codes.Aborted:            "ABORTED",
+	codes.OutOfRange:         "OUT_OF_RANGE",
+	codes.Unimplemented:      "UNIMPLEMENTED",
+	codes.Internal:           "INTERNAL",
+	codes.Unavailable:        "UNAVAILABLE",
+	codes.DataLoss:           "DATA_LOSS",
+	codes.Unauthenticated:    "UNAUTHENTICATED",
+}
+
+// httpStatusFromCode returns the HTTP status code for a gRPC code, as
+// documented in google/rpc/code.proto.
+func httpStatusFromCode(code codes.Code) int {
+	switch code {
+	case codes.Canceled:
+		return 499
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	default:
+		return http.StatusInternalServerError
+	}
+}
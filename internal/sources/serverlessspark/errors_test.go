@@ -0,0 +1,163 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serverlessspark_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/googleapis/mcp-toolbox/internal/sources/serverlessspark"
+	"github.com/googleapis/mcp-toolbox/internal/util"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func TestToAPIError(t *testing.T) {
+	tcs := []struct {
+		desc        string
+		err         error
+		wantCode    int
+		wantStatus  string
+		wantMessage string
+	}{
+		{
+			desc:        "permission denied",
+			err:         status.Error(codes.PermissionDenied, "Permission 'dataproc.batches.list' denied"),
+			wantCode:    403,
+			wantStatus:  "PERMISSION_DENIED",
+			wantMessage: "Permission 'dataproc.batches.list' denied",
+		},
+		{
+			desc:        "wrapped not found",
+			err:         fmt.Errorf("rpc failed: %w", status.Error(codes.NotFound, "Location not found")),
+			wantCode:    404,
+			wantStatus:  "NOT_FOUND",
+			wantMessage: "Location not found",
+		},
+		{
+			desc:        "cancelled",
+			err:         status.Error(codes.Canceled, "context canceled"),
+			wantCode:    499,
+			wantStatus:  "CANCELLED",
+			wantMessage: "context canceled",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := serverlessspark.ToAPIError(tc.err)
+			var apiErr *serverlessspark.APIError
+			if !errors.As(got, &apiErr) {
+				t.Fatalf("ToAPIError() = %T, want *APIError", got)
+			}
+			if apiErr.Code != tc.wantCode || apiErr.Status != tc.wantStatus || apiErr.Message != tc.wantMessage {
+				t.Errorf("ToAPIError() = {%d, %q, %q}, want {%d, %q, %q}", apiErr.Code, apiErr.Status, apiErr.Message, tc.wantCode, tc.wantStatus, tc.wantMessage)
+			}
+			if !errors.Is(got, tc.err) {
+				t.Errorf("ToAPIError() does not wrap the original error")
+			}
+		})
+	}
+}
+
+func TestToAPIErrorQuota(t *testing.T) {
+	st, err := status.New(codes.ResourceExhausted, "Quota exceeded for quota metric 'Read requests'").WithDetails(
+		&errdetails.RetryInfo{RetryDelay: durationpb.New(30 * time.Second)},
+	)
+	if err != nil {
+		t.Fatalf("failed to add details: %v", err)
+	}
+	tcs := []struct {
+		desc           string
+		err            error
+		wantRetryAfter time.Duration
+		wantMessage    string
+	}{
+		{
+			desc:           "with retry info",
+			err:            fmt.Errorf("rpc failed: %w", st.Err()),
+			wantRetryAfter: 30 * time.Second,
+			wantMessage:    "RESOURCE_EXHAUSTED (429): Quota exceeded for quota metric 'Read requests'; retry after 30s",
+		},
+		{
+			desc:        "without retry info",
+			err:         status.Error(codes.ResourceExhausted, "Rate limit exceeded"),
+			wantMessage: "RESOURCE_EXHAUSTED (429): Rate limit exceeded",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := serverlessspark.ToAPIError(tc.err)
+			var quotaErr *serverlessspark.QuotaError
+			if !errors.As(got, &quotaErr) {
+				t.Fatalf("ToAPIError() = %T, want *QuotaError", got)
+			}
+			if quotaErr.RetryAfter != tc.wantRetryAfter {
+				t.Errorf("RetryAfter = %v, want %v", quotaErr.RetryAfter, tc.wantRetryAfter)
+			}
+			if got.Error() != tc.wantMessage {
+				t.Errorf("Error() = %q, want %q", got.Error(), tc.wantMessage)
+			}
+			var apiErr *serverlessspark.APIError
+			if !errors.As(got, &apiErr) || apiErr.Code != 429 {
+				t.Errorf("ToAPIError() does not wrap an *APIError with code 429: %v", apiErr)
+			}
+			if !errors.Is(got, tc.err) {
+				t.Errorf("ToAPIError() does not wrap the original error")
+			}
+		})
+	}
+}
+
+func TestToAPIErrorFallback(t *testing.T) {
+	if got := serverlessspark.ToAPIError(nil); got != nil {
+		t.Errorf("ToAPIError(nil) = %v, want nil", got)
+	}
+	err := errors.New("connection reset")
+	if got := serverlessspark.ToAPIError(err); got != err {
+		t.Errorf("ToAPIError() = %v, want original error", got)
+	}
+}
+
+func TestProcessError(t *testing.T) {
+	err := fmt.Errorf("failed to list batches: %w", serverlessspark.ToAPIError(status.Error(codes.ResourceExhausted, "Rate limit exceeded")))
+	got := serverlessspark.ProcessError(err)
+	want := "quota exceeded, back off before retrying: failed to list batches: RESOURCE_EXHAUSTED (429): Rate limit exceeded"
+	if got.Category() != util.CategoryAgent || got.Error() != want {
+		t.Errorf("ProcessError() = %s %q, want %s %q", got.Category(), got.Error(), util.CategoryAgent, want)
+	}
+
+	// Errors that were not converted with ToAPIError are converted, keeping
+	// the retry delay.
+	st, stErr := status.New(codes.ResourceExhausted, "Rate limit exceeded").WithDetails(
+		&errdetails.RetryInfo{RetryDelay: durationpb.New(30 * time.Second)},
+	)
+	if stErr != nil {
+		t.Fatalf("failed to add details: %v", stErr)
+	}
+	got = serverlessspark.ProcessError(fmt.Errorf("failed to get batch: %w", st.Err()))
+	want = "quota exceeded, back off before retrying: RESOURCE_EXHAUSTED (429): Rate limit exceeded; retry after 30s"
+	if got.Category() != util.CategoryAgent || got.Error() != want {
+		t.Errorf("ProcessError() = %s %q, want %s %q", got.Category(), got.Error(), util.CategoryAgent, want)
+	}
+
+	err = fmt.Errorf("failed to list batches: %w", serverlessspark.ToAPIError(status.Error(codes.NotFound, "Location not found")))
+	if got := serverlessspark.ProcessError(err); got.Error() != util.ProcessGcpError(err).Error() {
+		t.Errorf("ProcessError() = %q, want %q", got.Error(), util.ProcessGcpError(err).Error())
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serverlessspark
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"cloud.google.com/go/storage"
+	"google.golang.org/api/option"
+	htransport "google.golang.org/api/transport/http"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// reservedHeaders are set by the clients themselves and may not be
+// overridden by customHeaders.
+var reservedHeaders = []string{"authorization", "user-agent"}
+
+// validateCustomHeaders returns an error if headers sets a reserved header.
+func validateCustomHeaders(headers map[string]string) error {
+	for k := range headers {
+		for _, reserved := range reservedHeaders {
+			if strings.EqualFold(k, reserved) {
+				return fmt.Errorf("customHeaders may not set the %q header", k)
+			}
+		}
+	}
+	return nil
+}
+
+// customHeaderOptions returns the options that add headers to every request
+// made by the gRPC clients, i.e. the Dataproc and Cloud Logging clients.
+func customHeaderOptions(headers map[string]string) []option.ClientOption {
+	if len(headers) == 0 {
+		return nil
+	}
+	var kv []string
+	for k, v := range headers {
+		kv = append(kv, strings.ToLower(k), v)
+	}
+	unary := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(metadata.AppendToOutgoingContext(ctx, kv...), method, req, reply, cc, opts...)
+	}
+	stream := func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(metadata.AppendToOutgoingContext(ctx, kv...), desc, cc, method, opts...)
+	}
+	return []option.ClientOption{
+		option.WithGRPCDialOption(grpc.WithChainUnaryInterceptor(unary)),
+		option.WithGRPCDialOption(grpc.WithChainStreamInterceptor(stream)),
+	}
+}
+
+// headerTransport is an http.RoundTripper that adds headers to requests,
+// without replacing any header that is already set.
+type headerTransport struct {
+	base    http.RoundTripper
+	headers map[string]string
+}
+
+func (t *headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	for k, v := range t.headers {
+		if req.Header.Get(k) == "" {
+			req.Header.Set(k, v)
+		}
+	}
+	return t.base.RoundTrip(req)
+}
+
+// newStorageClient creates the Cloud Storage client. Unlike the other
+// clients, it uses HTTP, so custom headers are added by wrapping its
+// transport. The wrapped client ignores opts, so the universe domain is passed
+// again to derive its endpoint.
+func newStorageClient(ctx context.Context, headers map[string]string, universeDomain string, opts []option.ClientOption) (*storage.Client, error) {
+	if len(headers) == 0 {
+		return storage.NewClient(ctx, opts...)
+	}
+	opts = append([]option.ClientOption{option.WithScopes(storage.ScopeFullControl, "https://www.googleapis.com/auth/cloud-platform")}, opts...)
+	trans, err := htransport.NewTransport(ctx, &headerTransport{base: http.DefaultTransport, headers: headers}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	clientOpts := []option.ClientOption{option.WithHTTPClient(&http.Client{Transport: trans})}
+	if universeDomain != "" {
+		clientOpts = append(clientOpts, option.WithUniverseDomain(universeDomain))
+	}
+	return storage.NewClient(ctx, clientOpts...)
+}
@@ -0,0 +1,123 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serverlessspark
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	dataproc "cloud.google.com/go/dataproc/v2/apiv1"
+	"cloud.google.com/go/dataproc/v2/apiv1/dataprocpb"
+	"google.golang.org/api/option"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestValidateCustomHeaders(t *testing.T) {
+	tcs := []struct {
+		headers map[string]string
+		wantErr bool
+	}{
+		{headers: nil},
+		{headers: map[string]string{"X-Request-Context": "audit"}},
+		{headers: map[string]string{"Authorization": "Bearer token"}, wantErr: true},
+		{headers: map[string]string{"user-agent": "my-agent"}, wantErr: true},
+	}
+	for _, tc := range tcs {
+		err := validateCustomHeaders(tc.headers)
+		if (err != nil) != tc.wantErr {
+			t.Errorf("validateCustomHeaders(%v) error = %v, wantErr %v", tc.headers, err, tc.wantErr)
+		}
+	}
+}
+
+func TestHeaderTransport(t *testing.T) {
+	var got http.Header
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header
+	}))
+	t.Cleanup(srv.Close)
+
+	client := &http.Client{Transport: &headerTransport{
+		base:    http.DefaultTransport,
+		headers: map[string]string{"X-Request-Context": "audit", "X-Existing": "custom"},
+	}}
+	req, err := http.NewRequest(http.MethodGet, srv.URL, nil)
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+	req.Header.Set("X-Existing", "original")
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	resp.Body.Close()
+
+	if v := got.Get("X-Request-Context"); v != "audit" {
+		t.Errorf("got X-Request-Context %q, want %q", v, "audit")
+	}
+	if v := got.Get("X-Existing"); v != "original" {
+		t.Errorf("got X-Existing %q, want the request's own value %q", v, "original")
+	}
+	if v := req.Header.Get("X-Request-Context"); v != "" {
+		t.Errorf("original request was modified: X-Request-Context %q", v)
+	}
+}
+
+// metadataBatchController records the metadata of GetBatch requests.
+type metadataBatchController struct {
+	dataprocpb.UnimplementedBatchControllerServer
+	md metadata.MD
+}
+
+func (f *metadataBatchController) GetBatch(ctx context.Context, req *dataprocpb.GetBatchRequest) (*dataprocpb.Batch, error) {
+	f.md, _ = metadata.FromIncomingContext(ctx)
+	return &dataprocpb.Batch{Name: req.Name}, nil
+}
+
+func TestCustomHeaderOptions(t *testing.T) {
+	lis, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	controller := &metadataBatchController{}
+	srv := grpc.NewServer()
+	dataprocpb.RegisterBatchControllerServer(srv, controller)
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	ctx := context.Background()
+	opts := append(customHeaderOptions(map[string]string{"X-Request-Context": "audit"}),
+		option.WithEndpoint(lis.Addr().String()),
+		option.WithoutAuthentication(),
+		option.WithGRPCDialOption(grpc.WithTransportCredentials(insecure.NewCredentials())),
+	)
+	client, err := dataproc.NewBatchControllerClient(ctx, opts...)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	t.Cleanup(func() { client.Close() })
+
+	if _, err := client.GetBatch(ctx, &dataprocpb.GetBatchRequest{Name: "projects/p/locations/l/batches/b"}); err != nil {
+		t.Fatalf("GetBatch() error = %v", err)
+	}
+	if got := controller.md.Get("x-request-context"); len(got) != 1 || got[0] != "audit" {
+		t.Errorf("got x-request-context %v, want [audit]", got)
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serverlessspark
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	dataproc "cloud.google.com/go/dataproc/v2/apiv1"
+	"google.golang.org/api/option"
+)
+
+// MultiLocationBatchesResponse is the result of listing batches across
+// locations.
+type MultiLocationBatchesResponse struct {
+	Batches []Batch `json:"batches"`
+	// Errors maps each location whose batches could not be listed to the
+	// error.
+	Errors map[string]string `json:"errors,omitempty"`
+}
+
+// locationBatches is the result of listing the batches in one location.
+type locationBatches struct {
+	location string
+	batches  []Batch
+	err      error
+}
+
+// ListBatchesInLocations lists the newest batches in the given project across
+// the given locations concurrently, at most maxConcurrency at a time,
+// returning at most limit batches, newest first, each tagged with its
+// location. If project is empty, the source's project is used. A failure to
+// list one location is reported in the response's Errors rather than failing
+// the whole call.
+func (s *Source) ListBatchesInLocations(ctx context.Context, project string, locations []string, limit int, filter string, includeLabels bool) (any, error) {
+	if project == "" {
+		project = s.GetProject()
+	}
+	locations = slices.Compact(slices.Sorted(slices.Values(locations)))
+
+	results := make([]locationBatches, len(locations))
+	forEachConcurrently(len(locations), s.GetMaxConcurrency(), func(i int) {
+		location := locations[i]
+		batches, err := s.listBatchesInLocation(ctx, project, location, limit, filter, includeLabels)
+		results[i] = locationBatches{location: location, batches: batches, err: err}
+	})
+	return mergeLocationBatches(results, limit), nil
+}
+
+// listBatchesInLocation lists up to limit of the newest batches in one
+// location.
+func (s *Source) listBatchesInLocation(ctx context.Context, project, location string, limit int, filter string, includeLabels bool) ([]Batch, error) {
+	client, closeClient, err := s.batchClientForLocation(ctx, location)
+	if err != nil {
+		return nil, err
+	}
+	defer closeClient()
+	batches, _, err := listBatches(ctx, client, project, location, &limit, "", filter, includeLabels)
+	return batches, err
+}
+
+// batchClientForLocation returns a batch client for the given location and a
+// function to call when done with it. The batch client is regional, so
+// locations other than the source's use a client created for the call.
+func (s *Source) batchClientForLocation(ctx context.Context, location string) (*dataproc.BatchControllerClient, func(), error) {
+	if location == s.GetLocation() {
+		return s.GetBatchControllerClient(), func() {}, nil
+	}
+	endpoint := regionalEndpoint(location, s.UniverseDomain)
+	opts := append([]option.ClientOption{option.WithEndpoint(endpoint)}, s.clientOpts...)
+	c, err := dataproc.NewBatchControllerClient(ctx, opts...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create dataproc batch client: %w", err)
+	}
+	return c, func() { c.Close() }, nil
+}
+
+// mergeLocationBatches merges the batches listed in each location into one
+// list of at most limit batches, newest first.
+func mergeLocationBatches(results []locationBatches, limit int) MultiLocationBatchesResponse {
+	resp := MultiLocationBatchesResponse{Batches: []Batch{}}
+	for _, r := range results {
+		if r.err != nil {
+			if resp.Errors == nil {
+				resp.Errors = make(map[string]string)
+			}
+			resp.Errors[r.location] = r.err.Error()
+			continue
+		}
+		for _, b := range r.batches {
+			b.Location = r.location
+			resp.Batches = append(resp.Batches, b)
+		}
+	}
+	// CreateTime is formatted as RFC 3339 in UTC, so it sorts as a string.
+	slices.SortStableFunc(resp.Batches, func(a, b Batch) int {
+		return cmp.Compare(b.CreateTime, a.CreateTime)
+	})
+	if len(resp.Batches) > limit {
+		resp.Batches = resp.Batches[:limit]
+	}
+	return resp
+}
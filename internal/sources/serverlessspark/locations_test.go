@@ -0,0 +1,53 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serverlessspark
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestMergeLocationBatches(t *testing.T) {
+	results := []locationBatches{
+		{location: "asia-east1", err: errors.New("permission denied")},
+		{location: "europe-west4", batches: []Batch{
+			{Name: "eu-new", CreateTime: "2026-01-03T00:00:00Z"},
+			{Name: "eu-old", CreateTime: "2026-01-01T00:00:00Z"},
+		}},
+		{location: "us-central1", batches: []Batch{
+			{Name: "us-new", CreateTime: "2026-01-04T00:00:00Z"},
+			{Name: "us-old", CreateTime: "2026-01-02T00:00:00Z"},
+		}},
+	}
+	got := mergeLocationBatches(results, 3)
+	want := MultiLocationBatchesResponse{
+		Batches: []Batch{
+			{Name: "us-new", CreateTime: "2026-01-04T00:00:00Z", Location: "us-central1"},
+			{Name: "eu-new", CreateTime: "2026-01-03T00:00:00Z", Location: "europe-west4"},
+			{Name: "us-old", CreateTime: "2026-01-02T00:00:00Z", Location: "us-central1"},
+		},
+		Errors: map[string]string{"asia-east1": "permission denied"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mergeLocationBatches() mismatch (-want +got):\n%s", diff)
+	}
+
+	got = mergeLocationBatches(nil, 3)
+	if diff := cmp.Diff(MultiLocationBatchesResponse{Batches: []Batch{}}, got); diff != "" {
+		t.Errorf("mergeLocationBatches(nil) mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serverlessspark
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"cloud.google.com/go/logging"
+	"cloud.google.com/go/logging/logadmin"
+	"github.com/googleapis/mcp-toolbox/internal/util/logiter"
+	"google.golang.org/api/iterator"
+)
+
+// ErrLogEntryNotFound is returned by GetLogEntry if no entry has the insertId.
+var ErrLogEntryNotFound = errors.New("log entry not found")
+
+// GetLogEntry returns the log entry with the given insertId among the logs of
+// the batch or session with the given full resource name, with all of its
+// details. The search is limited to the resource's log time range, as with
+// the logs URLs, since Cloud Logging only searches the last day otherwise. The
+// entry is searched for in logsProject if it is set, or else in the
+// resource's project.
+func (s *Source) GetLogEntry(ctx context.Context, name, insertID, logsProject string) (map[string]any, error) {
+	var resource LogResource
+	var resourceType string
+	var labels map[string]string
+	if projectID, location, batchID, err := ExtractBatchDetails(name); err == nil {
+		batchPb, err := s.getBatchCached(ctx, name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get batch: %w", err)
+		}
+		resource, resourceType = batchPb, BatchLogResourceType
+		labels = map[string]string{"project_id": projectID, "location": location, "batch_id": batchID}
+	} else if projectID, location, sessionID, err := ExtractSessionDetails(name); err == nil {
+		sessionPb, err := s.getSessionCached(ctx, name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get session: %w", err)
+		}
+		resource, resourceType = sessionPb, SessionLogResourceType
+		labels = map[string]string{"project_id": projectID, "location": location, "session_id": sessionID}
+	} else {
+		return nil, fmt.Errorf("failed to parse batch or session name: %s", name)
+	}
+	r := ResolveLogTimeRange(resource, LogTimeRange{})
+	spec := logFilterSpec(resourceType, labels, r)
+	spec.Extra = "insertId=" + strconv.Quote(insertID)
+
+	it := s.GetLoggingClient().Entries(ctx,
+		logadmin.ProjectIDs([]string{logsProjectID(labels["project_id"], logsProject)}),
+		logadmin.Filter(spec.Build()),
+		logadmin.PageSize(1),
+	)
+	entry, err := logiter.Next(ctx, it)
+	if err == iterator.Done {
+		return nil, fmt.Errorf("%w: no entry with insertId %q in the logs of %s", ErrLogEntryNotFound, insertID, name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get log entry: %w", err)
+	}
+	return logEntryDetails(entry), nil
+}
+
+// logEntryDetails converts entry to a map with all of its set fields.
+func logEntryDetails(entry *logging.Entry) map[string]any {
+	result := map[string]any{
+		"insertId":  entry.InsertID,
+		"logName":   entry.LogName,
+		"timestamp": entry.Timestamp.UTC().Format(time.RFC3339Nano),
+		"severity":  entry.Severity.String(),
+	}
+	if entry.Payload != nil {
+		result["payload"] = entry.Payload
+	}
+	if entry.Resource != nil {
+		result["resource"] = map[string]any{
+			"type":   entry.Resource.Type,
+			"labels": entry.Resource.Labels,
+		}
+	}
+	if len(entry.Labels) > 0 {
+		result["labels"] = entry.Labels
+	}
+	if entry.Trace != "" {
+		result["trace"] = entry.Trace
+	}
+	if entry.SpanID != "" {
+		result["spanId"] = entry.SpanID
+	}
+	if entry.Operation != nil {
+		result["operation"] = map[string]any{
+			"id":       entry.Operation.Id,
+			"producer": entry.Operation.Producer,
+			"first":    entry.Operation.First,
+			"last":     entry.Operation.Last,
+		}
+	}
+	if entry.SourceLocation != nil {
+		result["sourceLocation"] = map[string]any{
+			"file":     entry.SourceLocation.File,
+			"line":     entry.SourceLocation.Line,
+			"function": entry.SourceLocation.Function,
+		}
+	}
+	return result
+}
@@ -0,0 +1,176 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serverlessspark_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	dataproc "cloud.google.com/go/dataproc/v2/apiv1"
+	"cloud.google.com/go/dataproc/v2/apiv1/dataprocpb"
+	"cloud.google.com/go/logging/apiv2/loggingpb"
+	"cloud.google.com/go/logging/logadmin"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/mcp-toolbox/internal/sources/serverlessspark"
+	"google.golang.org/api/option"
+	"google.golang.org/genproto/googleapis/api/monitoredres"
+	ltype "google.golang.org/genproto/googleapis/logging/type"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestGetLogEntry(t *testing.T) {
+	entry := &loggingpb.LogEntry{
+		InsertId:  "abc123",
+		LogName:   "projects/my-project/logs/dataproc.googleapis.com%2Foutput",
+		Severity:  ltype.LogSeverity_ERROR,
+		Timestamp: timestamppb.New(time.Date(2026, 1, 2, 3, 30, 0, 0, time.UTC)),
+		Resource:  &monitoredres.MonitoredResource{Type: "cloud_dataproc_batch", Labels: map[string]string{"batch_id": "my-batch"}},
+		Labels:    map[string]string{"dataproc.googleapis.com/application_id": "app-1"},
+		Payload:   &loggingpb.LogEntry_TextPayload{TextPayload: "executor lost"},
+	}
+	tcs := []struct {
+		desc        string
+		name        string
+		logsProject string
+		entries     []*loggingpb.LogEntry
+		wantFilter  string
+		wantErr     error
+	}{
+		{
+			desc:    "batch",
+			name:    "projects/my-project/locations/us-central1/batches/my-batch",
+			entries: []*loggingpb.LogEntry{entry},
+			wantFilter: `resource.type="cloud_dataproc_batch"
+resource.labels.batch_id="my-batch"
+resource.labels.location="us-central1"
+resource.labels.project_id="my-project"
+timestamp>="2026-01-02T02:59:00Z"
+timestamp<="2026-01-02T04:00:00Z"
+(insertId="abc123")`,
+		},
+		{
+			desc:    "session",
+			name:    "projects/my-project/locations/us-central1/sessions/my-session",
+			entries: []*loggingpb.LogEntry{entry},
+			wantFilter: `resource.type="cloud_dataproc_session"
+resource.labels.location="us-central1"
+resource.labels.project_id="my-project"
+resource.labels.session_id="my-session"
+timestamp>="2026-01-02T02:59:00Z"
+timestamp<="2026-01-02T04:00:00Z"
+(insertId="abc123")`,
+		},
+		{
+			desc:        "batch in logs project",
+			name:        "projects/my-project/locations/us-central1/batches/my-batch",
+			logsProject: "central-logs",
+			entries:     []*loggingpb.LogEntry{entry},
+			wantFilter: `resource.type="cloud_dataproc_batch"
+resource.labels.batch_id="my-batch"
+resource.labels.location="us-central1"
+resource.labels.project_id="my-project"
+timestamp>="2026-01-02T02:59:00Z"
+timestamp<="2026-01-02T04:00:00Z"
+(insertId="abc123")`,
+		},
+		{
+			desc:    "not found",
+			name:    "projects/my-project/locations/us-central1/batches/my-batch",
+			entries: []*loggingpb.LogEntry{},
+			wantErr: serverlessspark.ErrLogEntryNotFound,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			lis, err := net.Listen("tcp", "localhost:0")
+			if err != nil {
+				t.Fatalf("failed to listen: %v", err)
+			}
+			logging := &fakeLogging{entries: tc.entries}
+			batch := &dataprocpb.Batch{
+				Name:       "projects/my-project/locations/us-central1/batches/my-batch",
+				State:      dataprocpb.Batch_FAILED,
+				CreateTime: timestamppb.New(time.Date(2026, 1, 2, 3, 0, 0, 0, time.UTC)),
+				StateTime:  timestamppb.New(time.Date(2026, 1, 2, 4, 0, 0, 0, time.UTC)),
+			}
+			srv := grpc.NewServer()
+			dataprocpb.RegisterBatchControllerServer(srv, &fakeBatchController{batch: batch})
+			dataprocpb.RegisterSessionControllerServer(srv, &fakeSessionController{state: dataprocpb.Session_TERMINATED})
+			loggingpb.RegisterLoggingServiceV2Server(srv, logging)
+			go func() { _ = srv.Serve(lis) }()
+			t.Cleanup(srv.Stop)
+
+			ctx := context.Background()
+			opts := []option.ClientOption{
+				option.WithEndpoint(lis.Addr().String()),
+				option.WithoutAuthentication(),
+				option.WithGRPCDialOption(grpc.WithTransportCredentials(insecure.NewCredentials())),
+			}
+			batchClient, err := dataproc.NewBatchControllerClient(ctx, opts...)
+			if err != nil {
+				t.Fatalf("failed to create batch client: %v", err)
+			}
+			t.Cleanup(func() { batchClient.Close() })
+			sessionClient, err := dataproc.NewSessionControllerClient(ctx, opts...)
+			if err != nil {
+				t.Fatalf("failed to create session client: %v", err)
+			}
+			t.Cleanup(func() { sessionClient.Close() })
+			loggingClient, err := logadmin.NewClient(ctx, "my-project", opts...)
+			if err != nil {
+				t.Fatalf("failed to create logging client: %v", err)
+			}
+			t.Cleanup(func() { loggingClient.Close() })
+			source := &serverlessspark.Source{
+				Config:        serverlessspark.Config{Project: "my-project", Location: "us-central1"},
+				BatchClient:   batchClient,
+				SessionClient: sessionClient,
+				LoggingClient: loggingClient,
+			}
+
+			got, err := source.GetLogEntry(ctx, tc.name, "abc123", tc.logsProject)
+			if tc.wantErr != nil {
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("GetLogEntry() error = %v, want %v", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetLogEntry() error = %v", err)
+			}
+			if got := logging.gotReq.GetFilter(); got != tc.wantFilter {
+				t.Errorf("got filter\n%s\nwant\n%s", got, tc.wantFilter)
+			}
+			wantResources := []string{"projects/my-project"}
+			if tc.logsProject != "" {
+				wantResources = []string{"projects/" + tc.logsProject}
+			}
+			if diff := cmp.Diff(wantResources, logging.gotReq.GetResourceNames()); diff != "" {
+				t.Errorf("resource names mismatch (-want +got):\n%s", diff)
+			}
+			if got["insertId"] != "abc123" || got["payload"] != "executor lost" {
+				t.Errorf("got entry %v, want insertId abc123 with payload %q", got, "executor lost")
+			}
+			if _, ok := got["labels"]; !ok {
+				t.Errorf("got entry %v without labels", got)
+			}
+		})
+	}
+}
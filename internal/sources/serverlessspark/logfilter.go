@@ -0,0 +1,63 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serverlessspark
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LogFilterSpec describes a Cloud Logging filter for the logs of a resource.
+type LogFilterSpec struct {
+	// ResourceType is the monitored resource type, e.g. cloud_dataproc_batch.
+	ResourceType string
+	// Labels are the resource labels to match, e.g. batch_id.
+	Labels map[string]string
+	// Start and End bound the entries' timestamps, if non-zero.
+	Start, End time.Time
+	// Severity is the minimum severity of the entries, e.g. ERROR, if set.
+	Severity string
+	// Extra is an additional filter the entries must match, if set.
+	Extra string
+}
+
+// Build returns the filter, with one clause per line. Clauses are in a fixed
+// order, with labels sorted by key, and values are quoted and escaped.
+func (s LogFilterSpec) Build() string {
+	var clauses []string
+	if s.ResourceType != "" {
+		clauses = append(clauses, "resource.type="+strconv.Quote(s.ResourceType))
+	}
+	for _, k := range slices.Sorted(maps.Keys(s.Labels)) {
+		clauses = append(clauses, fmt.Sprintf("resource.labels.%s=%s", k, strconv.Quote(s.Labels[k])))
+	}
+	if s.Severity != "" {
+		clauses = append(clauses, "severity>="+s.Severity)
+	}
+	if !s.Start.IsZero() {
+		clauses = append(clauses, "timestamp>="+strconv.Quote(s.Start.Format(time.RFC3339Nano)))
+	}
+	if !s.End.IsZero() {
+		clauses = append(clauses, "timestamp<="+strconv.Quote(s.End.Format(time.RFC3339Nano)))
+	}
+	if s.Extra != "" {
+		clauses = append(clauses, "("+s.Extra+")")
+	}
+	return strings.Join(clauses, "\n")
+}
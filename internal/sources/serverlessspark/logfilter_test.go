@@ -0,0 +1,77 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serverlessspark_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/googleapis/mcp-toolbox/internal/sources/serverlessspark"
+)
+
+func TestLogFilterSpecBuild(t *testing.T) {
+	tcs := []struct {
+		desc string
+		spec serverlessspark.LogFilterSpec
+		want string
+	}{
+		{
+			desc: "empty",
+			want: "",
+		},
+		{
+			desc: "labels sorted by key",
+			spec: serverlessspark.LogFilterSpec{
+				ResourceType: "cloud_dataproc_batch",
+				Labels:       map[string]string{"project_id": "my-project", "location": "us-central1", "batch_id": "my-batch"},
+			},
+			want: `resource.type="cloud_dataproc_batch"
+resource.labels.batch_id="my-batch"
+resource.labels.location="us-central1"
+resource.labels.project_id="my-project"`,
+		},
+		{
+			desc: "all fields",
+			spec: serverlessspark.LogFilterSpec{
+				ResourceType: "cloud_dataproc_session",
+				Labels:       map[string]string{"session_id": "my-session"},
+				Start:        time.Date(2025, 10, 1, 5, 0, 0, 0, time.UTC),
+				End:          time.Date(2025, 10, 1, 6, 0, 0, 500, time.UTC),
+				Severity:     "ERROR",
+				Extra:        `textPayload:"OOM" OR textPayload:"killed"`,
+			},
+			want: `resource.type="cloud_dataproc_session"
+resource.labels.session_id="my-session"
+severity>=ERROR
+timestamp>="2025-10-01T05:00:00Z"
+timestamp<="2025-10-01T06:00:00.0000005Z"
+(textPayload:"OOM" OR textPayload:"killed")`,
+		},
+		{
+			desc: "values escaped",
+			spec: serverlessspark.LogFilterSpec{
+				Labels: map[string]string{"batch_id": `my-batch" OR "a\b`},
+			},
+			want: `resource.labels.batch_id="my-batch\" OR \"a\\b"`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := tc.spec.Build(); got != tc.want {
+				t.Errorf("Build() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,142 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serverlessspark
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/logging/logadmin"
+	"github.com/googleapis/mcp-toolbox/internal/util/logiter"
+	"google.golang.org/api/iterator"
+)
+
+// maxHistogramEntries bounds the number of entries BatchLogHistogram counts.
+// Cloud Logging has no aggregate queries, so the entries are counted as they
+// are listed, and a batch with very verbose logs would otherwise take too long.
+const maxHistogramEntries = 100000
+
+// histogramPageSize is the page size used to list the entries to count, the
+// largest that Cloud Logging accepts.
+const histogramPageSize = 1000
+
+// BatchLogHistogram counts the log entries of the batch with the given full
+// name by severity and, if byHour is set, by the hour of their timestamps.
+// The entries are limited to the batch's log time range, with times set in
+// params taking precedence.
+//
+// If the batch has more than maxHistogramEntries entries in the range, only
+// the oldest are counted, and "truncated" is set in the result. If ctx's
+// deadline passes while counting, the entries counted so far are returned,
+// with both "truncated" and "deadlineExceeded" set. The filter that was run is
+// returned as "filter".
+//
+// The entries are listed in logsProject if it is set, or else in the batch's
+// project.
+func (s *Source) BatchLogHistogram(ctx context.Context, name string, params LogTimeRange, byHour bool, logsProject string) (map[string]any, error) {
+	projectID, location, batchID, err := ExtractBatchDetails(name)
+	if err != nil {
+		return nil, err
+	}
+	batchPb, err := s.getBatchCached(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get batch: %w", err)
+	}
+	r := ResolveLogTimeRange(batchPb, params)
+	spec := logFilterSpec(BatchLogResourceType, map[string]string{
+		"project_id": projectID,
+		"location":   location,
+		"batch_id":   batchID,
+	}, r)
+	// Only the defaults are buffered, since the caller asked for exact times.
+	if !params.Start.IsZero() {
+		spec.Start = params.Start
+	}
+	if !params.End.IsZero() {
+		spec.End = params.End
+	}
+
+	filter := spec.Build()
+	it := s.GetLoggingClient().Entries(ctx,
+		logadmin.ProjectIDs([]string{logsProjectID(projectID, logsProject)}),
+		logadmin.Filter(filter),
+		logadmin.PageSize(histogramPageSize),
+	)
+	total := 0
+	truncated, timedOut := false, false
+	counts := map[string]int{}
+	hourly := map[time.Time]map[string]int{}
+	for {
+		entry, err := logiter.Next(ctx, it)
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			if total > 0 && logiter.DeadlineExceeded(ctx) {
+				truncated, timedOut = true, true
+				break
+			}
+			return nil, fmt.Errorf("failed to list batch logs: %w", err)
+		}
+		if total == maxHistogramEntries {
+			truncated = true
+			break
+		}
+		total++
+		// Use the severity names of the logging query language, e.g. ERROR,
+		// so that they can be used in filters.
+		severity := strings.ToUpper(entry.Severity.String())
+		counts[severity]++
+		if byHour {
+			hour := entry.Timestamp.UTC().Truncate(time.Hour)
+			if hourly[hour] == nil {
+				hourly[hour] = map[string]int{}
+			}
+			hourly[hour][severity]++
+		}
+	}
+
+	result := map[string]any{
+		"batch":     name,
+		"total":     total,
+		"counts":    counts,
+		"truncated": truncated,
+		"filter":    filter,
+	}
+	if timedOut {
+		result["deadlineExceeded"] = true
+	}
+	if !spec.Start.IsZero() {
+		result["startTime"] = spec.Start.UTC().Format(time.RFC3339)
+	}
+	if !spec.End.IsZero() {
+		result["endTime"] = spec.End.UTC().Format(time.RFC3339)
+	}
+	if byHour {
+		buckets := []map[string]any{}
+		for _, hour := range slices.SortedFunc(maps.Keys(hourly), time.Time.Compare) {
+			buckets = append(buckets, map[string]any{
+				"hour":   hour.Format(time.RFC3339),
+				"counts": hourly[hour],
+			})
+		}
+		result["hourly"] = buckets
+	}
+	return result, nil
+}
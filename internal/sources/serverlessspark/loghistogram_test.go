@@ -0,0 +1,270 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serverlessspark_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	dataproc "cloud.google.com/go/dataproc/v2/apiv1"
+	"cloud.google.com/go/dataproc/v2/apiv1/dataprocpb"
+	"cloud.google.com/go/logging/apiv2/loggingpb"
+	"cloud.google.com/go/logging/logadmin"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/mcp-toolbox/internal/sources/serverlessspark"
+	"google.golang.org/api/option"
+	ltype "google.golang.org/genproto/googleapis/logging/type"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestBatchLogHistogram(t *testing.T) {
+	var entries []*loggingpb.LogEntry
+	for _, e := range []struct {
+		minute   int
+		severity ltype.LogSeverity
+	}{
+		{30, ltype.LogSeverity_INFO},
+		{45, ltype.LogSeverity_INFO},
+		{50, ltype.LogSeverity_ERROR},
+		{70, ltype.LogSeverity_ERROR},
+	} {
+		entries = append(entries, &loggingpb.LogEntry{
+			Severity:  e.severity,
+			Timestamp: timestamppb.New(time.Date(2026, 1, 2, 3, e.minute, 0, 0, time.UTC)),
+			Payload:   &loggingpb.LogEntry_TextPayload{TextPayload: "message"},
+		})
+	}
+	tcs := []struct {
+		desc        string
+		params      serverlessspark.LogTimeRange
+		byHour      bool
+		logsProject string
+		wantFilter  string
+		wantHourly  any
+	}{
+		{
+			desc: "batch time range",
+			wantFilter: `resource.type="cloud_dataproc_batch"
+resource.labels.batch_id="my-batch"
+resource.labels.location="us-central1"
+resource.labels.project_id="my-project"
+timestamp>="2026-01-02T02:59:00Z"
+timestamp<="2026-01-02T04:00:00Z"`,
+		},
+		{
+			desc:        "logs project",
+			logsProject: "central-logs",
+			wantFilter: `resource.type="cloud_dataproc_batch"
+resource.labels.batch_id="my-batch"
+resource.labels.location="us-central1"
+resource.labels.project_id="my-project"
+timestamp>="2026-01-02T02:59:00Z"
+timestamp<="2026-01-02T04:00:00Z"`,
+		},
+		{
+			desc: "by hour with explicit time range",
+			params: serverlessspark.LogTimeRange{
+				Start: time.Date(2026, 1, 2, 3, 15, 0, 0, time.UTC),
+				End:   time.Date(2026, 1, 2, 4, 15, 0, 0, time.UTC),
+			},
+			byHour: true,
+			wantFilter: `resource.type="cloud_dataproc_batch"
+resource.labels.batch_id="my-batch"
+resource.labels.location="us-central1"
+resource.labels.project_id="my-project"
+timestamp>="2026-01-02T03:15:00Z"
+timestamp<="2026-01-02T04:15:00Z"`,
+			wantHourly: []map[string]any{
+				{"hour": "2026-01-02T03:00:00Z", "counts": map[string]int{"INFO": 2, "ERROR": 1}},
+				{"hour": "2026-01-02T04:00:00Z", "counts": map[string]int{"ERROR": 1}},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			lis, err := net.Listen("tcp", "localhost:0")
+			if err != nil {
+				t.Fatalf("failed to listen: %v", err)
+			}
+			logging := &fakeLogging{entries: entries}
+			batch := &dataprocpb.Batch{
+				Name:       "projects/my-project/locations/us-central1/batches/my-batch",
+				State:      dataprocpb.Batch_FAILED,
+				CreateTime: timestamppb.New(time.Date(2026, 1, 2, 3, 0, 0, 0, time.UTC)),
+				StateTime:  timestamppb.New(time.Date(2026, 1, 2, 4, 0, 0, 0, time.UTC)),
+			}
+			srv := grpc.NewServer()
+			dataprocpb.RegisterBatchControllerServer(srv, &fakeBatchController{batch: batch})
+			loggingpb.RegisterLoggingServiceV2Server(srv, logging)
+			go func() { _ = srv.Serve(lis) }()
+			t.Cleanup(srv.Stop)
+
+			ctx := context.Background()
+			opts := []option.ClientOption{
+				option.WithEndpoint(lis.Addr().String()),
+				option.WithoutAuthentication(),
+				option.WithGRPCDialOption(grpc.WithTransportCredentials(insecure.NewCredentials())),
+			}
+			batchClient, err := dataproc.NewBatchControllerClient(ctx, opts...)
+			if err != nil {
+				t.Fatalf("failed to create batch client: %v", err)
+			}
+			t.Cleanup(func() { batchClient.Close() })
+			loggingClient, err := logadmin.NewClient(ctx, "my-project", opts...)
+			if err != nil {
+				t.Fatalf("failed to create logging client: %v", err)
+			}
+			t.Cleanup(func() { loggingClient.Close() })
+			source := &serverlessspark.Source{
+				Config:        serverlessspark.Config{Project: "my-project", Location: "us-central1"},
+				BatchClient:   batchClient,
+				LoggingClient: loggingClient,
+			}
+
+			got, err := source.BatchLogHistogram(ctx, batch.Name, tc.params, tc.byHour, tc.logsProject)
+			if err != nil {
+				t.Fatalf("BatchLogHistogram() error = %v", err)
+			}
+			if got := logging.gotReq.GetFilter(); got != tc.wantFilter {
+				t.Errorf("got filter\n%s\nwant\n%s", got, tc.wantFilter)
+			}
+			wantResources := []string{"projects/my-project"}
+			if tc.logsProject != "" {
+				wantResources = []string{"projects/" + tc.logsProject}
+			}
+			if diff := cmp.Diff(wantResources, logging.gotReq.GetResourceNames()); diff != "" {
+				t.Errorf("resource names mismatch (-want +got):\n%s", diff)
+			}
+			if got["filter"] != tc.wantFilter {
+				t.Errorf("got result filter\n%s\nwant\n%s", got["filter"], tc.wantFilter)
+			}
+			if got["total"] != 4 || got["truncated"] != false {
+				t.Errorf("got total %v, truncated %v, want 4, false", got["total"], got["truncated"])
+			}
+			if diff := cmp.Diff(map[string]int{"INFO": 2, "ERROR": 2}, got["counts"]); diff != "" {
+				t.Errorf("counts mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantHourly, got["hourly"]); diff != "" {
+				t.Errorf("hourly mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// endlessLogging returns a full page of entries, and a token for another, for
+// every ListLogEntries request, after delay. If block is set, it instead waits
+// for the request to be cancelled.
+type endlessLogging struct {
+	loggingpb.UnimplementedLoggingServiceV2Server
+	delay time.Duration
+	block bool
+}
+
+func (f *endlessLogging) ListLogEntries(ctx context.Context, req *loggingpb.ListLogEntriesRequest) (*loggingpb.ListLogEntriesResponse, error) {
+	if f.block {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	time.Sleep(f.delay)
+	entries := make([]*loggingpb.LogEntry, req.GetPageSize())
+	for i := range entries {
+		entries[i] = &loggingpb.LogEntry{
+			Severity:  ltype.LogSeverity_INFO,
+			Timestamp: timestamppb.New(time.Date(2026, 1, 2, 3, 30, 0, 0, time.UTC)),
+			Payload:   &loggingpb.LogEntry_TextPayload{TextPayload: "message"},
+		}
+	}
+	return &loggingpb.ListLogEntriesResponse{Entries: entries, NextPageToken: "more"}, nil
+}
+
+func TestBatchLogHistogramDeadline(t *testing.T) {
+	tcs := []struct {
+		desc    string
+		logging *endlessLogging
+		wantErr bool
+	}{
+		{desc: "partial result", logging: &endlessLogging{delay: 20 * time.Millisecond}},
+		{desc: "no entries before deadline", logging: &endlessLogging{block: true}, wantErr: true},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			lis, err := net.Listen("tcp", "localhost:0")
+			if err != nil {
+				t.Fatalf("failed to listen: %v", err)
+			}
+			batch := &dataprocpb.Batch{
+				Name:       "projects/my-project/locations/us-central1/batches/my-batch",
+				State:      dataprocpb.Batch_FAILED,
+				CreateTime: timestamppb.New(time.Date(2026, 1, 2, 3, 0, 0, 0, time.UTC)),
+				StateTime:  timestamppb.New(time.Date(2026, 1, 2, 4, 0, 0, 0, time.UTC)),
+			}
+			srv := grpc.NewServer()
+			dataprocpb.RegisterBatchControllerServer(srv, &fakeBatchController{batch: batch})
+			loggingpb.RegisterLoggingServiceV2Server(srv, tc.logging)
+			go func() { _ = srv.Serve(lis) }()
+			t.Cleanup(srv.Stop)
+
+			ctx := context.Background()
+			opts := []option.ClientOption{
+				option.WithEndpoint(lis.Addr().String()),
+				option.WithoutAuthentication(),
+				option.WithGRPCDialOption(grpc.WithTransportCredentials(insecure.NewCredentials())),
+			}
+			batchClient, err := dataproc.NewBatchControllerClient(ctx, opts...)
+			if err != nil {
+				t.Fatalf("failed to create batch client: %v", err)
+			}
+			t.Cleanup(func() { batchClient.Close() })
+			loggingClient, err := logadmin.NewClient(ctx, "my-project", opts...)
+			if err != nil {
+				t.Fatalf("failed to create logging client: %v", err)
+			}
+			t.Cleanup(func() { loggingClient.Close() })
+			source := &serverlessspark.Source{
+				Config:        serverlessspark.Config{Project: "my-project", Location: "us-central1"},
+				BatchClient:   batchClient,
+				LoggingClient: loggingClient,
+			}
+
+			ctx, cancel := context.WithTimeout(ctx, 200*time.Millisecond)
+			defer cancel()
+			start := time.Now()
+			got, err := source.BatchLogHistogram(ctx, batch.Name, serverlessspark.LogTimeRange{}, false, "")
+			// Listing all entries would take 100 pages, i.e. 2s.
+			if elapsed := time.Since(start); elapsed > time.Second {
+				t.Errorf("BatchLogHistogram() took %s after a 200ms deadline", elapsed)
+			}
+			if tc.wantErr {
+				if err == nil {
+					t.Errorf("BatchLogHistogram() = %v, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("BatchLogHistogram() error = %v", err)
+			}
+			if got["truncated"] != true || got["deadlineExceeded"] != true {
+				t.Errorf("got truncated %v, deadlineExceeded %v, want true, true", got["truncated"], got["deadlineExceeded"])
+			}
+			if total, _ := got["total"].(int); total == 0 {
+				t.Errorf("got total %v, want the entries counted before the deadline", got["total"])
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serverlessspark
+
+import (
+	"time"
+
+	"cloud.google.com/go/dataproc/v2/apiv1/dataprocpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// LogResource is a Serverless Spark resource that writes logs, i.e. a
+// *dataprocpb.Batch or a *dataprocpb.Session.
+type LogResource interface {
+	GetCreateTime() *timestamppb.Timestamp
+	GetStateTime() *timestamppb.Timestamp
+}
+
+// LogTimeRange is a time range for the logs of a batch or session. A zero
+// Start or End means the range is unbounded on that side.
+type LogTimeRange struct {
+	Start time.Time
+	End   time.Time
+	// EndIsStateTime is set if End is the state time of a resource in a
+	// final state, rather than a time set by the caller. The resource writes
+	// no logs after it, so filters for the range do not extend End.
+	EndIsStateTime bool
+}
+
+// ResolveLogTimeRange returns the time range covering the logs of the given
+// batch or session.
+//
+// Times set in params take precedence. Otherwise, the range starts at the
+// resource's create time and, unless the resource is still running, ends at
+// its state time, i.e. when it reached its final state. The end of the range
+// of a running resource is left unbounded, since it is still writing logs.
+func ResolveLogTimeRange(resource LogResource, params LogTimeRange) LogTimeRange {
+	r := params
+	if r.Start.IsZero() {
+		r.Start = timeOrZero(resource.GetCreateTime())
+	}
+	if r.End.IsZero() && !isRunning(resource) {
+		r.End = timeOrZero(resource.GetStateTime())
+		r.EndIsStateTime = !r.End.IsZero()
+	}
+	return r
+}
+
+// isRunning returns whether the given batch or session may still write logs.
+func isRunning(resource LogResource) bool {
+	switch r := resource.(type) {
+	case *dataprocpb.Batch:
+		switch r.GetState() {
+		case dataprocpb.Batch_PENDING, dataprocpb.Batch_RUNNING, dataprocpb.Batch_CANCELLING:
+			return true
+		}
+	case *dataprocpb.Session:
+		switch r.GetState() {
+		case dataprocpb.Session_CREATING, dataprocpb.Session_ACTIVE, dataprocpb.Session_TERMINATING:
+			return true
+		}
+	}
+	return false
+}
+
+// timeOrZero converts ts to a time.Time, returning the zero time if ts is
+// unset rather than the Unix epoch.
+func timeOrZero(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return ts.AsTime()
+}
@@ -0,0 +1,110 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serverlessspark_test
+
+import (
+	"testing"
+	"time"
+
+	"cloud.google.com/go/dataproc/v2/apiv1/dataprocpb"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/mcp-toolbox/internal/sources/serverlessspark"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestResolveLogTimeRange(t *testing.T) {
+	createTime := time.Date(2025, 10, 1, 5, 0, 0, 0, time.UTC)
+	stateTime := time.Date(2025, 10, 1, 6, 0, 0, 0, time.UTC)
+	userStart := time.Date(2025, 10, 1, 5, 30, 0, 0, time.UTC)
+	userEnd := time.Date(2025, 10, 1, 5, 45, 0, 0, time.UTC)
+
+	batch := func(state dataprocpb.Batch_State) *dataprocpb.Batch {
+		return &dataprocpb.Batch{
+			State:      state,
+			CreateTime: timestamppb.New(createTime),
+			StateTime:  timestamppb.New(stateTime),
+		}
+	}
+	session := func(state dataprocpb.Session_State) *dataprocpb.Session {
+		return &dataprocpb.Session{
+			State:      state,
+			CreateTime: timestamppb.New(createTime),
+			StateTime:  timestamppb.New(stateTime),
+		}
+	}
+
+	tcs := []struct {
+		desc     string
+		resource serverlessspark.LogResource
+		params   serverlessspark.LogTimeRange
+		want     serverlessspark.LogTimeRange
+	}{
+		{
+			desc:     "running batch",
+			resource: batch(dataprocpb.Batch_RUNNING),
+			want:     serverlessspark.LogTimeRange{Start: createTime},
+		},
+		{
+			desc:     "pending batch",
+			resource: batch(dataprocpb.Batch_PENDING),
+			want:     serverlessspark.LogTimeRange{Start: createTime},
+		},
+		{
+			desc:     "failed batch",
+			resource: batch(dataprocpb.Batch_FAILED),
+			want:     serverlessspark.LogTimeRange{Start: createTime, End: stateTime, EndIsStateTime: true},
+		},
+		{
+			desc:     "succeeded batch",
+			resource: batch(dataprocpb.Batch_SUCCEEDED),
+			want:     serverlessspark.LogTimeRange{Start: createTime, End: stateTime, EndIsStateTime: true},
+		},
+		{
+			desc:     "active session",
+			resource: session(dataprocpb.Session_ACTIVE),
+			want:     serverlessspark.LogTimeRange{Start: createTime},
+		},
+		{
+			desc:     "terminated session",
+			resource: session(dataprocpb.Session_TERMINATED),
+			want:     serverlessspark.LogTimeRange{Start: createTime, End: stateTime, EndIsStateTime: true},
+		},
+		{
+			desc:     "user-supplied times override terminal batch",
+			resource: batch(dataprocpb.Batch_FAILED),
+			params:   serverlessspark.LogTimeRange{Start: userStart, End: userEnd},
+			want:     serverlessspark.LogTimeRange{Start: userStart, End: userEnd},
+		},
+		{
+			desc:     "user-supplied end on running session",
+			resource: session(dataprocpb.Session_ACTIVE),
+			params:   serverlessspark.LogTimeRange{End: userEnd},
+			want:     serverlessspark.LogTimeRange{Start: createTime, End: userEnd},
+		},
+		{
+			desc:     "missing timestamps",
+			resource: &dataprocpb.Batch{State: dataprocpb.Batch_SUCCEEDED},
+			want:     serverlessspark.LogTimeRange{},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := serverlessspark.ResolveLogTimeRange(tc.resource, tc.params)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("ResolveLogTimeRange() diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,194 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serverlessspark
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"cloud.google.com/go/monitoring/apiv3/v2/monitoringpb"
+	"google.golang.org/api/iterator"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// batchMetric is a Cloud Monitoring metric of batches that BatchMetrics can
+// fetch.
+type batchMetric struct {
+	// metricType is the Cloud Monitoring metric type.
+	metricType string
+	// aligner down-samples the points in each window, e.g. averaging a gauge
+	// or summing the deltas of a cumulative metric.
+	aligner monitoringpb.Aggregation_Aligner
+}
+
+// batchMetrics are the metrics BatchMetrics can fetch, by the names that tools
+// accept.
+var batchMetrics = map[string]batchMetric{
+	"executors": {
+		metricType: "dataproc.googleapis.com/batch/spark/executors",
+		aligner:    monitoringpb.Aggregation_ALIGN_MEAN,
+	},
+	"shuffle_bytes_read": {
+		metricType: "dataproc.googleapis.com/batch/spark/executor/shuffle_read_bytes",
+		aligner:    monitoringpb.Aggregation_ALIGN_DELTA,
+	},
+	"shuffle_bytes_written": {
+		metricType: "dataproc.googleapis.com/batch/spark/executor/shuffle_write_bytes",
+		aligner:    monitoringpb.Aggregation_ALIGN_DELTA,
+	},
+	"driver_memory_used": {
+		metricType: "dataproc.googleapis.com/batch/spark/driver/memory_used_bytes",
+		aligner:    monitoringpb.Aggregation_ALIGN_MAX,
+	},
+}
+
+// BatchMetricNames returns the names of the metrics BatchMetrics can fetch,
+// sorted.
+func BatchMetricNames() []string {
+	return slices.Sorted(maps.Keys(batchMetrics))
+}
+
+const (
+	// MinMetricsWindow is the smallest window BatchMetrics accepts, the
+	// smallest alignment period of Cloud Monitoring.
+	MinMetricsWindow = time.Minute
+	// MaxMetricsWindow is the largest window BatchMetrics accepts.
+	MaxMetricsWindow = 24 * time.Hour
+	// DefaultMetricsWindow is the window tools use if none is given.
+	DefaultMetricsWindow = 5 * time.Minute
+)
+
+// maxMetricPoints bounds the number of points in each series that
+// BatchMetrics returns. If the batch's time range would have more windows, the
+// window is widened.
+const maxMetricPoints = 500
+
+// ValidateMetricsWindow returns an error if window can't be used to
+// down-sample metrics.
+func ValidateMetricsWindow(window time.Duration) error {
+	if window < MinMetricsWindow || window > MaxMetricsWindow {
+		return fmt.Errorf("must be between %s and %s: %s", MinMetricsWindow, MaxMetricsWindow, window)
+	}
+	if window%time.Second != 0 {
+		return fmt.Errorf("must be a whole number of seconds: %s", window)
+	}
+	return nil
+}
+
+// BatchMetrics fetches the time series of the given metric, e.g. "executors",
+// for the batch with the given full name, from its create time until it
+// finished, or now if it is still running. The points are down-sampled to one
+// per window, oldest first.
+//
+// The window actually used, which is widened if the batch ran for more than
+// maxMetricPoints windows, is returned as "window".
+func (s *Source) BatchMetrics(ctx context.Context, name, metric string, window time.Duration) (map[string]any, error) {
+	m, ok := batchMetrics[metric]
+	if !ok {
+		return nil, fmt.Errorf("metric must be one of %v: %q", BatchMetricNames(), metric)
+	}
+	if err := ValidateMetricsWindow(window); err != nil {
+		return nil, fmt.Errorf("window %w", err)
+	}
+	projectID, location, batchID, err := ExtractBatchDetails(name)
+	if err != nil {
+		return nil, err
+	}
+	batchPb, err := s.getBatchCached(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get batch: %w", err)
+	}
+	r := ResolveLogTimeRange(batchPb, LogTimeRange{})
+	if r.End.IsZero() {
+		r.End = time.Now()
+	}
+	if r.Start.IsZero() || !r.Start.Before(r.End) {
+		return nil, fmt.Errorf("batch %q has no time range to fetch metrics for", name)
+	}
+	if d := r.End.Sub(r.Start); d > maxMetricPoints*window {
+		// Round up to whole minutes, so that the window stays readable.
+		window = (d/maxMetricPoints + time.Minute - 1).Truncate(time.Minute)
+	}
+
+	filter := fmt.Sprintf(`metric.type=%q AND resource.type=%q AND resource.labels.project_id=%q AND resource.labels.location=%q AND resource.labels.batch_id=%q`,
+		m.metricType, BatchLogResourceType, projectID, location, batchID)
+	req := &monitoringpb.ListTimeSeriesRequest{
+		Name:   "projects/" + projectID,
+		Filter: filter,
+		Interval: &monitoringpb.TimeInterval{
+			StartTime: timestamppb.New(r.Start),
+			EndTime:   timestamppb.New(r.End),
+		},
+		Aggregation: &monitoringpb.Aggregation{
+			AlignmentPeriod:  durationpb.New(window),
+			PerSeriesAligner: m.aligner,
+		},
+		View: monitoringpb.ListTimeSeriesRequest_FULL,
+	}
+	it := s.GetMonitoringClient().ListTimeSeries(ctx, req)
+	series := []map[string]any{}
+	for {
+		ts, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list time series: %w", err)
+		}
+		series = append(series, timeSeriesToMap(ts))
+	}
+
+	return map[string]any{
+		"batch":      name,
+		"metric":     metric,
+		"metricType": m.metricType,
+		"startTime":  r.Start.UTC().Format(time.RFC3339),
+		"endTime":    r.End.UTC().Format(time.RFC3339),
+		"window":     window.String(),
+		"series":     series,
+	}, nil
+}
+
+// timeSeriesToMap converts ts to its labels and its points, oldest first.
+func timeSeriesToMap(ts *monitoringpb.TimeSeries) map[string]any {
+	points := make([]map[string]any, 0, len(ts.GetPoints()))
+	// Cloud Monitoring returns the newest point first.
+	for _, p := range slices.Backward(ts.GetPoints()) {
+		point := map[string]any{
+			"time": p.GetInterval().GetEndTime().AsTime().UTC().Format(time.RFC3339),
+		}
+		switch v := p.GetValue().GetValue().(type) {
+		case *monitoringpb.TypedValue_Int64Value:
+			point["value"] = v.Int64Value
+		case *monitoringpb.TypedValue_DoubleValue:
+			point["value"] = v.DoubleValue
+		case *monitoringpb.TypedValue_BoolValue:
+			point["value"] = v.BoolValue
+		}
+		points = append(points, point)
+	}
+	result := map[string]any{"points": points}
+	if labels := ts.GetMetric().GetLabels(); len(labels) > 0 {
+		result["labels"] = labels
+	}
+	if unit := ts.GetUnit(); unit != "" {
+		result["unit"] = unit
+	}
+	return result
+}
@@ -0,0 +1,183 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serverlessspark_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	dataproc "cloud.google.com/go/dataproc/v2/apiv1"
+	"cloud.google.com/go/dataproc/v2/apiv1/dataprocpb"
+	monitoring "cloud.google.com/go/monitoring/apiv3/v2"
+	"cloud.google.com/go/monitoring/apiv3/v2/monitoringpb"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/mcp-toolbox/internal/sources/serverlessspark"
+	"google.golang.org/api/option"
+	"google.golang.org/genproto/googleapis/api/metric"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// fakeMetrics records the ListTimeSeries request and returns series.
+type fakeMetrics struct {
+	monitoringpb.UnimplementedMetricServiceServer
+	series []*monitoringpb.TimeSeries
+	gotReq *monitoringpb.ListTimeSeriesRequest
+}
+
+func (f *fakeMetrics) ListTimeSeries(ctx context.Context, req *monitoringpb.ListTimeSeriesRequest) (*monitoringpb.ListTimeSeriesResponse, error) {
+	f.gotReq = req
+	return &monitoringpb.ListTimeSeriesResponse{TimeSeries: f.series}, nil
+}
+
+func point(minute int, value float64) *monitoringpb.Point {
+	return &monitoringpb.Point{
+		Interval: &monitoringpb.TimeInterval{EndTime: timestamppb.New(time.Date(2026, 1, 2, 3, minute, 0, 0, time.UTC))},
+		Value:    &monitoringpb.TypedValue{Value: &monitoringpb.TypedValue_DoubleValue{DoubleValue: value}},
+	}
+}
+
+func TestBatchMetrics(t *testing.T) {
+	tcs := []struct {
+		desc       string
+		stateTime  time.Time
+		window     time.Duration
+		wantWindow string
+		wantPeriod time.Duration
+	}{
+		{
+			desc:       "window",
+			stateTime:  time.Date(2026, 1, 2, 4, 0, 0, 0, time.UTC),
+			window:     5 * time.Minute,
+			wantWindow: "5m0s",
+			wantPeriod: 5 * time.Minute,
+		},
+		{
+			desc:       "widened window",
+			stateTime:  time.Date(2026, 1, 12, 3, 0, 0, 0, time.UTC),
+			window:     time.Minute,
+			wantWindow: "29m0s",
+			wantPeriod: 29 * time.Minute,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			lis, err := net.Listen("tcp", "localhost:0")
+			if err != nil {
+				t.Fatalf("failed to listen: %v", err)
+			}
+			metrics := &fakeMetrics{series: []*monitoringpb.TimeSeries{{
+				Metric: &metric.Metric{Labels: map[string]string{"status": "running"}},
+				Unit:   "1",
+				Points: []*monitoringpb.Point{point(10, 4), point(5, 2.5)},
+			}}}
+			batch := &dataprocpb.Batch{
+				Name:       "projects/my-project/locations/us-central1/batches/my-batch",
+				State:      dataprocpb.Batch_SUCCEEDED,
+				CreateTime: timestamppb.New(time.Date(2026, 1, 2, 3, 0, 0, 0, time.UTC)),
+				StateTime:  timestamppb.New(tc.stateTime),
+			}
+			srv := grpc.NewServer()
+			dataprocpb.RegisterBatchControllerServer(srv, &fakeBatchController{batch: batch})
+			monitoringpb.RegisterMetricServiceServer(srv, metrics)
+			go func() { _ = srv.Serve(lis) }()
+			t.Cleanup(srv.Stop)
+
+			ctx := context.Background()
+			opts := []option.ClientOption{
+				option.WithEndpoint(lis.Addr().String()),
+				option.WithoutAuthentication(),
+				option.WithGRPCDialOption(grpc.WithTransportCredentials(insecure.NewCredentials())),
+			}
+			batchClient, err := dataproc.NewBatchControllerClient(ctx, opts...)
+			if err != nil {
+				t.Fatalf("failed to create batch client: %v", err)
+			}
+			t.Cleanup(func() { batchClient.Close() })
+			monitoringClient, err := monitoring.NewMetricClient(ctx, opts...)
+			if err != nil {
+				t.Fatalf("failed to create monitoring client: %v", err)
+			}
+			t.Cleanup(func() { monitoringClient.Close() })
+			source := &serverlessspark.Source{
+				Config:           serverlessspark.Config{Project: "my-project", Location: "us-central1"},
+				BatchClient:      batchClient,
+				MonitoringClient: monitoringClient,
+			}
+
+			got, err := source.BatchMetrics(ctx, batch.Name, "executors", tc.window)
+			if err != nil {
+				t.Fatalf("BatchMetrics() error = %v", err)
+			}
+			want := map[string]any{
+				"batch":      batch.Name,
+				"metric":     "executors",
+				"metricType": "dataproc.googleapis.com/batch/spark/executors",
+				"startTime":  "2026-01-02T03:00:00Z",
+				"endTime":    tc.stateTime.Format(time.RFC3339),
+				"window":     tc.wantWindow,
+				"series": []map[string]any{{
+					"labels": map[string]string{"status": "running"},
+					"unit":   "1",
+					"points": []map[string]any{
+						{"time": "2026-01-02T03:05:00Z", "value": 2.5},
+						{"time": "2026-01-02T03:10:00Z", "value": 4.0},
+					},
+				}},
+			}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("incorrect result (-want +got):\n%s", diff)
+			}
+
+			req := metrics.gotReq
+			wantFilter := `metric.type="dataproc.googleapis.com/batch/spark/executors" AND resource.type="cloud_dataproc_batch" AND resource.labels.project_id="my-project" AND resource.labels.location="us-central1" AND resource.labels.batch_id="my-batch"`
+			if got := req.GetFilter(); got != wantFilter {
+				t.Errorf("got filter %q, want %q", got, wantFilter)
+			}
+			if got, want := req.GetName(), "projects/my-project"; got != want {
+				t.Errorf("got name %q, want %q", got, want)
+			}
+			if got := req.GetAggregation().GetAlignmentPeriod().AsDuration(); got != tc.wantPeriod {
+				t.Errorf("got alignment period %s, want %s", got, tc.wantPeriod)
+			}
+			if got, want := req.GetAggregation().GetPerSeriesAligner(), monitoringpb.Aggregation_ALIGN_MEAN; got != want {
+				t.Errorf("got aligner %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestBatchMetricsInvalid(t *testing.T) {
+	source := &serverlessspark.Source{}
+	for _, tc := range []struct {
+		desc   string
+		metric string
+		window time.Duration
+	}{
+		{desc: "unknown metric", metric: "cpu", window: time.Minute},
+		{desc: "window too small", metric: "executors", window: 30 * time.Second},
+		{desc: "window too large", metric: "executors", window: 48 * time.Hour},
+		{desc: "fractional window", metric: "executors", window: 90*time.Second + time.Millisecond},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			if _, err := source.BatchMetrics(context.Background(), "projects/my-project/locations/us-central1/batches/my-batch", tc.metric, tc.window); err == nil {
+				t.Errorf("BatchMetrics() succeeded, want error")
+			}
+		})
+	}
+}
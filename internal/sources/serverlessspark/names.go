@@ -0,0 +1,120 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serverlessspark
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// projectIDRegex matches project IDs, including domain-scoped project IDs
+// such as example.com:my-project.
+var projectIDRegex = regexp.MustCompile(`^([a-z][a-z0-9.-]*[a-z0-9]:)?[a-z][a-z0-9-]{4,28}[a-z0-9]$`)
+
+// ValidateProjectID returns an error if project is not a valid project ID.
+func ValidateProjectID(project string) error {
+	if !projectIDRegex.MatchString(project) {
+		return fmt.Errorf("invalid project %q: must be a project ID like my-project", project)
+	}
+	return nil
+}
+
+// resourceIDRegex matches batch and session IDs, which the API requires to be
+// 4-63 characters of lowercase letters, numbers, and hyphens. They may start
+// with a number, as the IDs Dataproc generates for batches, which are UUIDs,
+// often do.
+var resourceIDRegex = regexp.MustCompile(`^[a-z0-9-]{4,63}$`)
+
+// ValidateBatchID returns an error if id, the batchId parameter of the create
+// batch tools, is not a batch ID that Dataproc accepts, explaining which rule
+// it breaks, e.g. "batchId must be a batch ID of 4-63 ..., not 3 characters:
+// abc".
+func ValidateBatchID(id string) error {
+	if err := validateResourceID("batch", id); err != nil {
+		return fmt.Errorf("batchId %w", err)
+	}
+	return nil
+}
+
+// BatchResourceName returns the full resource name of the batch with the given
+// ID, e.g. projects/my-project/locations/us-central1/batches/my-batch. It
+// returns an error if id is not a valid batch ID.
+func BatchResourceName(project, location, id string) (string, error) {
+	if err := validateResourceID("batch", id); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("projects/%s/locations/%s/batches/%s", project, location, id), nil
+}
+
+// ValidateBatchName returns an error if name is not the full resource name of
+// a batch, e.g. projects/my-project/locations/us-central1/batches/my-batch,
+// as returned by other tools. Like validateResourceID, the error is phrased to
+// follow the name of the parameter.
+func ValidateBatchName(name string) error {
+	project, location, id, err := ExtractBatchDetails(name)
+	if err != nil || name != fmt.Sprintf("projects/%s/locations/%s/batches/%s", project, location, id) ||
+		!projectIDRegex.MatchString(project) || !locationRegex.MatchString(location) {
+		return fmt.Errorf("must be a full batch name like projects/my-project/locations/us-central1/batches/my-batch: %s", name)
+	}
+	return validateResourceID("batch", id)
+}
+
+// SessionResourceName returns the full resource name of the session with the
+// given ID, e.g. projects/my-project/locations/us-central1/sessions/my-session.
+// It returns an error if id is not a valid session ID.
+func SessionResourceName(project, location, id string) (string, error) {
+	if err := validateResourceID("session", id); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("projects/%s/locations/%s/sessions/%s", project, location, id), nil
+}
+
+// validateResourceID returns an error if id is not a valid ID for a resource of
+// the given kind. The error is phrased to follow the name of the parameter
+// that id came from, e.g. "name must be a short batch name without '/': ...".
+func validateResourceID(kind, id string) error {
+	if strings.Contains(id, "/") {
+		return fmt.Errorf("must be a short %s name without '/': %s", kind, id)
+	}
+	if resourceIDRegex.MatchString(id) {
+		return nil
+	}
+	rule := fmt.Sprintf("must be a %s ID of 4-63 lowercase letters, numbers, and hyphens", kind)
+	if n := utf8.RuneCountInString(id); n < 4 || n > 63 {
+		return fmt.Errorf("%s, not %d characters: %s", rule, n, id)
+	}
+	if strings.ToLower(id) != id {
+		return fmt.Errorf("%s, without uppercase letters: %s", rule, id)
+	}
+	for _, r := range id {
+		if !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '-') {
+			return fmt.Errorf("%s, without %q: %s", rule, r, id)
+		}
+	}
+	return fmt.Errorf("%s: %s", rule, id)
+}
+
+// locationRegex matches Google Cloud regions such as us-central1.
+var locationRegex = regexp.MustCompile(`^[a-z]+-[a-z]+[0-9]+$`)
+
+// ValidateLocation returns an error if location is not a valid region.
+func ValidateLocation(location string) error {
+	if !locationRegex.MatchString(location) {
+		return fmt.Errorf("invalid location %q: must be a region like us-central1", location)
+	}
+	return nil
+}
@@ -0,0 +1,195 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serverlessspark_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/googleapis/mcp-toolbox/internal/sources/serverlessspark"
+)
+
+func TestResourceNames(t *testing.T) {
+	tcs := []struct {
+		desc       string
+		fn         func(project, location, id string) (string, error)
+		id         string
+		want       string
+		wantSubstr string
+	}{
+		{
+			desc: "batch",
+			fn:   serverlessspark.BatchResourceName,
+			id:   "my-batch-1",
+			want: "projects/my-project/locations/us-central1/batches/my-batch-1",
+		},
+		{
+			desc: "generated batch ID",
+			fn:   serverlessspark.BatchResourceName,
+			id:   "0b4f7c2e-5a1d-4b8e-9c3f-2d6e8a1b7c4d",
+			want: "projects/my-project/locations/us-central1/batches/0b4f7c2e-5a1d-4b8e-9c3f-2d6e8a1b7c4d",
+		},
+		{
+			desc:       "full batch name",
+			fn:         serverlessspark.BatchResourceName,
+			id:         "projects/my-project/locations/us-central1/batches/my-batch",
+			wantSubstr: "must be a short batch name without '/'",
+		},
+		{
+			desc:       "invalid batch ID",
+			fn:         serverlessspark.BatchResourceName,
+			id:         "My_Batch",
+			wantSubstr: "must be a batch ID of 4-63 lowercase letters, numbers, and hyphens",
+		},
+		{
+			desc:       "short batch ID",
+			fn:         serverlessspark.BatchResourceName,
+			id:         "abc",
+			wantSubstr: "must be a batch ID",
+		},
+		{
+			desc: "session",
+			fn:   serverlessspark.SessionResourceName,
+			id:   "my-session",
+			want: "projects/my-project/locations/us-central1/sessions/my-session",
+		},
+		{
+			desc:       "full session name",
+			fn:         serverlessspark.SessionResourceName,
+			id:         "sessions/my-session",
+			wantSubstr: "must be a short session name without '/'",
+		},
+		{
+			desc:       "long session ID",
+			fn:         serverlessspark.SessionResourceName,
+			id:         strings.Repeat("a", 64),
+			wantSubstr: "must be a session ID",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := tc.fn("my-project", "us-central1", tc.id)
+			if tc.wantSubstr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantSubstr) {
+					t.Fatalf("expected error containing %q, got %v", tc.wantSubstr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestValidateBatchID(t *testing.T) {
+	tcs := []struct {
+		desc       string
+		id         string
+		wantSubstr string
+	}{
+		{desc: "valid", id: "my-batch-1"},
+		{desc: "leading digit", id: "0b4f7c2e-5a1d-4b8e-9c3f-2d6e8a1b7c4d"},
+		{desc: "minimum length", id: "abcd"},
+		{desc: "maximum length", id: strings.Repeat("a", 63)},
+		{desc: "too short", id: "abc", wantSubstr: "batchId must be a batch ID of 4-63 lowercase letters, numbers, and hyphens, not 3 characters: abc"},
+		{desc: "too long", id: strings.Repeat("a", 64), wantSubstr: "not 64 characters"},
+		{desc: "uppercase", id: "My-Batch", wantSubstr: "without uppercase letters: My-Batch"},
+		{desc: "underscore", id: "my_batch", wantSubstr: `without '_': my_batch`},
+		{desc: "dot", id: "my.batch", wantSubstr: `without '.': my.batch`},
+		{desc: "non-ASCII", id: "my-bätch", wantSubstr: `without 'ä': my-bätch`},
+		{desc: "slash", id: "batches/my-batch", wantSubstr: "batchId must be a short batch name without '/'"},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := serverlessspark.ValidateBatchID(tc.id)
+			if tc.wantSubstr == "" {
+				if err != nil {
+					t.Errorf("ValidateBatchID(%q) = %v, want nil", tc.id, err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantSubstr) {
+				t.Errorf("ValidateBatchID(%q) = %v, want error containing %q", tc.id, err, tc.wantSubstr)
+			}
+		})
+	}
+}
+
+func TestValidateProjectID(t *testing.T) {
+	tcs := []struct {
+		project string
+		wantErr bool
+	}{
+		{project: "my-project"},
+		{project: "example.com:my-project"},
+		{project: "projects/my-project", wantErr: true},
+		{project: "My-Project", wantErr: true},
+		{project: "abc", wantErr: true},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.project, func(t *testing.T) {
+			err := serverlessspark.ValidateProjectID(tc.project)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("ValidateProjectID(%q) = %v, want error: %v", tc.project, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateLocation(t *testing.T) {
+	for _, location := range []string{"us-central1", "europe-west4", "northamerica-northeast1"} {
+		if err := serverlessspark.ValidateLocation(location); err != nil {
+			t.Errorf("ValidateLocation(%q) = %v, want nil", location, err)
+		}
+	}
+	for _, location := range []string{"", "us-central", "US-CENTRAL1", "global", "us-central1/batches"} {
+		if err := serverlessspark.ValidateLocation(location); err == nil {
+			t.Errorf("ValidateLocation(%q) = nil, want error", location)
+		}
+	}
+}
+
+func TestValidateBatchName(t *testing.T) {
+	tcs := []struct {
+		name       string
+		wantSubstr string
+	}{
+		{name: "projects/my-project/locations/us-central1/batches/my-batch"},
+		{name: "projects/example.com:my-project/locations/europe-west4/batches/my-batch"},
+		{name: "my-batch", wantSubstr: "must be a full batch name"},
+		{name: "projects/my-project/locations/us-central1/sessions/my-session", wantSubstr: "must be a full batch name"},
+		{name: "x/projects/my-project/locations/us-central1/batches/my-batch", wantSubstr: "must be a full batch name"},
+		{name: "projects/my-project/locations/evil.example.com/batches/my-batch", wantSubstr: "must be a full batch name"},
+		{name: "projects/my-project/locations/us-central1/batches/My_Batch", wantSubstr: "must be a batch ID"},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			err := serverlessspark.ValidateBatchName(tc.name)
+			if tc.wantSubstr == "" {
+				if err != nil {
+					t.Errorf("ValidateBatchName(%q) = %v, want nil", tc.name, err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantSubstr) {
+				t.Errorf("ValidateBatchName(%q) = %v, want error containing %q", tc.name, err, tc.wantSubstr)
+			}
+		})
+	}
+}
@@ -0,0 +1,140 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serverlessspark
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"cloud.google.com/go/dataproc/v2/apiv1/dataprocpb"
+)
+
+// defaultResourceCacheTTL is how long a running batch or session is cached if
+// resourceCacheTTL is unset.
+const defaultResourceCacheTTL = 30 * time.Second
+
+// finalResourceCacheTTLFactor is how many times longer than a running one a
+// batch or session in a final state is cached. It no longer changes, so only
+// its deletion can make the cached copy stale.
+const finalResourceCacheTTLFactor = 10
+
+// resourceCache caches batches and sessions by full resource name, so that
+// tools that look up the same resource repeatedly, e.g. to resolve the time
+// range of its logs, do not call the API each time. It is safe for concurrent
+// use. A nil *resourceCache caches nothing.
+//
+// Cached resources are shared and must not be modified.
+type resourceCache struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]resourceCacheEntry
+}
+
+type resourceCacheEntry struct {
+	resource  LogResource
+	expiresAt time.Time
+}
+
+// newResourceCache returns a cache that keeps running resources for ttl, or
+// nil if ttl is not positive.
+func newResourceCache(ttl time.Duration) *resourceCache {
+	if ttl <= 0 {
+		return nil
+	}
+	return &resourceCache{ttl: ttl, now: time.Now, entries: make(map[string]resourceCacheEntry)}
+}
+
+// get returns the cached resource with the given name, if it has not expired.
+func (c *resourceCache) get(name string) (LogResource, bool) {
+	if c == nil {
+		return nil, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[name]
+	if !ok {
+		return nil, false
+	}
+	if !c.now().Before(e.expiresAt) {
+		delete(c.entries, name)
+		return nil, false
+	}
+	return e.resource, true
+}
+
+// put caches resource under name. Expired entries are removed at the same
+// time, so that the cache does not grow without bound.
+func (c *resourceCache) put(name string, resource LogResource) {
+	if c == nil {
+		return
+	}
+	ttl := c.ttl
+	if !isRunning(resource) {
+		ttl *= finalResourceCacheTTLFactor
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now()
+	for k, e := range c.entries {
+		if !now.Before(e.expiresAt) {
+			delete(c.entries, k)
+		}
+	}
+	c.entries[name] = resourceCacheEntry{resource: resource, expiresAt: now.Add(ttl)}
+}
+
+// getBatchCached returns the batch with the given full name, from the cache if
+// it was fetched recently.
+func (s *Source) getBatchCached(ctx context.Context, name string) (*dataprocpb.Batch, error) {
+	if r, ok := s.resourceCache.get(name); ok {
+		if batchPb, ok := r.(*dataprocpb.Batch); ok {
+			return batchPb, nil
+		}
+	}
+	_, location, _, err := ExtractBatchDetails(name)
+	if err != nil {
+		return nil, err
+	}
+	client, closeClient, err := s.batchClientForLocation(ctx, location)
+	if err != nil {
+		return nil, err
+	}
+	defer closeClient()
+	batchPb, err := client.GetBatch(ctx, &dataprocpb.GetBatchRequest{Name: name})
+	if err != nil {
+		return nil, err
+	}
+	s.resourceCache.put(name, batchPb)
+	return batchPb, nil
+}
+
+// getSessionCached returns the session with the given full name, from the
+// cache if it was fetched recently.
+func (s *Source) getSessionCached(ctx context.Context, name string) (*dataprocpb.Session, error) {
+	if r, ok := s.resourceCache.get(name); ok {
+		if sessionPb, ok := r.(*dataprocpb.Session); ok {
+			return sessionPb, nil
+		}
+	}
+	sessionPb, err := s.GetSessionControllerClient().GetSession(ctx, &dataprocpb.GetSessionRequest{Name: name})
+	if err != nil {
+		return nil, err
+	}
+	s.resourceCache.put(name, sessionPb)
+	return sessionPb, nil
+}
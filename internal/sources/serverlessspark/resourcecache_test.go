@@ -0,0 +1,86 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serverlessspark
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/dataproc/v2/apiv1/dataprocpb"
+)
+
+func TestResourceCache(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 0, 0, 0, time.UTC)
+	c := newResourceCache(30 * time.Second)
+	c.now = func() time.Time { return now }
+
+	running := &dataprocpb.Batch{Name: "running", State: dataprocpb.Batch_RUNNING}
+	failed := &dataprocpb.Batch{Name: "failed", State: dataprocpb.Batch_FAILED}
+	c.put("running", running)
+	c.put("failed", failed)
+
+	check := func(name string, want LogResource) {
+		t.Helper()
+		got, ok := c.get(name)
+		if want == nil && ok {
+			t.Errorf("get(%q) = %v, want no entry", name, got)
+		}
+		if want != nil && got != want {
+			t.Errorf("get(%q) = %v, %t, want %v", name, got, ok, want)
+		}
+	}
+	check("running", running)
+	check("failed", failed)
+	check("unknown", nil)
+
+	// Running resources expire after the TTL, final ones only later.
+	now = now.Add(30 * time.Second)
+	check("running", nil)
+	check("failed", failed)
+	now = now.Add(270 * time.Second)
+	check("failed", nil)
+}
+
+func TestResourceCacheDisabled(t *testing.T) {
+	c := newResourceCache(0)
+	if c != nil {
+		t.Fatalf("newResourceCache(0) = %v, want nil", c)
+	}
+	c.put("batch", &dataprocpb.Batch{})
+	if _, ok := c.get("batch"); ok {
+		t.Error("disabled cache returned an entry")
+	}
+}
+
+func TestResourceCacheConcurrent(t *testing.T) {
+	c := newResourceCache(time.Minute)
+	var wg sync.WaitGroup
+	for i := range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			name := string(rune('a' + i))
+			c.put(name, &dataprocpb.Session{Name: name})
+			c.get(name)
+		}()
+	}
+	wg.Wait()
+	for i := range 10 {
+		if _, ok := c.get(string(rune('a' + i))); !ok {
+			t.Errorf("entry %d missing", i)
+		}
+	}
+}
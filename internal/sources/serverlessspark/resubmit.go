@@ -0,0 +1,101 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serverlessspark
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"cloud.google.com/go/dataproc/v2/apiv1/dataprocpb"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/proto"
+)
+
+// reservedLabelPrefix is the prefix of the labels Dataproc adds to batches,
+// e.g. goog-dataproc-batch-uuid. They may not be set when creating a batch.
+const reservedLabelPrefix = "goog-"
+
+// ResubmitBatch creates a copy of the batch with the given full resource name
+// under a new batch ID, e.g. to re-run a failed batch. If args is non-nil, it
+// replaces the args of the batch's driver. The result is that of CreateBatch,
+// plus the new batchId.
+func (s *Source) ResubmitBatch(ctx context.Context, name string, args []string) (map[string]any, error) {
+	orig, err := s.GetBatchControllerClient().GetBatch(ctx, &dataprocpb.GetBatchRequest{Name: name})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get batch: %w", err)
+	}
+	_, _, origID, err := ExtractBatchDetails(orig.GetName())
+	if err != nil {
+		return nil, err
+	}
+	batch, err := ResubmittedBatch(orig, args)
+	if err != nil {
+		return nil, err
+	}
+	batchID := resubmittedBatchID(origID)
+	resp, err := s.createBatch(ctx, createBatchRequest(s.GetProject(), s.GetLocation(), batchID, batch, uuid.NewString()))
+	if err != nil {
+		return nil, err
+	}
+	resp["batchId"] = batchID
+	return resp, nil
+}
+
+// ResubmittedBatch returns a copy of batch that can be passed to CreateBatch:
+// the fields set by the server, including its reserved labels, are cleared.
+// If args is non-nil, it replaces the args of the batch's driver; Spark SQL
+// batches have no args, so args may not be set for them.
+func ResubmittedBatch(batch *dataprocpb.Batch, args []string) (*dataprocpb.Batch, error) {
+	b := &dataprocpb.Batch{
+		RuntimeConfig:     batch.GetRuntimeConfig(),
+		EnvironmentConfig: batch.GetEnvironmentConfig(),
+		BatchConfig:       batch.BatchConfig,
+	}
+	b = proto.Clone(b).(*dataprocpb.Batch)
+	for k, v := range batch.GetLabels() {
+		if strings.HasPrefix(k, reservedLabelPrefix) {
+			continue
+		}
+		if b.Labels == nil {
+			b.Labels = make(map[string]string)
+		}
+		b.Labels[k] = v
+	}
+	if args == nil {
+		return b, nil
+	}
+	switch c := b.BatchConfig.(type) {
+	case *dataprocpb.Batch_SparkBatch:
+		c.SparkBatch.Args = args
+	case *dataprocpb.Batch_PysparkBatch:
+		c.PysparkBatch.Args = args
+	case *dataprocpb.Batch_SparkRBatch:
+		c.SparkRBatch.Args = args
+	case *dataprocpb.Batch_SparkSqlBatch:
+		return nil, fmt.Errorf("args cannot be set for a Spark SQL batch")
+	default:
+		return nil, fmt.Errorf("args cannot be set for this batch")
+	}
+	return b, nil
+}
+
+// resubmittedBatchID returns a new batch ID derived from the ID of the batch
+// being resubmitted, with a random suffix, e.g. my-batch-1a2b3c4d.
+func resubmittedBatchID(origID string) string {
+	suffix := uuid.NewString()[:8]
+	prefix := origID[:min(len(origID), 63-len(suffix)-1)]
+	return strings.TrimRight(prefix, "-") + "-" + suffix
+}
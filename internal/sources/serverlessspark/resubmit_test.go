@@ -0,0 +1,149 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serverlessspark_test
+
+import (
+	"context"
+	"net"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	dataproc "cloud.google.com/go/dataproc/v2/apiv1"
+	"cloud.google.com/go/dataproc/v2/apiv1/dataprocpb"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/mcp-toolbox/internal/sources/serverlessspark"
+	"google.golang.org/api/option"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// failedBatch is a finished batch with the fields the server sets.
+func failedBatch() *dataprocpb.Batch {
+	return &dataprocpb.Batch{
+		Name:       "projects/my-project/locations/us-central1/batches/nightly-etl",
+		Uuid:       "aaaaaaaa-bbbb-cccc-dddd-eeeeeeeeeeee",
+		CreateTime: timestamppb.New(time.Date(2026, 1, 2, 3, 0, 0, 0, time.UTC)),
+		BatchConfig: &dataprocpb.Batch_PysparkBatch{PysparkBatch: &dataprocpb.PySparkBatch{
+			MainPythonFileUri: "gs://bucket/etl.py",
+			Args:              []string{"--date=2026-01-01"},
+		}},
+		RuntimeInfo:  &dataprocpb.RuntimeInfo{OutputUri: "gs://bucket/output"},
+		State:        dataprocpb.Batch_FAILED,
+		StateMessage: "Job failed",
+		StateTime:    timestamppb.New(time.Date(2026, 1, 2, 4, 0, 0, 0, time.UTC)),
+		Creator:      "alice@example.com",
+		Labels: map[string]string{
+			"team":                     "data-eng",
+			"goog-dataproc-batch-uuid": "aaaaaaaa-bbbb-cccc-dddd-eeeeeeeeeeee",
+			"goog-dataproc-location":   "us-central1",
+		},
+		RuntimeConfig:     &dataprocpb.RuntimeConfig{Version: "2.2"},
+		EnvironmentConfig: &dataprocpb.EnvironmentConfig{ExecutionConfig: &dataprocpb.ExecutionConfig{ServiceAccount: "sa@my-project.iam.gserviceaccount.com"}},
+		Operation:         "projects/my-project/regions/us-central1/operations/my-op",
+		StateHistory:      []*dataprocpb.Batch_StateHistory{{State: dataprocpb.Batch_PENDING}},
+	}
+}
+
+func TestResubmittedBatch(t *testing.T) {
+	want := &dataprocpb.Batch{
+		BatchConfig: &dataprocpb.Batch_PysparkBatch{PysparkBatch: &dataprocpb.PySparkBatch{
+			MainPythonFileUri: "gs://bucket/etl.py",
+			Args:              []string{"--date=2026-01-01"},
+		}},
+		Labels:            map[string]string{"team": "data-eng"},
+		RuntimeConfig:     &dataprocpb.RuntimeConfig{Version: "2.2"},
+		EnvironmentConfig: &dataprocpb.EnvironmentConfig{ExecutionConfig: &dataprocpb.ExecutionConfig{ServiceAccount: "sa@my-project.iam.gserviceaccount.com"}},
+	}
+	orig := failedBatch()
+	got, err := serverlessspark.ResubmittedBatch(orig, nil)
+	if err != nil {
+		t.Fatalf("ResubmittedBatch() error = %v", err)
+	}
+	if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+		t.Errorf("ResubmittedBatch() mismatch (-want +got):\n%s", diff)
+	}
+
+	got, err = serverlessspark.ResubmittedBatch(orig, []string{"--date=2026-01-02"})
+	if err != nil {
+		t.Fatalf("ResubmittedBatch() error = %v", err)
+	}
+	if diff := cmp.Diff([]string{"--date=2026-01-02"}, got.GetPysparkBatch().GetArgs()); diff != "" {
+		t.Errorf("args mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"--date=2026-01-01"}, orig.GetPysparkBatch().GetArgs()); diff != "" {
+		t.Errorf("original batch modified (-want +got):\n%s", diff)
+	}
+
+	sqlBatch := &dataprocpb.Batch{BatchConfig: &dataprocpb.Batch_SparkSqlBatch{SparkSqlBatch: &dataprocpb.SparkSqlBatch{QueryFileUri: "gs://bucket/q.sql"}}}
+	if _, err := serverlessspark.ResubmittedBatch(sqlBatch, []string{"x"}); err == nil || !strings.Contains(err.Error(), "Spark SQL") {
+		t.Errorf("ResubmittedBatch() error = %v, want Spark SQL args error", err)
+	}
+}
+
+func TestResubmitBatch(t *testing.T) {
+	lis, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	fake := &fakeBatchController{batch: failedBatch()}
+	srv := grpc.NewServer()
+	dataprocpb.RegisterBatchControllerServer(srv, fake)
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	ctx := context.Background()
+	client, err := dataproc.NewBatchControllerClient(ctx,
+		option.WithEndpoint(lis.Addr().String()),
+		option.WithoutAuthentication(),
+		option.WithGRPCDialOption(grpc.WithTransportCredentials(insecure.NewCredentials())),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	t.Cleanup(func() { client.Close() })
+	source := &serverlessspark.Source{
+		Config:      serverlessspark.Config{Project: "my-project", Location: "us-central1"},
+		BatchClient: client,
+	}
+
+	got, err := source.ResubmitBatch(ctx, "projects/my-project/locations/us-central1/batches/nightly-etl", []string{"--date=2026-01-02"})
+	if err != nil {
+		t.Fatalf("ResubmitBatch() error = %v", err)
+	}
+	batchID, _ := got["batchId"].(string)
+	if !regexp.MustCompile(`^nightly-etl-[0-9a-f]{8}$`).MatchString(batchID) {
+		t.Errorf("got batchId %q, want nightly-etl- with a random suffix", batchID)
+	}
+	if want := "projects/my-project/locations/us-central1/operations/my-op"; got["operation"] != want {
+		t.Errorf("got operation %v, want %q", got["operation"], want)
+	}
+	req := fake.createReq
+	if req.GetBatchId() != batchID {
+		t.Errorf("got request batch ID %q, want %q", req.GetBatchId(), batchID)
+	}
+	if req.GetRequestId() == "" {
+		t.Errorf("got empty request ID")
+	}
+	if req.GetBatch().GetState() != dataprocpb.Batch_STATE_UNSPECIFIED || req.GetBatch().GetName() != "" {
+		t.Errorf("got batch with server-set fields: %v", req.GetBatch())
+	}
+	if diff := cmp.Diff([]string{"--date=2026-01-02"}, req.GetBatch().GetPysparkBatch().GetArgs()); diff != "" {
+		t.Errorf("args mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,215 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serverlessspark
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/dataproc/v2/apiv1/dataprocpb"
+	"cloud.google.com/go/logging"
+	"cloud.google.com/go/logging/logadmin"
+	"github.com/googleapis/mcp-toolbox/internal/util/logiter"
+	"google.golang.org/api/iterator"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// maxRootCauseEntries bounds the number of ERROR entries BatchRootCause
+// scans, which is also the page size they are listed with.
+const maxRootCauseEntries = 1000
+
+// maxRootCauseLines bounds the number of lines returned with a root cause.
+const maxRootCauseLines = 20
+
+var (
+	// exceptionLineRegex matches the first line of a JVM or Python exception,
+	// e.g. "Caused by: java.io.IOException: disk full", capturing the
+	// exception class and its message, if any.
+	exceptionLineRegex = regexp.MustCompile(`^\s*(?:Caused by: |Exception in thread "[^"]*" )?((?:[A-Za-z_$][\w$]*\.)*[A-Za-z_$][\w$]*(?:Exception|Error|Throwable))(?::\s*(.*))?$`)
+	// stackTraceLineRegex matches the lines that continue a JVM stack trace:
+	// frames, "... 12 more", and nested causes and suppressed exceptions.
+	stackTraceLineRegex = regexp.MustCompile(`^\s+at \S|^\s*\.\.\. \d+ more\s*$|^\s*Caused by: |^\s*Suppressed: `)
+)
+
+// BatchRootCause finds the likely root cause of the failure of the batch with
+// the given full name in its log entries with severity ERROR or higher, which
+// are listed in logsProject, if set, as with logsProjectID.
+//
+// The entries are scanned oldest first for the first exception. Its last
+// "Caused by:" exception, or the exception itself if it has no cause, is
+// returned as "rootCause", with the lines of its stack trace. If no exception
+// is found, the last ERROR entry is returned instead. "heuristic" says which
+// was used. At most maxRootCauseEntries entries are scanned; if there are
+// more, or ctx's deadline passes while they are listed, "truncated" is set.
+//
+// If the batch has not failed, the result contains a message explaining why
+// instead.
+func (s *Source) BatchRootCause(ctx context.Context, name, logsProject string) (map[string]any, error) {
+	projectID, location, batchID, err := ExtractBatchDetails(name)
+	if err != nil {
+		return nil, err
+	}
+	batchPb, err := s.getBatchCached(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get batch: %w", err)
+	}
+	result := map[string]any{
+		"batch": name,
+		"state": batchPb.GetState().String(),
+	}
+	if msg := batchPb.GetStateMessage(); msg != "" {
+		result["stateMessage"] = msg
+	}
+	if batchPb.GetState() != dataprocpb.Batch_FAILED {
+		result["message"] = fmt.Sprintf("The batch is in state %s, not FAILED, so it has no root cause to find.", batchPb.GetState())
+		return result, nil
+	}
+
+	r := ResolveLogTimeRange(batchPb, LogTimeRange{})
+	spec := logFilterSpec(BatchLogResourceType, map[string]string{
+		"project_id": projectID,
+		"location":   location,
+		"batch_id":   batchID,
+	}, r)
+	spec.Severity = "ERROR"
+
+	it := s.GetLoggingClient().Entries(ctx,
+		logadmin.ProjectIDs([]string{logsProjectID(projectID, logsProject)}),
+		logadmin.Filter(spec.Build()),
+		logadmin.PageSize(maxRootCauseEntries),
+	)
+	var entries []*logging.Entry
+	truncated := false
+	for {
+		entry, err := logiter.Next(ctx, it)
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			if len(entries) > 0 && logiter.DeadlineExceeded(ctx) {
+				truncated = true
+				result["deadlineExceeded"] = true
+				break
+			}
+			return nil, fmt.Errorf("failed to list batch error logs: %w", err)
+		}
+		if len(entries) == maxRootCauseEntries {
+			truncated = true
+			break
+		}
+		entries = append(entries, entry)
+	}
+	result["entriesScanned"] = len(entries)
+	result["truncated"] = truncated
+
+	rootCause, heuristic := findRootCause(entries)
+	if rootCause == nil {
+		result["message"] = "No log entries with severity ERROR or higher were found for the batch; see stateMessage."
+		return result, nil
+	}
+	result["rootCause"] = rootCause
+	result["heuristic"] = heuristic
+	return result, nil
+}
+
+// logLine is a line of the text of a log entry.
+type logLine struct {
+	text  string
+	entry *logging.Entry
+}
+
+// findRootCause returns the root cause of the first exception in the lines
+// of entries, which are oldest first, and the heuristic "exception", or, if
+// there is no exception, the last entry and the heuristic "lastError". Stack
+// traces may be logged as one entry or as one entry per line, so the lines of
+// all of the entries are searched together.
+func findRootCause(entries []*logging.Entry) (map[string]any, string) {
+	if len(entries) == 0 {
+		return nil, ""
+	}
+	var lines []logLine
+	for _, entry := range entries {
+		for _, text := range strings.Split(entryText(entry), "\n") {
+			lines = append(lines, logLine{text: strings.TrimRight(text, "\r"), entry: entry})
+		}
+	}
+
+	for i, line := range lines {
+		if !exceptionLineRegex.MatchString(line.text) {
+			continue
+		}
+		// The trace continues until the first line that is not part of it;
+		// its last exception line is the deepest cause.
+		cause := i
+		end := i + 1
+		for end < len(lines) && stackTraceLineRegex.MatchString(lines[end].text) {
+			if exceptionLineRegex.MatchString(lines[end].text) {
+				cause = end
+			}
+			end++
+		}
+		var causeLines []string
+		for _, l := range lines[cause:min(end, cause+maxRootCauseLines)] {
+			causeLines = append(causeLines, l.text)
+		}
+		m := exceptionLineRegex.FindStringSubmatch(lines[cause].text)
+		rootCause := rootCauseEntry(lines[cause].entry, strings.TrimPrefix(strings.TrimSpace(lines[cause].text), "Caused by: "), causeLines)
+		rootCause["exception"] = m[1]
+		if m[2] != "" {
+			rootCause["message"] = m[2]
+		}
+		return rootCause, "exception"
+	}
+
+	last := entries[len(entries)-1]
+	var lastLines []string
+	for _, text := range strings.Split(strings.TrimSpace(entryText(last)), "\n") {
+		if len(lastLines) == maxRootCauseLines {
+			break
+		}
+		lastLines = append(lastLines, strings.TrimRight(text, "\r"))
+	}
+	return rootCauseEntry(last, lastLines[0], lastLines), "lastError"
+}
+
+// rootCauseEntry describes a root cause found in entry.
+func rootCauseEntry(entry *logging.Entry, summary string, lines []string) map[string]any {
+	return map[string]any{
+		"summary":   summary,
+		"lines":     lines,
+		"insertId":  entry.InsertID,
+		"logName":   entry.LogName,
+		"timestamp": entry.Timestamp.UTC().Format(time.RFC3339Nano),
+		"severity":  entry.Severity.String(),
+	}
+}
+
+// entryText returns the text of the entry's payload: a text payload, or the
+// "message" field of a JSON payload, which is where Dataproc writes the output
+// of the driver and executors.
+func entryText(entry *logging.Entry) string {
+	switch p := entry.Payload.(type) {
+	case string:
+		return p
+	case *structpb.Struct:
+		if msg, ok := p.GetFields()["message"]; ok {
+			return msg.GetStringValue()
+		}
+	}
+	return ""
+}
@@ -0,0 +1,244 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serverlessspark_test
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	dataproc "cloud.google.com/go/dataproc/v2/apiv1"
+	"cloud.google.com/go/dataproc/v2/apiv1/dataprocpb"
+	"cloud.google.com/go/logging/apiv2/loggingpb"
+	"cloud.google.com/go/logging/logadmin"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/mcp-toolbox/internal/sources/serverlessspark"
+	"google.golang.org/api/option"
+	ltype "google.golang.org/genproto/googleapis/logging/type"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// errorEntries returns ERROR log entries with the given text payloads, one
+// minute apart, with insertIds e0, e1, and so on.
+func errorEntries(payloads ...string) []*loggingpb.LogEntry {
+	var entries []*loggingpb.LogEntry
+	for i, p := range payloads {
+		entries = append(entries, &loggingpb.LogEntry{
+			InsertId:  fmt.Sprintf("e%d", i),
+			LogName:   "projects/my-project/logs/dataproc.googleapis.com%2Foutput",
+			Severity:  ltype.LogSeverity_ERROR,
+			Timestamp: timestamppb.New(time.Date(2026, 1, 2, 3, 30+i, 0, 0, time.UTC)),
+			Payload:   &loggingpb.LogEntry_TextPayload{TextPayload: p},
+		})
+	}
+	return entries
+}
+
+func TestBatchRootCause(t *testing.T) {
+	jsonEntry := &loggingpb.LogEntry{
+		InsertId:  "j0",
+		LogName:   "projects/my-project/logs/dataproc.googleapis.com%2Foutput",
+		Severity:  ltype.LogSeverity_CRITICAL,
+		Timestamp: timestamppb.New(time.Date(2026, 1, 2, 3, 30, 0, 0, time.UTC)),
+		Payload: &loggingpb.LogEntry_JsonPayload{JsonPayload: &structpb.Struct{Fields: map[string]*structpb.Value{
+			"message": structpb.NewStringValue("pyspark.errors.exceptions.captured.AnalysisException: [TABLE_OR_VIEW_NOT_FOUND] The table `sales` cannot be found."),
+		}}},
+	}
+	tcs := []struct {
+		desc          string
+		state         dataprocpb.Batch_State
+		entries       []*loggingpb.LogEntry
+		wantRootCause map[string]any
+		wantHeuristic string
+		wantMessage   bool
+	}{
+		{
+			desc:  "stack trace over entries",
+			state: dataprocpb.Batch_FAILED,
+			entries: errorEntries(
+				"26/01/02 03:30:00 ERROR SparkContext: Error initializing SparkContext.",
+				"org.apache.spark.SparkException: Job aborted.",
+				"\tat org.apache.spark.scheduler.DAGScheduler.failJobAndIndependentStages(DAGScheduler.scala:2672)",
+				"Caused by: java.lang.RuntimeException: task failed",
+				"\tat com.example.Job.run(Job.scala:10)",
+				"Caused by: java.io.IOException: disk full",
+				"\tat java.io.FileOutputStream.writeBytes(Native Method)",
+				"\t... 12 more",
+				"26/01/02 03:30:09 ERROR Utils: Uncaught exception in thread shutdown-hook-0",
+				"java.lang.IllegalStateException: already stopped",
+			),
+			wantRootCause: map[string]any{
+				"summary":   "java.io.IOException: disk full",
+				"exception": "java.io.IOException",
+				"message":   "disk full",
+				"lines":     []string{"Caused by: java.io.IOException: disk full", "\tat java.io.FileOutputStream.writeBytes(Native Method)", "\t... 12 more"},
+				"insertId":  "e5",
+				"logName":   "projects/my-project/logs/dataproc.googleapis.com/output",
+				"timestamp": "2026-01-02T03:35:00Z",
+				"severity":  "Error",
+			},
+			wantHeuristic: "exception",
+		},
+		{
+			desc:  "stack trace in one entry",
+			state: dataprocpb.Batch_FAILED,
+			entries: errorEntries(
+				"Exception in thread \"main\" org.apache.spark.SparkException: Job aborted.\n\tat org.apache.spark.deploy.SparkSubmit.main(SparkSubmit.scala:1)\nCaused by: java.lang.OutOfMemoryError: Java heap space\n\tat java.util.Arrays.copyOf(Arrays.java:3332)",
+			),
+			wantRootCause: map[string]any{
+				"summary":   "java.lang.OutOfMemoryError: Java heap space",
+				"exception": "java.lang.OutOfMemoryError",
+				"message":   "Java heap space",
+				"lines":     []string{"Caused by: java.lang.OutOfMemoryError: Java heap space", "\tat java.util.Arrays.copyOf(Arrays.java:3332)"},
+				"insertId":  "e0",
+				"logName":   "projects/my-project/logs/dataproc.googleapis.com/output",
+				"timestamp": "2026-01-02T03:30:00Z",
+				"severity":  "Error",
+			},
+			wantHeuristic: "exception",
+		},
+		{
+			desc:    "exception in JSON payload",
+			state:   dataprocpb.Batch_FAILED,
+			entries: []*loggingpb.LogEntry{jsonEntry},
+			wantRootCause: map[string]any{
+				"summary":   "pyspark.errors.exceptions.captured.AnalysisException: [TABLE_OR_VIEW_NOT_FOUND] The table `sales` cannot be found.",
+				"exception": "pyspark.errors.exceptions.captured.AnalysisException",
+				"message":   "[TABLE_OR_VIEW_NOT_FOUND] The table `sales` cannot be found.",
+				"lines":     []string{"pyspark.errors.exceptions.captured.AnalysisException: [TABLE_OR_VIEW_NOT_FOUND] The table `sales` cannot be found."},
+				"insertId":  "j0",
+				"logName":   "projects/my-project/logs/dataproc.googleapis.com/output",
+				"timestamp": "2026-01-02T03:30:00Z",
+				"severity":  "Critical",
+			},
+			wantHeuristic: "exception",
+		},
+		{
+			desc:    "no exception",
+			state:   dataprocpb.Batch_FAILED,
+			entries: errorEntries("executor 1 lost", "Driver exited with code 1\nsee the driver output"),
+			wantRootCause: map[string]any{
+				"summary":   "Driver exited with code 1",
+				"lines":     []string{"Driver exited with code 1", "see the driver output"},
+				"insertId":  "e1",
+				"logName":   "projects/my-project/logs/dataproc.googleapis.com/output",
+				"timestamp": "2026-01-02T03:31:00Z",
+				"severity":  "Error",
+			},
+			wantHeuristic: "lastError",
+		},
+		{
+			desc:        "no entries",
+			state:       dataprocpb.Batch_FAILED,
+			entries:     []*loggingpb.LogEntry{},
+			wantMessage: true,
+		},
+		{
+			desc:        "not failed",
+			state:       dataprocpb.Batch_SUCCEEDED,
+			wantMessage: true,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			lis, err := net.Listen("tcp", "localhost:0")
+			if err != nil {
+				t.Fatalf("failed to listen: %v", err)
+			}
+			logging := &fakeLogging{entries: tc.entries}
+			batch := &dataprocpb.Batch{
+				Name:         "projects/my-project/locations/us-central1/batches/my-batch",
+				State:        tc.state,
+				StateMessage: "Job failed with message [disk full].",
+				CreateTime:   timestamppb.New(time.Date(2026, 1, 2, 3, 0, 0, 0, time.UTC)),
+				StateTime:    timestamppb.New(time.Date(2026, 1, 2, 4, 0, 0, 0, time.UTC)),
+			}
+			srv := grpc.NewServer()
+			dataprocpb.RegisterBatchControllerServer(srv, &fakeBatchController{batch: batch})
+			loggingpb.RegisterLoggingServiceV2Server(srv, logging)
+			go func() { _ = srv.Serve(lis) }()
+			t.Cleanup(srv.Stop)
+
+			ctx := context.Background()
+			opts := []option.ClientOption{
+				option.WithEndpoint(lis.Addr().String()),
+				option.WithoutAuthentication(),
+				option.WithGRPCDialOption(grpc.WithTransportCredentials(insecure.NewCredentials())),
+			}
+			batchClient, err := dataproc.NewBatchControllerClient(ctx, opts...)
+			if err != nil {
+				t.Fatalf("failed to create batch client: %v", err)
+			}
+			t.Cleanup(func() { batchClient.Close() })
+			loggingClient, err := logadmin.NewClient(ctx, "my-project", opts...)
+			if err != nil {
+				t.Fatalf("failed to create logging client: %v", err)
+			}
+			t.Cleanup(func() { loggingClient.Close() })
+			source := &serverlessspark.Source{
+				Config:        serverlessspark.Config{Project: "my-project", Location: "us-central1"},
+				BatchClient:   batchClient,
+				LoggingClient: loggingClient,
+			}
+
+			got, err := source.BatchRootCause(ctx, batch.Name, "")
+			if err != nil {
+				t.Fatalf("BatchRootCause() error = %v", err)
+			}
+			if got["stateMessage"] != batch.StateMessage {
+				t.Errorf("got stateMessage %v, want %q", got["stateMessage"], batch.StateMessage)
+			}
+			if _, ok := got["message"]; ok != tc.wantMessage {
+				t.Errorf("got message in result %t, want %t: %v", ok, tc.wantMessage, got)
+			}
+			if tc.state != dataprocpb.Batch_FAILED {
+				if logging.gotReq != nil {
+					t.Errorf("logs were listed for a batch in state %v", tc.state)
+				}
+				return
+			}
+			wantFilter := `resource.type="cloud_dataproc_batch"
+resource.labels.batch_id="my-batch"
+resource.labels.location="us-central1"
+resource.labels.project_id="my-project"
+severity>=ERROR
+timestamp>="2026-01-02T02:59:00Z"
+timestamp<="2026-01-02T04:00:00Z"`
+			if got := logging.gotReq.GetFilter(); got != wantFilter {
+				t.Errorf("got filter\n%s\nwant\n%s", got, wantFilter)
+			}
+			if got["entriesScanned"] != len(tc.entries) || got["truncated"] != false {
+				t.Errorf("got entriesScanned %v, truncated %v, want %d, false", got["entriesScanned"], got["truncated"], len(tc.entries))
+			}
+			if tc.wantRootCause == nil {
+				if _, ok := got["rootCause"]; ok {
+					t.Errorf("got rootCause %v, want none", got["rootCause"])
+				}
+				return
+			}
+			if diff := cmp.Diff(tc.wantRootCause, got["rootCause"]); diff != "" {
+				t.Errorf("rootCause mismatch (-want +got):\n%s", diff)
+			}
+			if got["heuristic"] != tc.wantHeuristic {
+				t.Errorf("got heuristic %v, want %q", got["heuristic"], tc.wantHeuristic)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serverlessspark
+
+// DefaultRuntimeVersion is the runtime version Dataproc uses for batches and
+// sessions that do not set one.
+const DefaultRuntimeVersion = "2.2"
+
+// RuntimeVersion describes a Serverless for Apache Spark runtime version and
+// the main components it includes.
+type RuntimeVersion struct {
+	Version      string `json:"version"`
+	SparkVersion string `json:"sparkVersion"`
+	JavaVersion  string `json:"javaVersion"`
+	ScalaVersion string `json:"scalaVersion"`
+	Default      bool   `json:"default,omitempty"`
+}
+
+// runtimeVersions lists the supported runtime versions, newest first.
+// Dataproc has no API to list them, so this list must be updated as versions
+// are released and reach end of support. See
+// https://cloud.google.com/dataproc-serverless/docs/concepts/versions/spark-runtime-versions.
+var runtimeVersions = []RuntimeVersion{
+	{Version: "3.0", SparkVersion: "4.0", JavaVersion: "17", ScalaVersion: "2.13"},
+	{Version: "2.3", SparkVersion: "3.5", JavaVersion: "17", ScalaVersion: "2.13"},
+	{Version: "2.2", SparkVersion: "3.5", JavaVersion: "17", ScalaVersion: "2.13"},
+	{Version: "1.2", SparkVersion: "3.5", JavaVersion: "17", ScalaVersion: "2.12"},
+}
+
+// ListRuntimeVersionsResponse is the response of ListRuntimeVersions.
+type ListRuntimeVersionsResponse struct {
+	DefaultVersion  string           `json:"defaultVersion"`
+	RuntimeVersions []RuntimeVersion `json:"runtimeVersions"`
+}
+
+// ListRuntimeVersions returns the supported runtime versions, marking the
+// default one.
+func (s *Source) ListRuntimeVersions() ListRuntimeVersionsResponse {
+	versions := make([]RuntimeVersion, len(runtimeVersions))
+	for i, v := range runtimeVersions {
+		v.Default = v.Version == DefaultRuntimeVersion
+		versions[i] = v
+	}
+	return ListRuntimeVersionsResponse{DefaultVersion: DefaultRuntimeVersion, RuntimeVersions: versions}
+}
@@ -0,0 +1,33 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serverlessspark
+
+import "testing"
+
+func TestListRuntimeVersions(t *testing.T) {
+	got := (&Source{}).ListRuntimeVersions()
+	if got.DefaultVersion != DefaultRuntimeVersion {
+		t.Errorf("DefaultVersion = %q, want %q", got.DefaultVersion, DefaultRuntimeVersion)
+	}
+	var defaults []string
+	for _, v := range got.RuntimeVersions {
+		if v.Default {
+			defaults = append(defaults, v.Version)
+		}
+	}
+	if len(defaults) != 1 || defaults[0] != DefaultRuntimeVersion {
+		t.Errorf("versions marked default = %v, want [%s]", defaults, DefaultRuntimeVersion)
+	}
+}
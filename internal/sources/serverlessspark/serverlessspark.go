@@ -19,12 +19,17 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"slices"
+	"sync"
 	"time"
 
 	dataproc "cloud.google.com/go/dataproc/v2/apiv1"
 	"cloud.google.com/go/dataproc/v2/apiv1/dataprocpb"
+	"cloud.google.com/go/logging/logadmin"
 	longrunning "cloud.google.com/go/longrunning/autogen"
-	"cloud.google.com/go/longrunning/autogen/longrunningpb"
+	monitoring "cloud.google.com/go/monitoring/apiv3/v2"
+	"cloud.google.com/go/storage"
 	"github.com/goccy/go-yaml"
 	"github.com/googleapis/mcp-toolbox/internal/sources"
 	"github.com/googleapis/mcp-toolbox/internal/util"
@@ -58,8 +63,35 @@ type Config struct {
 	Type     string `yaml:"type" validate:"required"`
 	Project  string `yaml:"project" validate:"required"`
 	Location string `yaml:"location" validate:"required"`
+	// CredentialsFile is the path to a credentials JSON file to use instead of
+	// Application Default Credentials.
+	CredentialsFile string `yaml:"credentialsFile"`
+	// AllowedLocations restricts the locations that tools may target. If
+	// empty, all locations are allowed.
+	AllowedLocations []string `yaml:"allowedLocations"`
+	// CustomHeaders are added to every request, e.g. for proxies that require
+	// them. They may not set the Authorization or User-Agent headers.
+	CustomHeaders map[string]string `yaml:"customHeaders"`
+	// DefaultLabels are added to the batches and sessions created by tools.
+	// Labels passed to a tool take precedence.
+	DefaultLabels map[string]string `yaml:"defaultLabels"`
+	// MaxConcurrency bounds the concurrent requests of tools that fan out,
+	// e.g. across locations. Defaults to 4.
+	MaxConcurrency int `yaml:"maxConcurrency"`
+	// UniverseDomain is the universe domain of the APIs, for Google Cloud
+	// universes other than the public one. Defaults to googleapis.com.
+	UniverseDomain string `yaml:"universeDomain"`
+	// ResourceCacheTTL is how long the batches and sessions that tools look
+	// up to resolve the time range of their logs are cached, as a duration
+	// like "30s". Resources in a final state are cached longer. "0s"
+	// disables the cache. Defaults to 30s.
+	ResourceCacheTTL string `yaml:"resourceCacheTTL"`
 }
 
+// MaxLabels is the maximum number of labels Dataproc accepts on a batch or
+// session.
+const MaxLabels = 32
+
 func (r Config) SourceConfigType() string {
 	return SourceType
 }
@@ -69,23 +101,93 @@ func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.So
 	if err != nil {
 		return nil, fmt.Errorf("error in User Agent retrieval: %s", err)
 	}
-	endpoint := fmt.Sprintf("%s-dataproc.googleapis.com:443", r.Location)
-	batchClient, err := dataproc.NewBatchControllerClient(ctx, option.WithEndpoint(endpoint), option.WithUserAgent(ua))
+	if len(r.AllowedLocations) > 0 && !slices.Contains(r.AllowedLocations, r.Location) {
+		return nil, fmt.Errorf("location %q must be one of allowedLocations %v", r.Location, r.AllowedLocations)
+	}
+	if err := validateCustomHeaders(r.CustomHeaders); err != nil {
+		return nil, err
+	}
+	if err := util.ValidateLabels(r.DefaultLabels, MaxLabels); err != nil {
+		return nil, fmt.Errorf("invalid defaultLabels: %w", err)
+	}
+	if r.MaxConcurrency < 0 {
+		return nil, fmt.Errorf("maxConcurrency must not be negative: %d", r.MaxConcurrency)
+	}
+	if err := validateUniverseDomain(r.UniverseDomain); err != nil {
+		return nil, err
+	}
+	resourceCacheTTL := defaultResourceCacheTTL
+	if r.ResourceCacheTTL != "" {
+		resourceCacheTTL, err = time.ParseDuration(r.ResourceCacheTTL)
+		if err != nil || resourceCacheTTL < 0 {
+			return nil, fmt.Errorf("resourceCacheTTL must be a non-negative duration, e.g. \"30s\": %q", r.ResourceCacheTTL)
+		}
+	}
+	endpoint := regionalEndpoint(r.Location, r.UniverseDomain)
+	// Options shared by the Dataproc, Cloud Storage, and Cloud Logging clients.
+	commonOpts := []option.ClientOption{option.WithUserAgent(ua)}
+	if r.UniverseDomain != "" {
+		// The Cloud Storage and Cloud Logging clients derive their endpoints
+		// from the universe domain, and credentials are checked against it.
+		commonOpts = append(commonOpts, option.WithUniverseDomain(r.UniverseDomain))
+	}
+	if r.CredentialsFile != "" {
+		creds, err := sources.CredentialsFromFile(ctx, r.CredentialsFile)
+		if err != nil {
+			return nil, err
+		}
+		commonOpts = append(commonOpts, option.WithCredentials(creds))
+	}
+	grpcOpts := append(customHeaderOptions(r.CustomHeaders), commonOpts...)
+	opts := append([]option.ClientOption{option.WithEndpoint(endpoint)}, grpcOpts...)
+
+	// If a client fails to be created, close those created before it.
+	var clients []io.Closer
+	defer func() {
+		for _, c := range clients {
+			c.Close()
+		}
+	}()
+	batchClient, err := dataproc.NewBatchControllerClient(ctx, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create dataproc batch client: %w", err)
 	}
-	sessionTemplateClient, err := dataproc.NewSessionTemplateControllerClient(ctx, option.WithEndpoint(endpoint), option.WithUserAgent(ua))
+	clients = append(clients, batchClient)
+	sessionTemplateClient, err := dataproc.NewSessionTemplateControllerClient(ctx, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create dataproc session template client: %w", err)
 	}
-	opsClient, err := longrunning.NewOperationsClient(ctx, option.WithEndpoint(endpoint), option.WithUserAgent(ua))
+	clients = append(clients, sessionTemplateClient)
+	opsClient, err := longrunning.NewOperationsClient(ctx, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create longrunning client: %w", err)
 	}
-	sessionClient, err := dataproc.NewSessionControllerClient(ctx, option.WithEndpoint(endpoint), option.WithUserAgent(ua))
+	clients = append(clients, opsClient)
+	sessionClient, err := dataproc.NewSessionControllerClient(ctx, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create dataproc session client: %w", err)
 	}
+	clients = append(clients, sessionClient)
+
+	// The storage client reads batch diagnostics from Cloud Storage.
+	storageClient, err := newStorageClient(ctx, r.CustomHeaders, r.UniverseDomain, commonOpts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create storage client: %w", err)
+	}
+	clients = append(clients, storageClient)
+
+	// The logging client reads the logs of failed sessions.
+	loggingClient, err := logadmin.NewClient(ctx, r.Project, grpcOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create logging client: %w", err)
+	}
+	clients = append(clients, loggingClient)
+
+	// The monitoring client reads the metrics of batches.
+	monitoringClient, err := monitoring.NewMetricClient(ctx, grpcOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create monitoring client: %w", err)
+	}
 
 	s := &Source{
 		Config:                r,
@@ -93,7 +195,15 @@ func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.So
 		SessionTemplateClient: sessionTemplateClient,
 		OpsClient:             opsClient,
 		SessionClient:         sessionClient,
-	}
+		StorageClient:         storageClient,
+		LoggingClient:         loggingClient,
+		MonitoringClient:      monitoringClient,
+		clientOpts:            grpcOpts,
+		userAgent:             ua,
+		resourceCache:         newResourceCache(resourceCacheTTL),
+	}
+	// The source closes the clients from now on.
+	clients = nil
 	return s, nil
 }
 
@@ -105,6 +215,23 @@ type Source struct {
 	SessionTemplateClient *dataproc.SessionTemplateControllerClient
 	OpsClient             *longrunning.OperationsClient
 	SessionClient         *dataproc.SessionControllerClient
+	StorageClient         *storage.Client
+	LoggingClient         *logadmin.Client
+	MonitoringClient      *monitoring.MetricClient
+
+	// clientOpts are the options used to create the clients, without the
+	// regional endpoint, for creating clients for other locations.
+	clientOpts []option.ClientOption
+
+	// userAgent is the user agent of the clients' requests.
+	userAgent string
+
+	// resourceCache caches the batches and sessions looked up by the logs
+	// tools. It is nil if the cache is disabled.
+	resourceCache *resourceCache
+
+	closeOnce sync.Once
+	closeErr  error
 }
 
 func (s *Source) SourceType() string {
@@ -123,6 +250,22 @@ func (s *Source) GetLocation() string {
 	return s.Location
 }
 
+// GetUniverseDomain returns the configured universe domain, or the empty
+// string for googleapis.com.
+func (s *Source) GetUniverseDomain() string {
+	return s.UniverseDomain
+}
+
+// GetDefaultLabels returns the labels to add to created batches and sessions.
+func (s *Source) GetDefaultLabels() map[string]string {
+	return s.DefaultLabels
+}
+
+// IsLocationAllowed reports whether tools may target the given location.
+func (s *Source) IsLocationAllowed(location string) bool {
+	return len(s.AllowedLocations) == 0 || slices.Contains(s.AllowedLocations, location)
+}
+
 func (s *Source) GetBatchControllerClient() *dataproc.BatchControllerClient {
 	return s.BatchClient
 }
@@ -139,14 +282,53 @@ func (s *Source) GetOperationsClient(ctx context.Context) (*longrunning.Operatio
 	return s.OpsClient, nil
 }
 
+func (s *Source) GetStorageClient() *storage.Client {
+	return s.StorageClient
+}
+
+func (s *Source) GetLoggingClient() *logadmin.Client {
+	return s.LoggingClient
+}
+
+func (s *Source) GetMonitoringClient() *monitoring.MetricClient {
+	return s.MonitoringClient
+}
+
+// Close closes the underlying clients. It is safe to call Close more than
+// once; subsequent calls return the result of the first.
 func (s *Source) Close() error {
-	return errors.Join(s.BatchClient.Close(), s.SessionClient.Close(), s.SessionTemplateClient.Close(), s.OpsClient.Close())
+	s.closeOnce.Do(func() {
+		errs := []error{s.BatchClient.Close(), s.SessionClient.Close(), s.SessionTemplateClient.Close(), s.OpsClient.Close()}
+		if s.StorageClient != nil {
+			errs = append(errs, s.StorageClient.Close())
+		}
+		if s.LoggingClient != nil {
+			errs = append(errs, s.LoggingClient.Close())
+		}
+		if s.MonitoringClient != nil {
+			errs = append(errs, s.MonitoringClient.Close())
+		}
+		s.closeErr = errors.Join(errs...)
+	})
+	return s.closeErr
 }
 
-func (s *Source) CancelOperation(ctx context.Context, operation string) (any, error) {
-	req := &longrunningpb.CancelOperationRequest{
-		Name: fmt.Sprintf("projects/%s/locations/%s/operations/%s", s.GetProject(), s.GetLocation(), operation),
+// HealthCheck verifies that the Dataproc API is reachable with the configured
+// endpoint and credentials by making a lightweight list call.
+func (s *Source) HealthCheck(ctx context.Context) error {
+	req := &dataprocpb.ListBatchesRequest{
+		Parent:   fmt.Sprintf("projects/%s/locations/%s", s.GetProject(), s.GetLocation()),
+		PageSize: 1,
+	}
+	it := s.GetBatchControllerClient().ListBatches(ctx, req)
+	if _, err := it.Next(); err != nil && err != iterator.Done {
+		return fmt.Errorf("serverless spark health check failed: %w", ToAPIError(err))
 	}
+	return nil
+}
+
+func (s *Source) CancelOperation(ctx context.Context, operation string) (any, error) {
+	req := cancelOperationRequest(s.GetProject(), s.GetLocation(), operation)
 	client, err := s.GetOperationsClient(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get operations client: %w", err)
@@ -158,12 +340,15 @@ func (s *Source) CancelOperation(ctx context.Context, operation string) (any, er
 	return fmt.Sprintf("Cancelled [%s].", operation), nil
 }
 
-func (s *Source) CreateBatch(ctx context.Context, batch *dataprocpb.Batch) (map[string]any, error) {
-	req := &dataprocpb.CreateBatchRequest{
-		Parent: fmt.Sprintf("projects/%s/locations/%s", s.GetProject(), s.GetLocation()),
-		Batch:  batch,
-	}
+// CreateBatch creates batch with the given ID, or with an ID generated by
+// Dataproc if batchID is empty.
+func (s *Source) CreateBatch(ctx context.Context, batchID string, batch *dataprocpb.Batch, requestID string) (map[string]any, error) {
+	return s.createBatch(ctx, createBatchRequest(s.GetProject(), s.GetLocation(), batchID, batch, requestID))
+}
 
+// createBatch sends req and returns the operation, its metadata, and links to
+// the new batch.
+func (s *Source) createBatch(ctx context.Context, req *dataprocpb.CreateBatchRequest) (map[string]any, error) {
 	client := s.GetBatchControllerClient()
 	op, err := client.CreateBatch(ctx, req)
 	if err != nil {
@@ -181,7 +366,10 @@ func (s *Source) CreateBatch(ctx context.Context, batch *dataprocpb.Batch) (map[
 	consoleUrl := BatchConsoleURL(projectID, location, batchID)
 	logsUrl := BatchLogsURL(projectID, location, batchID, meta.GetCreateTime().AsTime(), time.Time{})
 
+	// The operation name, e.g. for cancelling the batch, is returned alongside
+	// its metadata, which includes the full batch resource name.
 	wrappedResult := map[string]any{
+		"operation":  op.Name(),
 		"opMetadata": meta,
 		"consoleUrl": consoleUrl,
 		"logsUrl":    logsUrl,
@@ -192,7 +380,42 @@ func (s *Source) CreateBatch(ctx context.Context, batch *dataprocpb.Batch) (map[
 // ListBatchesResponse is the response from the list batches API.
 type ListBatchesResponse struct {
 	Batches       []Batch `json:"batches"`
-	NextPageToken string  `json:"nextPageToken"`
+	NextPageToken string  `json:"nextPageToken,omitempty"`
+}
+
+// The always*Response types shadow the NextPageToken of the list responses
+// they embed so that it is included even if it is empty; see
+// WithAlwaysPageToken.
+type alwaysBatchesResponse struct {
+	ListBatchesResponse
+	NextPageToken string `json:"nextPageToken"`
+}
+
+type alwaysSessionTemplatesResponse struct {
+	ListSessionTemplatesResponse
+	NextPageToken string `json:"nextPageToken"`
+}
+
+type alwaysSessionsResponse struct {
+	ListSessionsResponse
+	NextPageToken string `json:"nextPageToken"`
+}
+
+// WithAlwaysPageToken returns resp, a response of ListBatches, ListSessions or
+// ListSessionTemplates, with its nextPageToken included even if it is empty,
+// so that clients can detect the last page without checking for the field.
+// Other values, such as the response of ListBatchesInLocations, which does not
+// page, are returned unchanged.
+func WithAlwaysPageToken(resp any) any {
+	switch r := resp.(type) {
+	case ListBatchesResponse:
+		return alwaysBatchesResponse{ListBatchesResponse: r, NextPageToken: r.NextPageToken}
+	case ListSessionTemplatesResponse:
+		return alwaysSessionTemplatesResponse{ListSessionTemplatesResponse: r, NextPageToken: r.NextPageToken}
+	case ListSessionsResponse:
+		return alwaysSessionsResponse{ListSessionsResponse: r, NextPageToken: r.NextPageToken}
+	}
+	return resp
 }
 
 // Batch represents a single batch job.
@@ -203,13 +426,33 @@ type Batch struct {
 	Creator    string `json:"creator"`
 	CreateTime string `json:"createTime"`
 	Operation  string `json:"operation"`
-	ConsoleURL string `json:"consoleUrl"`
-	LogsURL    string `json:"logsUrl"`
+	// ConsoleURL and LogsURL are empty if the batch's name can't be parsed.
+	ConsoleURL string `json:"consoleUrl,omitempty"`
+	LogsURL    string `json:"logsUrl,omitempty"`
+	// Location is only set when listing batches across locations.
+	Location string `json:"location,omitempty"`
+	// Labels is only set when requested, to keep the list output compact.
+	Labels map[string]string `json:"labels,omitempty"`
 }
 
-func (s *Source) ListBatches(ctx context.Context, ps *int, pt, filter string) (any, error) {
-	client := s.GetBatchControllerClient()
-	parent := fmt.Sprintf("projects/%s/locations/%s", s.GetProject(), s.GetLocation())
+// ListBatches lists the batches in the given project and the source's location.
+// If project is empty, the source's project is used. If includeLabels is true,
+// the batches' labels are included.
+func (s *Source) ListBatches(ctx context.Context, project string, ps *int, pt, filter string, includeLabels bool) (any, error) {
+	if project == "" {
+		project = s.GetProject()
+	}
+	batches, nextPageToken, err := listBatches(ctx, s.GetBatchControllerClient(), project, s.GetLocation(), ps, pt, filter, includeLabels)
+	if err != nil {
+		return nil, err
+	}
+	return ListBatchesResponse{Batches: batches, NextPageToken: nextPageToken}, nil
+}
+
+// listBatches lists a page of the batches in the given project and location,
+// newest first.
+func listBatches(ctx context.Context, client *dataproc.BatchControllerClient, project, location string, ps *int, pt, filter string, includeLabels bool) ([]Batch, string, error) {
+	parent := fmt.Sprintf("projects/%s/locations/%s", project, location)
 	req := &dataprocpb.ListBatchesRequest{
 		Parent:  parent,
 		OrderBy: "create_time desc",
@@ -231,29 +474,25 @@ func (s *Source) ListBatches(ctx context.Context, ps *int, pt, filter string) (a
 	var batchPbs []*dataprocpb.Batch
 	nextPageToken, err := pager.NextPage(&batchPbs)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list batches: %w", err)
+		return nil, "", fmt.Errorf("failed to list batches: %w", ToAPIError(err))
 	}
 
 	batches, err := ToBatches(batchPbs)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
-
-	return ListBatchesResponse{Batches: batches, NextPageToken: nextPageToken}, nil
+	if includeLabels {
+		for i, batchPb := range batchPbs {
+			batches[i].Labels = batchPb.Labels
+		}
+	}
+	return batches, nextPageToken, nil
 }
 
 // ToBatches converts a slice of protobuf Batch messages to a slice of Batch structs.
 func ToBatches(batchPbs []*dataprocpb.Batch) ([]Batch, error) {
 	batches := make([]Batch, 0, len(batchPbs))
 	for _, batchPb := range batchPbs {
-		consoleUrl, err := BatchConsoleURLFromProto(batchPb)
-		if err != nil {
-			return nil, fmt.Errorf("error generating console url: %v", err)
-		}
-		logsUrl, err := BatchLogsURLFromProto(batchPb)
-		if err != nil {
-			return nil, fmt.Errorf("error generating logs url: %v", err)
-		}
 		batch := Batch{
 			Name:       batchPb.Name,
 			UUID:       batchPb.Uuid,
@@ -261,18 +500,26 @@ func ToBatches(batchPbs []*dataprocpb.Batch) ([]Batch, error) {
 			Creator:    batchPb.Creator,
 			CreateTime: batchPb.CreateTime.AsTime().Format(time.RFC3339),
 			Operation:  batchPb.Operation,
-			ConsoleURL: consoleUrl,
-			LogsURL:    logsUrl,
+		}
+		// A batch whose name can't be parsed is still listed, just without
+		// links, rather than failing the whole listing.
+		if consoleUrl, err := BatchConsoleURLFromProto(batchPb); err == nil {
+			batch.ConsoleURL = consoleUrl
+		}
+		if logsUrl, err := BatchLogsURLFromProto(batchPb); err == nil {
+			batch.LogsURL = logsUrl
 		}
 		batches = append(batches, batch)
 	}
 	return batches, nil
 }
 
+// GetBatch gets the batch with the given full resource name; see
+// BatchResourceName.
 func (s *Source) GetBatch(ctx context.Context, name string) (map[string]any, error) {
 	client := s.GetBatchControllerClient()
 	req := &dataprocpb.GetBatchRequest{
-		Name: fmt.Sprintf("projects/%s/locations/%s/batches/%s", s.GetProject(), s.GetLocation(), name),
+		Name: name,
 	}
 
 	batchPb, err := client.GetBatch(ctx, req)
@@ -299,21 +546,74 @@ func (s *Source) GetBatch(ctx context.Context, name string) (map[string]any, err
 		return nil, fmt.Errorf("error generating logs url: %v", err)
 	}
 
+	// Surface the state fields at the top level so that e.g. the reason for a
+	// failure can be reported without traversing the full batch.
 	wrappedResult := map[string]any{
-		"consoleUrl": consoleUrl,
-		"logsUrl":    logsUrl,
-		"batch":      result,
+		"state":        batchPb.GetState().String(),
+		"stateMessage": batchPb.GetStateMessage(),
+		"consoleUrl":   consoleUrl,
+		"logsUrl":      logsUrl,
+		"runtimeInfo":  BatchRuntimeInfo(batchPb),
+		"batch":        result,
+	}
+	if batchPb.GetStateTime() != nil {
+		wrappedResult["stateTime"] = batchPb.GetStateTime().AsTime().Format(time.RFC3339Nano)
+	}
+	if dcuHours, ok := EstimatedDCUHours(batchPb); ok {
+		wrappedResult["estimatedDcuHours"] = dcuHours
+	}
+	if d, ok := BatchDuration(batchPb, time.Now()); ok {
+		wrappedResult["durationSeconds"] = int64(d / time.Second)
 	}
 
 	return wrappedResult, nil
 }
 
+// ListSessionTemplatesResponse is the response from the list session templates API.
+type ListSessionTemplatesResponse struct {
+	SessionTemplates []SessionTemplate `json:"sessionTemplates"`
+	NextPageToken    string            `json:"nextPageToken,omitempty"`
+}
+
 // SessionTemplate represents a single session template.
 type SessionTemplate struct {
-	Name        string `json:"name"`
-	Description string `json:"description"`
-	Creator     string `json:"creator"`
-	CreateTime  string `json:"createTime"`
+	Name           string `json:"name"`
+	Description    string `json:"description"`
+	Creator        string `json:"creator"`
+	CreateTime     string `json:"createTime"`
+	RuntimeVersion string `json:"runtimeVersion,omitempty"`
+}
+
+// ListSessionTemplates lists the session templates in the source's project and
+// location.
+func (s *Source) ListSessionTemplates(ctx context.Context, ps *int, pt string) (any, error) {
+	client := s.GetSessionTemplateControllerClient()
+	req := &dataprocpb.ListSessionTemplatesRequest{
+		Parent: fmt.Sprintf("projects/%s/locations/%s", s.GetProject(), s.GetLocation()),
+	}
+
+	if ps != nil {
+		req.PageSize = int32(*ps)
+	}
+	if pt != "" {
+		req.PageToken = pt
+	}
+
+	it := client.ListSessionTemplates(ctx, req)
+	pager := iterator.NewPager(it, int(req.PageSize), req.PageToken)
+
+	var sessionTemplatePbs []*dataprocpb.SessionTemplate
+	nextPageToken, err := pager.NextPage(&sessionTemplatePbs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list session templates: %w", err)
+	}
+
+	sessionTemplates, err := ToSessionTemplates(sessionTemplatePbs)
+	if err != nil {
+		return nil, err
+	}
+
+	return ListSessionTemplatesResponse{SessionTemplates: sessionTemplates, NextPageToken: nextPageToken}, nil
 }
 
 func (s *Source) GetSessionTemplate(ctx context.Context, name string) (map[string]any, error) {
@@ -350,20 +650,39 @@ func ToSessionTemplates(sessionTemplatePbs []*dataprocpb.SessionTemplate) ([]Ses
 	for _, sessionTemplatePb := range sessionTemplatePbs {
 
 		sessionTemplate := SessionTemplate{
-			Name:        sessionTemplatePb.Name,
-			Description: sessionTemplatePb.Description,
-			Creator:     sessionTemplatePb.Creator,
-			CreateTime:  sessionTemplatePb.CreateTime.AsTime().Format(time.RFC3339),
+			Name:           sessionTemplatePb.Name,
+			Description:    sessionTemplatePb.Description,
+			Creator:        sessionTemplatePb.Creator,
+			CreateTime:     sessionTemplatePb.CreateTime.AsTime().Format(time.RFC3339),
+			RuntimeVersion: sessionTemplatePb.GetRuntimeConfig().GetVersion(),
 		}
 		sessionTemplates = append(sessionTemplates, sessionTemplate)
 	}
 	return sessionTemplates, nil
 }
 
+// CreateSession starts creating an interactive session with the given ID. It
+// returns the name of the create operation and the console URL of the session,
+// without waiting for the session to become active.
+func (s *Source) CreateSession(ctx context.Context, sessionID string, session *dataprocpb.Session) (map[string]any, error) {
+	req := createSessionRequest(s.GetProject(), s.GetLocation(), sessionID, session)
+
+	op, err := s.GetSessionControllerClient().CreateSession(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create session: %w", err)
+	}
+
+	wrappedResult := map[string]any{
+		"operation":  op.Name(),
+		"consoleUrl": SessionConsoleURL(s.GetProject(), s.GetLocation(), sessionID),
+	}
+	return wrappedResult, nil
+}
+
 // ListSessionsResponse is the response from the list sessions API.
 type ListSessionsResponse struct {
 	Sessions      []Session `json:"sessions"`
-	NextPageToken string    `json:"nextPageToken"`
+	NextPageToken string    `json:"nextPageToken,omitempty"`
 }
 
 // Session represents a single session job.
@@ -411,10 +730,12 @@ func (s *Source) ListSessions(ctx context.Context, ps *int, pt, filter string) (
 	return ListSessionsResponse{Sessions: sessions, NextPageToken: nextPageToken}, nil
 }
 
+// GetSession gets the session with the given full resource name; see
+// SessionResourceName.
 func (s *Source) GetSession(ctx context.Context, name string) (map[string]any, error) {
 	client := s.GetSessionControllerClient()
 	req := &dataprocpb.GetSessionRequest{
-		Name: fmt.Sprintf("projects/%s/locations/%s/sessions/%s", s.GetProject(), s.GetLocation(), name),
+		Name: name,
 	}
 
 	sessionPb, err := client.GetSession(ctx, req)
@@ -422,6 +743,12 @@ func (s *Source) GetSession(ctx context.Context, name string) (map[string]any, e
 		return nil, fmt.Errorf("failed to get session: %w", err)
 	}
 
+	return sessionResult(sessionPb)
+}
+
+// sessionResult converts a session to the result of GetSession, with links
+// to the session's console page and logs.
+func sessionResult(sessionPb *dataprocpb.Session) (map[string]any, error) {
 	jsonBytes, err := protojson.Marshal(sessionPb)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal session to JSON: %w", err)
@@ -16,13 +16,31 @@ package serverlessspark_test
 
 import (
 	"context"
+	"encoding/json"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
+	dataproc "cloud.google.com/go/dataproc/v2/apiv1"
+	"cloud.google.com/go/dataproc/v2/apiv1/dataprocpb"
+	"cloud.google.com/go/logging/apiv2/loggingpb"
+	"cloud.google.com/go/logging/logadmin"
+	"cloud.google.com/go/longrunning/autogen/longrunningpb"
 	"github.com/google/go-cmp/cmp"
 	"github.com/googleapis/mcp-toolbox/internal/server"
 	"github.com/googleapis/mcp-toolbox/internal/sources"
 	"github.com/googleapis/mcp-toolbox/internal/sources/serverlessspark"
 	"github.com/googleapis/mcp-toolbox/internal/testutils"
+	"github.com/googleapis/mcp-toolbox/internal/util"
+	"google.golang.org/api/option"
+	ltype "google.golang.org/genproto/googleapis/logging/type"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func TestParseFromYamlServerlessSpark(t *testing.T) {
@@ -49,6 +67,151 @@ func TestParseFromYamlServerlessSpark(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "with credentials file",
+			in: `
+				kind: source
+				name: my-instance
+				type: serverless-spark
+				project: my-project
+				location: my-location
+				credentialsFile: /etc/toolbox/key.json
+			`,
+			want: map[string]sources.SourceConfig{
+				"my-instance": serverlessspark.Config{
+					Name:            "my-instance",
+					Type:            serverlessspark.SourceType,
+					Project:         "my-project",
+					Location:        "my-location",
+					CredentialsFile: "/etc/toolbox/key.json",
+				},
+			},
+		},
+		{
+			desc: "with allowed locations",
+			in: `
+				kind: source
+				name: my-instance
+				type: serverless-spark
+				project: my-project
+				location: us-central1
+				allowedLocations:
+				  - us-central1
+				  - europe-west4
+			`,
+			want: map[string]sources.SourceConfig{
+				"my-instance": serverlessspark.Config{
+					Name:             "my-instance",
+					Type:             serverlessspark.SourceType,
+					Project:          "my-project",
+					Location:         "us-central1",
+					AllowedLocations: []string{"us-central1", "europe-west4"},
+				},
+			},
+		},
+		{
+			desc: "with custom headers",
+			in: `
+				kind: source
+				name: my-instance
+				type: serverless-spark
+				project: my-project
+				location: us-central1
+				customHeaders:
+				  X-Request-Context: audit
+			`,
+			want: map[string]sources.SourceConfig{
+				"my-instance": serverlessspark.Config{
+					Name:          "my-instance",
+					Type:          serverlessspark.SourceType,
+					Project:       "my-project",
+					Location:      "us-central1",
+					CustomHeaders: map[string]string{"X-Request-Context": "audit"},
+				},
+			},
+		},
+		{
+			desc: "with default labels",
+			in: `
+				kind: source
+				name: my-instance
+				type: serverless-spark
+				project: my-project
+				location: us-central1
+				defaultLabels:
+				  team: data-eng
+				  env: prod
+			`,
+			want: map[string]sources.SourceConfig{
+				"my-instance": serverlessspark.Config{
+					Name:          "my-instance",
+					Type:          serverlessspark.SourceType,
+					Project:       "my-project",
+					Location:      "us-central1",
+					DefaultLabels: map[string]string{"team": "data-eng", "env": "prod"},
+				},
+			},
+		},
+		{
+			desc: "with max concurrency",
+			in: `
+				kind: source
+				name: my-instance
+				type: serverless-spark
+				project: my-project
+				location: us-central1
+				maxConcurrency: 8
+			`,
+			want: map[string]sources.SourceConfig{
+				"my-instance": serverlessspark.Config{
+					Name:           "my-instance",
+					Type:           serverlessspark.SourceType,
+					Project:        "my-project",
+					Location:       "us-central1",
+					MaxConcurrency: 8,
+				},
+			},
+		},
+		{
+			desc: "with universe domain",
+			in: `
+				kind: source
+				name: my-instance
+				type: serverless-spark
+				project: my-project
+				location: us-central1
+				universeDomain: example.goog
+			`,
+			want: map[string]sources.SourceConfig{
+				"my-instance": serverlessspark.Config{
+					Name:           "my-instance",
+					Type:           serverlessspark.SourceType,
+					Project:        "my-project",
+					Location:       "us-central1",
+					UniverseDomain: "example.goog",
+				},
+			},
+		},
+		{
+			desc: "with resource cache TTL",
+			in: `
+				kind: source
+				name: my-instance
+				type: serverless-spark
+				project: my-project
+				location: us-central1
+				resourceCacheTTL: 1m
+			`,
+			want: map[string]sources.SourceConfig{
+				"my-instance": serverlessspark.Config{
+					Name:             "my-instance",
+					Type:             serverlessspark.SourceType,
+					Project:          "my-project",
+					Location:         "us-central1",
+					ResourceCacheTTL: "1m",
+				},
+			},
+		},
 	}
 	for _, tc := range tcs {
 		t.Run(tc.desc, func(t *testing.T) {
@@ -116,3 +279,444 @@ func TestFailParseFromYaml(t *testing.T) {
 		})
 	}
 }
+
+func TestInitializeInvalidCredentialsFile(t *testing.T) {
+	ctx := util.WithUserAgent(context.Background(), "test")
+	path := filepath.Join(t.TempDir(), "key.json")
+	if err := os.WriteFile(path, []byte("not json"), 0o600); err != nil {
+		t.Fatalf("failed to write credentials file: %s", err)
+	}
+	cfg := serverlessspark.Config{Name: "my-instance", Type: serverlessspark.SourceType, Project: "my-project", Location: "my-location", CredentialsFile: path}
+	_, err := cfg.Initialize(ctx, nil)
+	if err == nil || !strings.Contains(err.Error(), "failed to parse credentials file") {
+		t.Fatalf("Initialize() error = %v, want credentials file parse error", err)
+	}
+}
+
+func TestInitializeLocationNotAllowed(t *testing.T) {
+	ctx := util.WithUserAgent(context.Background(), "test")
+	cfg := serverlessspark.Config{Name: "my-instance", Type: serverlessspark.SourceType, Project: "my-project", Location: "us-central1", AllowedLocations: []string{"europe-west4"}}
+	_, err := cfg.Initialize(ctx, nil)
+	if err == nil || !strings.Contains(err.Error(), "must be one of allowedLocations") {
+		t.Fatalf("Initialize() error = %v, want allowedLocations error", err)
+	}
+}
+
+func TestInitializeReservedCustomHeader(t *testing.T) {
+	ctx := util.WithUserAgent(context.Background(), "test")
+	cfg := serverlessspark.Config{Name: "my-instance", Type: serverlessspark.SourceType, Project: "my-project", Location: "us-central1", CustomHeaders: map[string]string{"Authorization": "Bearer token"}}
+	_, err := cfg.Initialize(ctx, nil)
+	if err == nil || !strings.Contains(err.Error(), "customHeaders may not set") {
+		t.Fatalf("Initialize() error = %v, want customHeaders error", err)
+	}
+}
+
+func TestInitializeInvalidDefaultLabels(t *testing.T) {
+	ctx := util.WithUserAgent(context.Background(), "test")
+	cfg := serverlessspark.Config{Name: "my-instance", Type: serverlessspark.SourceType, Project: "my-project", Location: "us-central1", DefaultLabels: map[string]string{"Team": "data-eng"}}
+	_, err := cfg.Initialize(ctx, nil)
+	if err == nil || !strings.Contains(err.Error(), `invalid defaultLabels: invalid label key "Team"`) {
+		t.Fatalf("Initialize() error = %v, want invalid defaultLabels error", err)
+	}
+}
+
+func TestInitializeInvalidMaxConcurrency(t *testing.T) {
+	ctx := util.WithUserAgent(context.Background(), "test")
+	cfg := serverlessspark.Config{Name: "my-instance", Type: serverlessspark.SourceType, Project: "my-project", Location: "us-central1", MaxConcurrency: -1}
+	_, err := cfg.Initialize(ctx, nil)
+	if err == nil || !strings.Contains(err.Error(), "maxConcurrency must not be negative") {
+		t.Fatalf("Initialize() error = %v, want maxConcurrency error", err)
+	}
+}
+
+func TestInitializeInvalidUniverseDomain(t *testing.T) {
+	ctx := util.WithUserAgent(context.Background(), "test")
+	cfg := serverlessspark.Config{Name: "my-instance", Type: serverlessspark.SourceType, Project: "my-project", Location: "us-central1", UniverseDomain: "https://example.goog"}
+	_, err := cfg.Initialize(ctx, nil)
+	if err == nil || !strings.Contains(err.Error(), "universeDomain must be a domain name") {
+		t.Fatalf("Initialize() error = %v, want universeDomain error", err)
+	}
+}
+
+func TestInitializeInvalidResourceCacheTTL(t *testing.T) {
+	ctx := util.WithUserAgent(context.Background(), "test")
+	for _, ttl := range []string{"30", "-1s"} {
+		cfg := serverlessspark.Config{Name: "my-instance", Type: serverlessspark.SourceType, Project: "my-project", Location: "us-central1", ResourceCacheTTL: ttl}
+		_, err := cfg.Initialize(ctx, nil)
+		if err == nil || !strings.Contains(err.Error(), "resourceCacheTTL must be a non-negative duration") {
+			t.Errorf("Initialize() with resourceCacheTTL %q: error = %v, want resourceCacheTTL error", ttl, err)
+		}
+	}
+}
+
+func TestIsLocationAllowed(t *testing.T) {
+	s := &serverlessspark.Source{}
+	if !s.IsLocationAllowed("asia-east1") {
+		t.Errorf("IsLocationAllowed() = false with no allowedLocations, want true")
+	}
+	s.AllowedLocations = []string{"us-central1", "europe-west4"}
+	if !s.IsLocationAllowed("europe-west4") {
+		t.Errorf("IsLocationAllowed(%q) = false, want true", "europe-west4")
+	}
+	if s.IsLocationAllowed("asia-east1") {
+		t.Errorf("IsLocationAllowed(%q) = true, want false", "asia-east1")
+	}
+}
+
+// fakeBatchController returns batch for GetBatch and an operation for
+// CreateBatch, and records the ListBatches and CreateBatch requests.
+type fakeBatchController struct {
+	dataprocpb.UnimplementedBatchControllerServer
+	batch     *dataprocpb.Batch
+	listReq   *dataprocpb.ListBatchesRequest
+	createReq *dataprocpb.CreateBatchRequest
+}
+
+func (f *fakeBatchController) GetBatch(ctx context.Context, req *dataprocpb.GetBatchRequest) (*dataprocpb.Batch, error) {
+	return f.batch, nil
+}
+
+func (f *fakeBatchController) ListBatches(ctx context.Context, req *dataprocpb.ListBatchesRequest) (*dataprocpb.ListBatchesResponse, error) {
+	f.listReq = req
+	return &dataprocpb.ListBatchesResponse{}, nil
+}
+
+func (f *fakeBatchController) CreateBatch(ctx context.Context, req *dataprocpb.CreateBatchRequest) (*longrunningpb.Operation, error) {
+	f.createReq = req
+	meta, err := anypb.New(&dataprocpb.BatchOperationMetadata{
+		Batch:      req.Parent + "/batches/my-batch",
+		BatchUuid:  "1234",
+		CreateTime: timestamppb.New(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &longrunningpb.Operation{Name: "projects/my-project/locations/us-central1/operations/my-op", Metadata: meta}, nil
+}
+
+func TestCreateBatch(t *testing.T) {
+	lis, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	srv := grpc.NewServer()
+	dataprocpb.RegisterBatchControllerServer(srv, &fakeBatchController{})
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	ctx := context.Background()
+	client, err := dataproc.NewBatchControllerClient(ctx,
+		option.WithEndpoint(lis.Addr().String()),
+		option.WithoutAuthentication(),
+		option.WithGRPCDialOption(grpc.WithTransportCredentials(insecure.NewCredentials())),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	t.Cleanup(func() { client.Close() })
+	source := &serverlessspark.Source{
+		Config:      serverlessspark.Config{Project: "my-project", Location: "us-central1"},
+		BatchClient: client,
+	}
+
+	got, err := source.CreateBatch(ctx, "", &dataprocpb.Batch{}, "my-request")
+	if err != nil {
+		t.Fatalf("CreateBatch() error = %v", err)
+	}
+	if want := "projects/my-project/locations/us-central1/operations/my-op"; got["operation"] != want {
+		t.Errorf("got operation %v, want %q", got["operation"], want)
+	}
+	meta, ok := got["opMetadata"].(*dataprocpb.BatchOperationMetadata)
+	if !ok {
+		t.Fatalf("got opMetadata %T, want *dataprocpb.BatchOperationMetadata", got["opMetadata"])
+	}
+	if want := "projects/my-project/locations/us-central1/batches/my-batch"; meta.GetBatch() != want {
+		t.Errorf("got batch %q, want %q", meta.GetBatch(), want)
+	}
+}
+
+// fakeSessionController returns a session in the given state for GetSession.
+type fakeSessionController struct {
+	dataprocpb.UnimplementedSessionControllerServer
+	state dataprocpb.Session_State
+}
+
+func (f *fakeSessionController) GetSession(ctx context.Context, req *dataprocpb.GetSessionRequest) (*dataprocpb.Session, error) {
+	return &dataprocpb.Session{
+		Name:       req.Name,
+		State:      f.state,
+		CreateTime: timestamppb.New(time.Date(2026, 1, 2, 3, 0, 0, 0, time.UTC)),
+		StateTime:  timestamppb.New(time.Date(2026, 1, 2, 4, 0, 0, 0, time.UTC)),
+	}, nil
+}
+
+// fakeLogging records the ListLogEntries request and returns entries, or fixed
+// entries if entries is nil.
+type fakeLogging struct {
+	loggingpb.UnimplementedLoggingServiceV2Server
+	entries []*loggingpb.LogEntry
+	gotReq  *loggingpb.ListLogEntriesRequest
+}
+
+func (f *fakeLogging) ListLogEntries(ctx context.Context, req *loggingpb.ListLogEntriesRequest) (*loggingpb.ListLogEntriesResponse, error) {
+	f.gotReq = req
+	if f.entries != nil {
+		return &loggingpb.ListLogEntriesResponse{Entries: f.entries}, nil
+	}
+	var entries []*loggingpb.LogEntry
+	for i, msg := range []string{"driver exited", "executor lost", "out of memory"} {
+		entries = append(entries, &loggingpb.LogEntry{
+			LogName:   "projects/my-project/logs/dataproc.googleapis.com%2Foutput",
+			Severity:  ltype.LogSeverity_ERROR,
+			Timestamp: timestamppb.New(time.Date(2026, 1, 2, 3, 59-i, 0, 0, time.UTC)),
+			Payload:   &loggingpb.LogEntry_TextPayload{TextPayload: msg},
+		})
+	}
+	return &loggingpb.ListLogEntriesResponse{Entries: entries}, nil
+}
+
+func TestGetSessionWithErrorLogs(t *testing.T) {
+	tcs := []struct {
+		desc          string
+		state         dataprocpb.Session_State
+		filter        string
+		rawFilterOnly bool
+		logsProject   string
+		wantLogs      []string
+		wantFilter    string
+	}{
+		{desc: "failed", state: dataprocpb.Session_FAILED, wantLogs: []string{"driver exited", "executor lost"}},
+		{desc: "active", state: dataprocpb.Session_ACTIVE},
+		{desc: "failed with logs project", state: dataprocpb.Session_FAILED, logsProject: "central-logs", wantLogs: []string{"driver exited", "executor lost"}},
+		{
+			desc:     "failed with filter",
+			state:    dataprocpb.Session_FAILED,
+			filter:   `textPayload:"lost" OR jsonPayload.message:"lost"`,
+			wantLogs: []string{"driver exited", "executor lost"},
+			wantFilter: `resource.type="cloud_dataproc_session"
+resource.labels.location="us-central1"
+resource.labels.project_id="my-project"
+resource.labels.session_id="my-session"
+severity>=ERROR
+timestamp>="2026-01-02T02:59:00Z"
+timestamp<="2026-01-02T04:00:00Z"
+(textPayload:"lost" OR jsonPayload.message:"lost")`,
+		},
+		{
+			desc:          "failed with raw filter only",
+			state:         dataprocpb.Session_FAILED,
+			filter:        `logName:"spark" OR textPayload:"lost"`,
+			rawFilterOnly: true,
+			wantLogs:      []string{"driver exited", "executor lost"},
+			// The entries are still error logs. The filter is still
+			// parenthesized, so that its OR does not bind looser than the
+			// other clauses' AND.
+			wantFilter: `severity>=ERROR
+timestamp>="2026-01-02T02:59:00Z"
+timestamp<="2026-01-02T04:00:00Z"
+(logName:"spark" OR textPayload:"lost")`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			lis, err := net.Listen("tcp", "localhost:0")
+			if err != nil {
+				t.Fatalf("failed to listen: %v", err)
+			}
+			logging := &fakeLogging{}
+			srv := grpc.NewServer()
+			dataprocpb.RegisterSessionControllerServer(srv, &fakeSessionController{state: tc.state})
+			loggingpb.RegisterLoggingServiceV2Server(srv, logging)
+			go func() { _ = srv.Serve(lis) }()
+			t.Cleanup(srv.Stop)
+
+			ctx := context.Background()
+			opts := []option.ClientOption{
+				option.WithEndpoint(lis.Addr().String()),
+				option.WithoutAuthentication(),
+				option.WithGRPCDialOption(grpc.WithTransportCredentials(insecure.NewCredentials())),
+			}
+			sessionClient, err := dataproc.NewSessionControllerClient(ctx, opts...)
+			if err != nil {
+				t.Fatalf("failed to create session client: %v", err)
+			}
+			t.Cleanup(func() { sessionClient.Close() })
+			loggingClient, err := logadmin.NewClient(ctx, "my-project", opts...)
+			if err != nil {
+				t.Fatalf("failed to create logging client: %v", err)
+			}
+			t.Cleanup(func() { loggingClient.Close() })
+			source := &serverlessspark.Source{
+				Config:        serverlessspark.Config{Project: "my-project", Location: "us-central1"},
+				SessionClient: sessionClient,
+				LoggingClient: loggingClient,
+			}
+
+			got, err := source.GetSessionWithErrorLogs(ctx, "projects/my-project/locations/us-central1/sessions/my-session", 2, tc.filter, tc.rawFilterOnly, tc.logsProject)
+			if err != nil {
+				t.Fatalf("GetSessionWithErrorLogs() error = %v", err)
+			}
+			if _, ok := got["session"]; !ok {
+				t.Errorf("result has no session: %v", got)
+			}
+			if tc.wantLogs == nil {
+				if _, ok := got["errorLogs"]; ok {
+					t.Errorf("got errorLogs %v, want none", got["errorLogs"])
+				}
+				if logging.gotReq != nil {
+					t.Errorf("logs were listed for a session in state %v", tc.state)
+				}
+				return
+			}
+			logs, ok := got["errorLogs"].([]map[string]any)
+			if !ok {
+				t.Fatalf("got errorLogs %T, want []map[string]any", got["errorLogs"])
+			}
+			var gotLogs []string
+			for _, l := range logs {
+				gotLogs = append(gotLogs, l["payload"].(string))
+			}
+			if diff := cmp.Diff(tc.wantLogs, gotLogs); diff != "" {
+				t.Errorf("incorrect errorLogs: diff %v", diff)
+			}
+			want := `resource.labels.session_id="my-session"`
+			if got := strings.Contains(logging.gotReq.GetFilter(), want); got == tc.rawFilterOnly {
+				t.Errorf("got filter %q containing %q %t, want %t", logging.gotReq.GetFilter(), want, got, !tc.rawFilterOnly)
+			}
+			if !strings.Contains(logging.gotReq.GetFilter(), "severity>=ERROR") {
+				t.Errorf("got filter %q, want it to contain severity>=ERROR", logging.gotReq.GetFilter())
+			}
+			if tc.wantFilter != "" {
+				if diff := cmp.Diff(tc.wantFilter, logging.gotReq.GetFilter()); diff != "" {
+					t.Errorf("incorrect filter (-want +got):\n%s", diff)
+				}
+			}
+			if got, want := logging.gotReq.GetOrderBy(), "timestamp desc"; got != want {
+				t.Errorf("got order %q, want %q", got, want)
+			}
+			wantResources := []string{"projects/my-project"}
+			if tc.logsProject != "" {
+				wantResources = []string{"projects/" + tc.logsProject}
+			}
+			if diff := cmp.Diff(wantResources, logging.gotReq.GetResourceNames()); diff != "" {
+				t.Errorf("resource names mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestToBatchesUnparseableName(t *testing.T) {
+	batches, err := serverlessspark.ToBatches([]*dataprocpb.Batch{
+		{Name: "projects/my-project/locations/us-central1/batches/my-batch"},
+		{Name: "not-a-batch-name"},
+	})
+	if err != nil {
+		t.Fatalf("ToBatches() error = %v", err)
+	}
+	if want := "https://console.cloud.google.com/dataproc/batches/us-central1/my-batch/summary?project=my-project"; batches[0].ConsoleURL != want {
+		t.Errorf("got consoleUrl %q, want %q", batches[0].ConsoleURL, want)
+	}
+	if batches[1].Name != "not-a-batch-name" || batches[1].ConsoleURL != "" || batches[1].LogsURL != "" {
+		t.Errorf("got %+v, want batch without links", batches[1])
+	}
+}
+
+func TestListBatchesFilterAndOrder(t *testing.T) {
+	lis, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	controller := &fakeBatchController{}
+	srv := grpc.NewServer()
+	dataprocpb.RegisterBatchControllerServer(srv, controller)
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	ctx := context.Background()
+	client, err := dataproc.NewBatchControllerClient(ctx,
+		option.WithEndpoint(lis.Addr().String()),
+		option.WithoutAuthentication(),
+		option.WithGRPCDialOption(grpc.WithTransportCredentials(insecure.NewCredentials())),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	t.Cleanup(func() { client.Close() })
+	source := &serverlessspark.Source{
+		Config:      serverlessspark.Config{Project: "my-project", Location: "us-central1"},
+		BatchClient: client,
+	}
+
+	filter := `state = FAILED AND create_time >= "2026-01-01T00:00:00Z"`
+	pageSize := 20
+	got, err := source.ListBatches(ctx, "", &pageSize, "", filter, false)
+	if err != nil {
+		t.Fatalf("ListBatches() error = %v", err)
+	}
+	if got := controller.listReq.GetFilter(); got != filter {
+		t.Errorf("got filter %q, want %q", got, filter)
+	}
+	if got, want := controller.listReq.GetOrderBy(), "create_time desc"; got != want {
+		t.Errorf("got orderBy %q, want %q", got, want)
+	}
+	// The empty token of the last page is omitted unless requested.
+	b, err := json.Marshal(got)
+	if err != nil {
+		t.Fatalf("failed to marshal response: %v", err)
+	}
+	if strings.Contains(string(b), "nextPageToken") {
+		t.Errorf("got response %s, want no nextPageToken", b)
+	}
+	b, err = json.Marshal(serverlessspark.WithAlwaysPageToken(got))
+	if err != nil {
+		t.Fatalf("failed to marshal response: %v", err)
+	}
+	if want := `{"batches":[],"nextPageToken":""}`; string(b) != want {
+		t.Errorf("got response %s, want %s", b, want)
+	}
+}
+
+func TestWithAlwaysPageToken(t *testing.T) {
+	tcs := []struct {
+		desc string
+		resp any
+		want string
+	}{
+		{
+			desc: "batches",
+			resp: serverlessspark.ListBatchesResponse{Batches: []serverlessspark.Batch{}},
+			want: `{"batches":[],"nextPageToken":""}`,
+		},
+		{
+			desc: "batches with token",
+			resp: serverlessspark.ListBatchesResponse{Batches: []serverlessspark.Batch{}, NextPageToken: "abc"},
+			want: `{"batches":[],"nextPageToken":"abc"}`,
+		},
+		{
+			desc: "sessions",
+			resp: serverlessspark.ListSessionsResponse{Sessions: []serverlessspark.Session{}},
+			want: `{"sessions":[],"nextPageToken":""}`,
+		},
+		{
+			desc: "session templates",
+			resp: serverlessspark.ListSessionTemplatesResponse{SessionTemplates: []serverlessspark.SessionTemplate{}},
+			want: `{"sessionTemplates":[],"nextPageToken":""}`,
+		},
+		{
+			desc: "other",
+			resp: map[string]any{"batches": []any{}},
+			want: `{"batches":[]}`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			b, err := json.Marshal(serverlessspark.WithAlwaysPageToken(tc.resp))
+			if err != nil {
+				t.Fatalf("failed to marshal response: %v", err)
+			}
+			if string(b) != tc.want {
+				t.Errorf("got %s, want %s", b, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serverlessspark
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/dataproc/v2/apiv1/dataprocpb"
+	"cloud.google.com/go/logging/logadmin"
+	"github.com/googleapis/mcp-toolbox/internal/util/logiter"
+	"google.golang.org/api/iterator"
+)
+
+// GetSessionWithErrorLogs gets a session like GetSession. If the session
+// failed, it also adds up to limit of the session's most recent ERROR log
+// entries, newest first, as "errorLogs". If filter is set, the entries must
+// also match it; if rawFilterOnly is also set, they need only match it, the
+// severity, and the session's time range, as with sessionErrorLogs. The
+// entries are listed in logsProject if it is set, or else in the session's
+// project. If ctx's deadline passes while the entries are listed, those read
+// so far are returned, and "errorLogsTruncated" is set.
+func (s *Source) GetSessionWithErrorLogs(ctx context.Context, name string, limit int, filter string, rawFilterOnly bool, logsProject string) (map[string]any, error) {
+	sessionPb, err := s.GetSessionControllerClient().GetSession(ctx, &dataprocpb.GetSessionRequest{Name: name})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+	result, err := sessionResult(sessionPb)
+	if err != nil {
+		return nil, err
+	}
+	if sessionPb.GetState() != dataprocpb.Session_FAILED {
+		return result, nil
+	}
+	logs, truncated, err := sessionErrorLogs(ctx, s.GetLoggingClient(), sessionPb, limit, filter, rawFilterOnly, logsProject)
+	if err != nil {
+		return nil, err
+	}
+	result["errorLogs"] = logs
+	if truncated {
+		result["errorLogsTruncated"] = true
+	}
+	return result, nil
+}
+
+// sessionErrorLogs returns up to limit of the session's most recent log
+// entries with severity ERROR or higher, newest first. A non-empty filter is
+// ANDed with the session's clauses, like the other filters of LogFilterSpec.
+// If rawFilterOnly is set, the resource clauses are left out, so that the
+// entries need only match filter, the severity, and the session's time range,
+// and are still error logs. The
+// entries are listed in logsProject, if set, as with logsProjectID. If
+// ctx's deadline passes after some entries were read, they are returned with
+// truncated set.
+func sessionErrorLogs(ctx context.Context, client *logadmin.Client, sessionPb *dataprocpb.Session, limit int, filter string, rawFilterOnly bool, logsProject string) (_ []map[string]any, truncated bool, _ error) {
+	projectID, location, sessionID, err := ExtractSessionDetails(sessionPb.GetName())
+	if err != nil {
+		return nil, false, err
+	}
+	r := ResolveLogTimeRange(sessionPb, LogTimeRange{})
+	spec := logFilterSpec(SessionLogResourceType, map[string]string{
+		"project_id": projectID,
+		"location":   location,
+		"session_id": sessionID,
+	}, r)
+	if rawFilterOnly {
+		spec = LogFilterSpec{Start: spec.Start, End: spec.End}
+	}
+	spec.Severity = "ERROR"
+	spec.Extra = filter
+
+	it := client.Entries(ctx,
+		logadmin.ProjectIDs([]string{logsProjectID(projectID, logsProject)}),
+		logadmin.Filter(spec.Build()),
+		logadmin.NewestFirst(),
+		logadmin.PageSize(int32(limit)),
+	)
+	logs := []map[string]any{}
+	for len(logs) < limit {
+		entry, err := logiter.Next(ctx, it)
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			if len(logs) > 0 && logiter.DeadlineExceeded(ctx) {
+				return logs, true, nil
+			}
+			return nil, false, fmt.Errorf("failed to list session error logs: %w", err)
+		}
+		log := map[string]any{
+			"logName":   entry.LogName,
+			"timestamp": entry.Timestamp.UTC().Format(time.RFC3339Nano),
+			"severity":  entry.Severity.String(),
+		}
+		if entry.Payload != nil {
+			log["payload"] = entry.Payload
+		}
+		logs = append(logs, log)
+	}
+	return logs, false, nil
+}
+
+// logsProjectID returns the project to list a resource's log entries in:
+// logsProject, for setups that route Dataproc logs to a central project, or
+// the resource's own project if it is empty. Either way, the entries are
+// matched on the resource's labels, which name its own project.
+func logsProjectID(resourceProject, logsProject string) string {
+	if logsProject != "" {
+		return logsProject
+	}
+	return resourceProject
+}
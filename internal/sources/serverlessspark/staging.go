@@ -0,0 +1,112 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serverlessspark
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/dataproc/v2/apiv1/dataprocpb"
+	"cloud.google.com/go/storage"
+	"google.golang.org/api/iterator"
+)
+
+// DefaultStagingObjectsLimit is the default limit on the number of objects
+// returned by ListBatchStagingObjects.
+const DefaultStagingObjectsLimit = 100
+
+// ListBatchStagingObjects gets the batch with the given full resource name and
+// lists the objects in its staging bucket whose names contain the batch's
+// UUID, i.e. the dependencies Dataproc staged for it and the outputs it wrote,
+// such as diagnostics. At most limit objects are returned, and "truncated"
+// reports whether there were more.
+//
+// The bucket is the batch's stagingBucket or, if that is unset, the bucket
+// Dataproc wrote the batch's diagnostics to. If neither is known, the result
+// contains a message explaining why instead.
+func (s *Source) ListBatchStagingObjects(ctx context.Context, name string, limit int) (map[string]any, error) {
+	batchPb, err := s.GetBatchControllerClient().GetBatch(ctx, &dataprocpb.GetBatchRequest{Name: name})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get batch: %w", err)
+	}
+	bucket := stagingBucket(batchPb)
+	if bucket == "" {
+		return map[string]any{
+			"name":    name,
+			"state":   batchPb.GetState().String(),
+			"message": "The batch has no stagingBucket, and Dataproc has not reported a bucket for its outputs yet. Retry once the batch has finished running.",
+		}, nil
+	}
+	if batchPb.GetUuid() == "" {
+		return nil, fmt.Errorf("batch %s has no UUID", name)
+	}
+
+	glob := stagingObjectsGlob(batchPb.GetUuid())
+	it := s.GetStorageClient().Bucket(bucket).Objects(ctx, &storage.Query{MatchGlob: glob})
+	objects := []map[string]any{}
+	truncated := false
+	for {
+		attrs, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list objects in gs://%s: %w", bucket, err)
+		}
+		if len(objects) == limit {
+			truncated = true
+			break
+		}
+		objects = append(objects, map[string]any{
+			"name":    attrs.Name,
+			"uri":     fmt.Sprintf("gs://%s/%s", bucket, attrs.Name),
+			"size":    attrs.Size,
+			"updated": attrs.Updated.UTC().Format(time.RFC3339),
+		})
+	}
+	return map[string]any{
+		"name":          name,
+		"stagingBucket": bucket,
+		"matchGlob":     glob,
+		"objects":       objects,
+		"truncated":     truncated,
+	}, nil
+}
+
+// stagingBucket returns the name of the bucket a batch stages its files in:
+// its configured stagingBucket, which may be given as a bucket name or a gs://
+// URI, or else the bucket of its diagnostics, which Dataproc writes to the
+// staging bucket it chose for the batch. It returns "" if neither is set.
+func stagingBucket(batchPb *dataprocpb.Batch) string {
+	if b := batchPb.GetEnvironmentConfig().GetExecutionConfig().GetStagingBucket(); b != "" {
+		bucket, _, _ := strings.Cut(strings.TrimPrefix(b, "gs://"), "/")
+		return bucket
+	}
+	if uri := batchPb.GetRuntimeInfo().GetDiagnosticOutputUri(); uri != "" {
+		if bucket, _, err := parseGCSURI(uri); err == nil {
+			return bucket
+		}
+	}
+	return ""
+}
+
+// stagingObjectsGlob returns the Cloud Storage glob matching the objects of
+// the batch with the given UUID, which Dataproc puts under paths containing
+// the UUID.
+func stagingObjectsGlob(uuid string) string {
+	return "**" + uuid + "**"
+}
@@ -0,0 +1,64 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serverlessspark
+
+import (
+	"testing"
+
+	"cloud.google.com/go/dataproc/v2/apiv1/dataprocpb"
+)
+
+func TestStagingBucket(t *testing.T) {
+	withStaging := func(bucket string) *dataprocpb.Batch {
+		return &dataprocpb.Batch{EnvironmentConfig: &dataprocpb.EnvironmentConfig{
+			ExecutionConfig: &dataprocpb.ExecutionConfig{StagingBucket: bucket},
+		}}
+	}
+	tcs := []struct {
+		desc  string
+		batch *dataprocpb.Batch
+		want  string
+	}{
+		{
+			desc:  "bucket name",
+			batch: withStaging("my-bucket"),
+			want:  "my-bucket",
+		},
+		{
+			desc:  "bucket URI",
+			batch: withStaging("gs://my-bucket/"),
+			want:  "my-bucket",
+		},
+		{
+			desc: "diagnostics bucket",
+			batch: &dataprocpb.Batch{RuntimeInfo: &dataprocpb.RuntimeInfo{
+				DiagnosticOutputUri: "gs://dataproc-staging-us-central1-123-abc/google-cloud-dataproc-metainfo/1234/diagnostics.tar.gz",
+			}},
+			want: "dataproc-staging-us-central1-123-abc",
+		},
+		{
+			desc:  "unknown",
+			batch: &dataprocpb.Batch{},
+			want:  "",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := stagingBucket(tc.batch); got != tc.want {
+				t.Errorf("stagingBucket() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -18,6 +18,8 @@ import (
 	"fmt"
 	"net/url"
 	"regexp"
+	"slices"
+	"strings"
 	"time"
 
 	"cloud.google.com/go/dataproc/v2/apiv1/dataprocpb"
@@ -26,11 +28,62 @@ import (
 var batchFullNameRegex = regexp.MustCompile(`projects/(?P<project>[^/]+)/locations/(?P<location>[^/]+)/batches/(?P<batch_id>[^/]+)`)
 var sessionTemplateFullNameRegex = regexp.MustCompile(`projects/(?P<project>[^/]+)/locations/(?P<location>[^/]+)/sessionTemplates/(?P<template_id>[^/]+)`)
 
+// BatchConsolePathTemplate is the path of a batch page in the Google Cloud
+// Console. "{location}", "{batch}", and "{tab}" are replaced with the batch's
+// location and ID and the tab of the page to open.
+const BatchConsolePathTemplate = "/dataproc/batches/{location}/{batch}/{tab}"
+
+// The tabs of the batch page in the Google Cloud Console.
+const (
+	BatchConsoleTabSummary       = "summary"
+	BatchConsoleTabMonitoring    = "monitoring"
+	BatchConsoleTabConfiguration = "configuration"
+)
+
+// BatchConsoleTabs are the tabs that BatchConsoleTabURL accepts.
+var BatchConsoleTabs = []string{BatchConsoleTabSummary, BatchConsoleTabMonitoring, BatchConsoleTabConfiguration}
+
+// SessionConsolePathTemplate is the path of the session details page in the
+// Google Cloud Console. "{location}" and "{session}" are replaced with the
+// session's location and ID.
+const SessionConsolePathTemplate = "/dataproc/interactive/{location}/{session}/details"
+
+// The monitored resource types that batches and sessions log under.
+const (
+	BatchLogResourceType   = "cloud_dataproc_batch"
+	SessionLogResourceType = "cloud_dataproc_session"
+)
+
 const (
 	logTimeBufferBefore = 1 * time.Minute
 	logTimeBufferAfter  = 10 * time.Minute
 )
 
+// logFilterSpec returns the filter for the logs of a resource in r, with some
+// buffer before and after it. No buffer is added after the state time of a
+// resource in a final state, since that would only match later logs of other
+// resources.
+func logFilterSpec(resourceType string, labels map[string]string, r LogTimeRange) LogFilterSpec {
+	spec := LogFilterSpec{ResourceType: resourceType, Labels: labels}
+	if !r.Start.IsZero() {
+		spec.Start = r.Start.Add(-1 * logTimeBufferBefore)
+	}
+	if !r.End.IsZero() {
+		spec.End = r.End
+		if !r.EndIsStateTime {
+			spec.End = r.End.Add(logTimeBufferAfter)
+		}
+	}
+	return spec
+}
+
+// consoleURL builds a URL to the given path in the Google Cloud Console.
+func consoleURL(path, projectID string) string {
+	v := url.Values{}
+	v.Add("project", projectID)
+	return "https://console.cloud.google.com" + path + "?" + v.Encode()
+}
+
 // Extract BatchDetails extracts the project ID, location, and batch ID from a fully qualified batch name.
 func ExtractBatchDetails(batchName string) (projectID, location, batchID string, err error) {
 	matches := batchFullNameRegex.FindStringSubmatch(batchName)
@@ -42,29 +95,42 @@ func ExtractBatchDetails(batchName string) (projectID, location, batchID string,
 
 // BatchConsoleURL builds a URL to the Google Cloud Console linking to the batch summary page.
 func BatchConsoleURL(projectID, location, batchID string) string {
-	return fmt.Sprintf("https://console.cloud.google.com/dataproc/batches/%s/%s/summary?project=%s", location, batchID, projectID)
+	return batchConsoleTabURL(projectID, location, batchID, BatchConsoleTabSummary)
+}
+
+// BatchConsoleTabURL is like BatchConsoleURL, but links to the given tab of
+// the batch page, one of BatchConsoleTabs. An empty tab means the summary tab.
+func BatchConsoleTabURL(projectID, location, batchID, tab string) (string, error) {
+	if tab == "" {
+		tab = BatchConsoleTabSummary
+	}
+	if !slices.Contains(BatchConsoleTabs, tab) {
+		return "", fmt.Errorf("must be one of %s: %q", strings.Join(BatchConsoleTabs, ", "), tab)
+	}
+	return batchConsoleTabURL(projectID, location, batchID, tab), nil
+}
+
+func batchConsoleTabURL(projectID, location, batchID, tab string) string {
+	path := strings.NewReplacer("{location}", location, "{batch}", batchID, "{tab}", tab).Replace(BatchConsolePathTemplate)
+	return consoleURL(path, projectID)
 }
 
 // BatchLogsURL builds a URL to the Google Cloud Console showing Cloud Logging for the given batch and time range.
 //
 // The implementation adds some buffer before and after the provided times.
 func BatchLogsURL(projectID, location, batchID string, startTime, endTime time.Time) string {
-	advancedFilterTemplate := `resource.type="cloud_dataproc_batch"
-resource.labels.project_id="%s"
-resource.labels.location="%s"
-resource.labels.batch_id="%s"`
-	advancedFilter := fmt.Sprintf(advancedFilterTemplate, projectID, location, batchID)
-	if !startTime.IsZero() {
-		actualStart := startTime.Add(-1 * logTimeBufferBefore)
-		advancedFilter += fmt.Sprintf("\ntimestamp>=\"%s\"", actualStart.Format(time.RFC3339Nano))
-	}
-	if !endTime.IsZero() {
-		actualEnd := endTime.Add(logTimeBufferAfter)
-		advancedFilter += fmt.Sprintf("\ntimestamp<=\"%s\"", actualEnd.Format(time.RFC3339Nano))
-	}
+	return batchLogsURL(projectID, location, batchID, LogTimeRange{Start: startTime, End: endTime})
+}
+
+func batchLogsURL(projectID, location, batchID string, r LogTimeRange) string {
+	advancedFilter := logFilterSpec(BatchLogResourceType, map[string]string{
+		"project_id": projectID,
+		"location":   location,
+		"batch_id":   batchID,
+	}, r).Build()
 
 	v := url.Values{}
-	v.Add("resource", "cloud_dataproc_batch/batch_id/"+batchID)
+	v.Add("resource", BatchLogResourceType+"/batch_id/"+batchID)
 	v.Add("advancedFilter", advancedFilter)
 	v.Add("project", projectID)
 
@@ -86,9 +152,7 @@ func BatchLogsURLFromProto(batchPb *dataprocpb.Batch) (string, error) {
 	if err != nil {
 		return "", err
 	}
-	createTime := batchPb.GetCreateTime().AsTime()
-	stateTime := batchPb.GetStateTime().AsTime()
-	return BatchLogsURL(projectID, location, batchID, createTime, stateTime), nil
+	return batchLogsURL(projectID, location, batchID, ResolveLogTimeRange(batchPb, LogTimeRange{})), nil
 }
 
 // ExtractSessionTemplateDetails extracts the project ID, location, and session template ID from a fully qualified sessionTemplateName.
@@ -113,26 +177,21 @@ func ExtractSessionDetails(sessionName string) (projectID, location, sessionID s
 
 // SessionConsoleURL builds a URL to the Google Cloud Console linking to the session summary page.
 func SessionConsoleURL(projectID, location, sessionID string) string {
-	return fmt.Sprintf("https://console.cloud.google.com/dataproc/interactive/%s/%s/details?project=%s", location, sessionID, projectID)
+	path := strings.NewReplacer("{location}", location, "{session}", sessionID).Replace(SessionConsolePathTemplate)
+	return consoleURL(path, projectID)
 }
 
 // SessionLogsURL builds a URL to the Google Cloud Console showing Cloud Logging for the given session and time range.
 func SessionLogsURL(projectID, location, sessionID string, startTime, endTime time.Time) string {
-	advancedFilterTemplate := `resource.type="cloud_dataproc_session"
-resource.labels.session_id=%q
-resource.labels.project_id=%q
-resource.labels.location=%q`
-
-	advancedFilter := fmt.Sprintf(advancedFilterTemplate, sessionID, projectID, location)
+	return sessionLogsURL(projectID, location, sessionID, LogTimeRange{Start: startTime, End: endTime})
+}
 
-	if !startTime.IsZero() {
-		actualStart := startTime.Add(-1 * logTimeBufferBefore)
-		advancedFilter += fmt.Sprintf("\ntimestamp>=\"%s\"", actualStart.Format(time.RFC3339Nano))
-	}
-	if !endTime.IsZero() {
-		actualEnd := endTime.Add(logTimeBufferAfter)
-		advancedFilter += fmt.Sprintf("\ntimestamp<=\"%s\"", actualEnd.Format(time.RFC3339Nano))
-	}
+func sessionLogsURL(projectID, location, sessionID string, r LogTimeRange) string {
+	advancedFilter := logFilterSpec(SessionLogResourceType, map[string]string{
+		"project_id": projectID,
+		"location":   location,
+		"session_id": sessionID,
+	}, r).Build()
 
 	v := url.Values{}
 	v.Add("advancedFilter", advancedFilter)
@@ -156,7 +215,5 @@ func SessionLogsURLFromProto(sessionPb *dataprocpb.Session) (string, error) {
 	if err != nil {
 		return "", err
 	}
-	createTime := sessionPb.GetCreateTime().AsTime()
-	stateTime := sessionPb.GetStateTime().AsTime()
-	return SessionLogsURL(projectID, location, sessionID, createTime, stateTime), nil
+	return sessionLogsURL(projectID, location, sessionID, ResolveLogTimeRange(sessionPb, LogTimeRange{})), nil
 }
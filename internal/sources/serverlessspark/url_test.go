@@ -61,15 +61,46 @@ func TestBatchConsoleURL(t *testing.T) {
 	}
 }
 
+func TestBatchConsoleTabURL(t *testing.T) {
+	tcs := []struct {
+		tab     string
+		want    string
+		wantErr bool
+	}{
+		{tab: "", want: "https://console.cloud.google.com/dataproc/batches/us-central1/my-batch/summary?project=my-project"},
+		{tab: "summary", want: "https://console.cloud.google.com/dataproc/batches/us-central1/my-batch/summary?project=my-project"},
+		{tab: "monitoring", want: "https://console.cloud.google.com/dataproc/batches/us-central1/my-batch/monitoring?project=my-project"},
+		{tab: "configuration", want: "https://console.cloud.google.com/dataproc/batches/us-central1/my-batch/configuration?project=my-project"},
+		{tab: "logs", wantErr: true},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.tab, func(t *testing.T) {
+			got, err := serverlessspark.BatchConsoleTabURL("my-project", "us-central1", "my-batch", tc.tab)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("BatchConsoleTabURL() = %v, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("BatchConsoleTabURL() error = %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("BatchConsoleTabURL() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
 func TestBatchLogsURL(t *testing.T) {
 	startTime := time.Date(2025, 10, 1, 5, 0, 0, 0, time.UTC)
 	endTime := time.Date(2025, 10, 1, 6, 0, 0, 0, time.UTC)
 	got := serverlessspark.BatchLogsURL("my-project", "us-central1", "my-batch", startTime, endTime)
 	want := "https://console.cloud.google.com/logs/viewer?advancedFilter=" +
 		"resource.type%3D%22cloud_dataproc_batch%22" +
-		"%0Aresource.labels.project_id%3D%22my-project%22" +
-		"%0Aresource.labels.location%3D%22us-central1%22" +
 		"%0Aresource.labels.batch_id%3D%22my-batch%22" +
+		"%0Aresource.labels.location%3D%22us-central1%22" +
+		"%0Aresource.labels.project_id%3D%22my-project%22" +
 		"%0Atimestamp%3E%3D%222025-10-01T04%3A59%3A00Z%22" + // Minus 1 minute
 		"%0Atimestamp%3C%3D%222025-10-01T06%3A10%3A00Z%22" + // Plus 10 minutes
 		"&project=my-project" +
@@ -107,11 +138,38 @@ func TestBatchLogsURLFromProto(t *testing.T) {
 	}
 	want := "https://console.cloud.google.com/logs/viewer?advancedFilter=" +
 		"resource.type%3D%22cloud_dataproc_batch%22" +
-		"%0Aresource.labels.project_id%3D%22my-project%22" +
+		"%0Aresource.labels.batch_id%3D%22my-batch%22" +
 		"%0Aresource.labels.location%3D%22us-central1%22" +
+		"%0Aresource.labels.project_id%3D%22my-project%22" +
+		"%0Atimestamp%3E%3D%222025-10-01T04%3A59%3A00Z%22" + // Minus 1 minute
+		"%0Atimestamp%3C%3D%222025-10-01T06%3A00%3A00Z%22" + // State time, without buffer
+		"&project=my-project" +
+		"&resource=cloud_dataproc_batch%2Fbatch_id%2Fmy-batch"
+	if got != want {
+		t.Errorf("BatchLogsURLFromProto() = %v, want %v", got, want)
+	}
+}
+
+func TestBatchLogsURLFromProto_Running(t *testing.T) {
+	createTime := time.Date(2025, 10, 1, 5, 0, 0, 0, time.UTC)
+	stateTime := time.Date(2025, 10, 1, 5, 1, 0, 0, time.UTC)
+	batchPb := &dataprocpb.Batch{
+		Name:       "projects/my-project/locations/us-central1/batches/my-batch",
+		State:      dataprocpb.Batch_RUNNING,
+		CreateTime: timestamppb.New(createTime),
+		StateTime:  timestamppb.New(stateTime),
+	}
+	got, err := serverlessspark.BatchLogsURLFromProto(batchPb)
+	if err != nil {
+		t.Fatalf("BatchLogsURLFromProto() error = %v", err)
+	}
+	// A running batch is still writing logs, so the end time is unbounded.
+	want := "https://console.cloud.google.com/logs/viewer?advancedFilter=" +
+		"resource.type%3D%22cloud_dataproc_batch%22" +
 		"%0Aresource.labels.batch_id%3D%22my-batch%22" +
+		"%0Aresource.labels.location%3D%22us-central1%22" +
+		"%0Aresource.labels.project_id%3D%22my-project%22" +
 		"%0Atimestamp%3E%3D%222025-10-01T04%3A59%3A00Z%22" + // Minus 1 minute
-		"%0Atimestamp%3C%3D%222025-10-01T06%3A10%3A00Z%22" + // Plus 10 minutes
 		"&project=my-project" +
 		"&resource=cloud_dataproc_batch%2Fbatch_id%2Fmy-batch"
 	if got != want {
@@ -163,9 +221,9 @@ func TestSessionLogsURL(t *testing.T) {
 	got := serverlessspark.SessionLogsURL("my-project", "us-central1", "my-session", startTime, endTime)
 	want := "https://console.cloud.google.com/logs/viewer?advancedFilter=" +
 		"resource.type%3D%22cloud_dataproc_session%22" +
-		"%0Aresource.labels.session_id%3D%22my-session%22" +
-		"%0Aresource.labels.project_id%3D%22my-project%22" +
 		"%0Aresource.labels.location%3D%22us-central1%22" +
+		"%0Aresource.labels.project_id%3D%22my-project%22" +
+		"%0Aresource.labels.session_id%3D%22my-session%22" +
 		"%0Atimestamp%3E%3D%222025-10-01T04%3A59%3A00Z%22" + // Minus 1 minute
 		"%0Atimestamp%3C%3D%222025-10-01T06%3A10%3A00Z%22" + // Plus 10 minutes
 		"&project=my-project"
@@ -202,11 +260,11 @@ func TestSessionLogsURLFromProto(t *testing.T) {
 	}
 	want := "https://console.cloud.google.com/logs/viewer?advancedFilter=" +
 		"resource.type%3D%22cloud_dataproc_session%22" +
-		"%0Aresource.labels.session_id%3D%22my-session%22" +
-		"%0Aresource.labels.project_id%3D%22my-project%22" +
 		"%0Aresource.labels.location%3D%22us-central1%22" +
+		"%0Aresource.labels.project_id%3D%22my-project%22" +
+		"%0Aresource.labels.session_id%3D%22my-session%22" +
 		"%0Atimestamp%3E%3D%222025-10-01T04%3A59%3A00Z%22" + // Minus 1 minute
-		"%0Atimestamp%3C%3D%222025-10-01T06%3A10%3A00Z%22" + // Plus 10 minutes
+		"%0Atimestamp%3C%3D%222025-10-01T06%3A00%3A00Z%22" + // State time, without buffer
 		"&project=my-project"
 	if got != want {
 		t.Errorf("SessionLogsURLFromProto() = \n%v\nwant \n%v", got, want)
@@ -224,9 +282,9 @@ func TestSessionLogsURL_Escaping(t *testing.T) {
 	want := "https://console.cloud.google.com/logs/viewer?advancedFilter=" +
 		"resource.type%3D%22cloud_dataproc_session%22" +
 		// "my-session\" OR root" encoded
-		"%0Aresource.labels.session_id%3D%22my-session%5C%22+OR+root%22" +
-		"%0Aresource.labels.project_id%3D%22my-project%22" +
 		"%0Aresource.labels.location%3D%22us-central1%22" +
+		"%0Aresource.labels.project_id%3D%22my-project%22" +
+		"%0Aresource.labels.session_id%3D%22my-session%5C%22+OR+root%22" +
 		"%0Atimestamp%3E%3D%222025-10-01T04%3A59%3A00Z%22" +
 		"%0Atimestamp%3C%3D%222025-10-01T06%3A10%3A00Z%22" +
 		"&project=my-project"
@@ -0,0 +1,82 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serverlessspark
+
+import (
+	"maps"
+	"time"
+
+	"cloud.google.com/go/dataproc/v2/apiv1/dataprocpb"
+)
+
+// EstimatedDCUHours returns a rough estimate of the DCU-hours consumed by the
+// given batch, computed from the approximate usage in its runtime info. The
+// second return value is false if the batch has no approximate usage yet, which
+// is the case until the batch completes.
+//
+// Shuffle storage is billed separately per GB-month and is not included.
+func EstimatedDCUHours(batch *dataprocpb.Batch) (float64, bool) {
+	usage := batch.GetRuntimeInfo().GetApproximateUsage()
+	if usage == nil {
+		return 0, false
+	}
+	return float64(usage.GetMilliDcuSeconds()) / 1000 / 3600, true
+}
+
+// BatchDuration returns how long the given batch has run: from its create time
+// to its state time if it has finished, or to now if it is still running. The
+// second return value is false if the needed timestamps are missing or invalid.
+func BatchDuration(batch *dataprocpb.Batch, now time.Time) (time.Duration, bool) {
+	create := batch.GetCreateTime()
+	if create.CheckValid() != nil {
+		return 0, false
+	}
+	end := now
+	if !isRunning(batch) {
+		state := batch.GetStateTime()
+		if state.CheckValid() != nil {
+			return 0, false
+		}
+		end = state.AsTime()
+	}
+	d := end.Sub(create.AsTime())
+	if d < 0 {
+		return 0, false
+	}
+	return d, true
+}
+
+// BatchRuntimeInfo returns the parts of the batch's runtime info that are
+// useful for following a batch: its endpoints, such as the Spark UI, its
+// outputUri, and its approximateUsage. All three keys are always present, so
+// that callers need not check for them; endpoints is empty and
+// approximateUsage is nil until the batch reports them.
+func BatchRuntimeInfo(batch *dataprocpb.Batch) map[string]any {
+	info := batch.GetRuntimeInfo()
+	endpoints := map[string]string{}
+	maps.Copy(endpoints, info.GetEndpoints())
+	var usage map[string]any
+	if u := info.GetApproximateUsage(); u != nil {
+		usage = map[string]any{
+			"milliDcuSeconds":         u.GetMilliDcuSeconds(),
+			"shuffleStorageGbSeconds": u.GetShuffleStorageGbSeconds(),
+		}
+	}
+	return map[string]any{
+		"endpoints":        endpoints,
+		"outputUri":        info.GetOutputUri(),
+		"approximateUsage": usage,
+	}
+}
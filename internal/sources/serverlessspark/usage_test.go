@@ -0,0 +1,173 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serverlessspark_test
+
+import (
+	"testing"
+	"time"
+
+	"cloud.google.com/go/dataproc/v2/apiv1/dataprocpb"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/mcp-toolbox/internal/sources/serverlessspark"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestEstimatedDCUHours(t *testing.T) {
+	tcs := []struct {
+		desc   string
+		batch  *dataprocpb.Batch
+		want   float64
+		wantOk bool
+	}{
+		{
+			desc: "completed batch",
+			batch: &dataprocpb.Batch{
+				RuntimeInfo: &dataprocpb.RuntimeInfo{
+					ApproximateUsage: &dataprocpb.UsageMetrics{
+						MilliDcuSeconds:         7_200_000,
+						ShuffleStorageGbSeconds: 3600,
+					},
+				},
+			},
+			want:   2,
+			wantOk: true,
+		},
+		{
+			desc:  "no usage",
+			batch: &dataprocpb.Batch{RuntimeInfo: &dataprocpb.RuntimeInfo{}},
+		},
+		{
+			desc:  "no runtime info",
+			batch: &dataprocpb.Batch{},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, ok := serverlessspark.EstimatedDCUHours(tc.batch)
+			if got != tc.want || ok != tc.wantOk {
+				t.Errorf("EstimatedDCUHours() = (%v, %v), want (%v, %v)", got, ok, tc.want, tc.wantOk)
+			}
+		})
+	}
+}
+
+func TestBatchRuntimeInfo(t *testing.T) {
+	tcs := []struct {
+		desc  string
+		batch *dataprocpb.Batch
+		want  map[string]any
+	}{
+		{
+			desc: "running batch",
+			batch: &dataprocpb.Batch{
+				RuntimeInfo: &dataprocpb.RuntimeInfo{
+					Endpoints: map[string]string{"Spark History Server": "https://example.com/sparkui"},
+					OutputUri: "gs://my-bucket/output",
+				},
+			},
+			want: map[string]any{
+				"endpoints":        map[string]string{"Spark History Server": "https://example.com/sparkui"},
+				"outputUri":        "gs://my-bucket/output",
+				"approximateUsage": map[string]any(nil),
+			},
+		},
+		{
+			desc: "completed batch",
+			batch: &dataprocpb.Batch{
+				RuntimeInfo: &dataprocpb.RuntimeInfo{
+					OutputUri: "gs://my-bucket/output",
+					ApproximateUsage: &dataprocpb.UsageMetrics{
+						MilliDcuSeconds:         7_200_000,
+						ShuffleStorageGbSeconds: 3600,
+					},
+				},
+			},
+			want: map[string]any{
+				"endpoints": map[string]string{},
+				"outputUri": "gs://my-bucket/output",
+				"approximateUsage": map[string]any{
+					"milliDcuSeconds":         int64(7_200_000),
+					"shuffleStorageGbSeconds": int64(3600),
+				},
+			},
+		},
+		{
+			desc:  "no runtime info",
+			batch: &dataprocpb.Batch{},
+			want: map[string]any{
+				"endpoints":        map[string]string{},
+				"outputUri":        "",
+				"approximateUsage": map[string]any(nil),
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := serverlessspark.BatchRuntimeInfo(tc.batch)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("BatchRuntimeInfo() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestBatchDuration(t *testing.T) {
+	now := time.Date(2025, 10, 1, 7, 0, 0, 0, time.UTC)
+	create := timestamppb.New(time.Date(2025, 10, 1, 5, 0, 0, 0, time.UTC))
+	state := timestamppb.New(time.Date(2025, 10, 1, 6, 30, 0, 0, time.UTC))
+	tcs := []struct {
+		desc   string
+		batch  *dataprocpb.Batch
+		want   time.Duration
+		wantOk bool
+	}{
+		{
+			desc:   "succeeded batch",
+			batch:  &dataprocpb.Batch{State: dataprocpb.Batch_SUCCEEDED, CreateTime: create, StateTime: state},
+			want:   90 * time.Minute,
+			wantOk: true,
+		},
+		{
+			desc:   "running batch",
+			batch:  &dataprocpb.Batch{State: dataprocpb.Batch_RUNNING, CreateTime: create, StateTime: state},
+			want:   2 * time.Hour,
+			wantOk: true,
+		},
+		{
+			desc:  "no create time",
+			batch: &dataprocpb.Batch{State: dataprocpb.Batch_SUCCEEDED, StateTime: state},
+		},
+		{
+			desc:  "no state time",
+			batch: &dataprocpb.Batch{State: dataprocpb.Batch_FAILED, CreateTime: create},
+		},
+		{
+			desc:  "invalid create time",
+			batch: &dataprocpb.Batch{State: dataprocpb.Batch_RUNNING, CreateTime: &timestamppb.Timestamp{Nanos: -1}},
+		},
+		{
+			desc:  "state time before create time",
+			batch: &dataprocpb.Batch{State: dataprocpb.Batch_SUCCEEDED, CreateTime: state, StateTime: create},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, ok := serverlessspark.BatchDuration(tc.batch, now)
+			if got != tc.want || ok != tc.wantOk {
+				t.Errorf("BatchDuration() = (%v, %v), want (%v, %v)", got, ok, tc.want, tc.wantOk)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serverlessspark
+
+import (
+	"context"
+	"slices"
+	"time"
+)
+
+// TerminalBatchStates are the states a batch does not leave.
+var TerminalBatchStates = []string{"SUCCEEDED", "FAILED", "CANCELLED"}
+
+const (
+	waitInitialDelay = 5 * time.Second
+	waitMaxDelay     = 1 * time.Minute
+)
+
+// WaitForBatch polls the batch with the given full resource name, with
+// exponential backoff, until its state is one of targetStates or timeout
+// elapses. It returns the last result of GetBatch, with "timedOut" set to
+// whether the batch did not reach a target state in time. It returns an error
+// if ctx is done first.
+func (s *Source) WaitForBatch(ctx context.Context, name string, targetStates []string, timeout time.Duration) (map[string]any, error) {
+	return waitForBatch(ctx, s.GetBatch, name, targetStates, timeout, waitInitialDelay)
+}
+
+func waitForBatch(ctx context.Context, getBatch func(context.Context, string) (map[string]any, error), name string, targetStates []string, timeout, delay time.Duration) (map[string]any, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		batch, err := getBatch(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		state, _ := batch["state"].(string)
+		if slices.Contains(targetStates, state) {
+			batch["timedOut"] = false
+			return batch, nil
+		}
+
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			batch["timedOut"] = true
+			return batch, nil
+		}
+		timer := time.NewTimer(min(delay, remaining))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+		delay = min(2*delay, waitMaxDelay)
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serverlessspark
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// fakeGetBatch returns a GetBatch function that reports the given states in
+// turn, repeating the last one.
+func fakeGetBatch(states ...string) (func(context.Context, string) (map[string]any, error), *int) {
+	calls := 0
+	return func(ctx context.Context, name string) (map[string]any, error) {
+		state := states[min(calls, len(states)-1)]
+		calls++
+		return map[string]any{"state": state, "stateMessage": "message " + state}, nil
+	}, &calls
+}
+
+func TestWaitForBatch(t *testing.T) {
+	tcs := []struct {
+		desc         string
+		states       []string
+		targetStates []string
+		timeout      time.Duration
+		wantState    string
+		wantTimedOut bool
+		wantCalls    int
+	}{
+		{
+			desc:         "already terminal",
+			states:       []string{"SUCCEEDED"},
+			targetStates: TerminalBatchStates,
+			timeout:      time.Minute,
+			wantState:    "SUCCEEDED",
+			wantCalls:    1,
+		},
+		{
+			desc:         "reaches terminal",
+			states:       []string{"PENDING", "RUNNING", "FAILED"},
+			targetStates: TerminalBatchStates,
+			timeout:      time.Minute,
+			wantState:    "FAILED",
+			wantCalls:    3,
+		},
+		{
+			desc:         "reaches running",
+			states:       []string{"PENDING", "RUNNING", "SUCCEEDED"},
+			targetStates: []string{"RUNNING"},
+			timeout:      time.Minute,
+			wantState:    "RUNNING",
+			wantCalls:    2,
+		},
+		{
+			desc:         "times out",
+			states:       []string{"RUNNING"},
+			targetStates: TerminalBatchStates,
+			timeout:      20 * time.Millisecond,
+			wantState:    "RUNNING",
+			wantTimedOut: true,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			getBatch, calls := fakeGetBatch(tc.states...)
+			got, err := waitForBatch(context.Background(), getBatch, "name", tc.targetStates, tc.timeout, time.Millisecond)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got["state"] != tc.wantState || got["stateMessage"] != "message "+tc.wantState {
+				t.Errorf("got state %v (%v), want %v", got["state"], got["stateMessage"], tc.wantState)
+			}
+			if got["timedOut"] != tc.wantTimedOut {
+				t.Errorf("got timedOut %v, want %v", got["timedOut"], tc.wantTimedOut)
+			}
+			if tc.wantCalls != 0 && *calls != tc.wantCalls {
+				t.Errorf("got %d calls, want %d", *calls, tc.wantCalls)
+			}
+		})
+	}
+}
+
+func TestWaitForBatchCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	getBatch, _ := fakeGetBatch("RUNNING")
+	if _, err := waitForBatch(ctx, getBatch, "name", TerminalBatchStates, time.Minute, time.Minute); !errors.Is(err, context.Canceled) {
+		t.Errorf("got error %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestWaitForBatchError(t *testing.T) {
+	wantErr := errors.New("not found")
+	getBatch := func(context.Context, string) (map[string]any, error) { return nil, wantErr }
+	if _, err := waitForBatch(context.Background(), getBatch, "name", TerminalBatchStates, time.Minute, time.Millisecond); !errors.Is(err, wantErr) {
+		t.Errorf("got error %v, want %v", err, wantErr)
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"os"
 	"strings"
 
 	"cloud.google.com/go/cloudsqlconn"
@@ -126,3 +127,17 @@ func GetIAMAccessToken(ctx context.Context) (string, error) {
 	}
 	return token.AccessToken, nil
 }
+
+// CredentialsFromFile loads credentials from the JSON file at path, e.g. a
+// service account key, for use instead of Application Default Credentials.
+func CredentialsFromFile(ctx context.Context, path string) (*google.Credentials, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read credentials file %q: %w", path, err)
+	}
+	creds, err := google.CredentialsFromJSON(ctx, data, "https://www.googleapis.com/auth/cloud-platform")
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse credentials file %q: %w", path, err)
+	}
+	return creds, nil
+}
@@ -67,9 +67,9 @@ func (cfg Config) Initialize(context.Context) (tools.Tool, error) {
 		return nil, fmt.Errorf("description is required for tool %q", cfg.Name)
 	}
 
-	limitDescription := fmt.Sprintf("Maximum number of log entries to return. Default: %d.", defaultLimit)
+	limitDescription := fmt.Sprintf("Maximum number of log names to return, between 1 and %d. Default: %d.", parameters.MaxAPIPageSize, defaultLimit)
 	params := parameters.Parameters{
-		parameters.NewIntParameter("limit", limitDescription, parameters.WithIntRequired(false)),
+		parameters.NewBoundedIntParameter("limit", 1, parameters.MaxAPIPageSize, defaultLimit, limitDescription),
 	}
 
 	return Tool{
@@ -96,11 +96,8 @@ func (t Tool) Invoke(ctx context.Context, resourceMgr tools.SourceProvider, para
 	}
 
 	limit := defaultLimit
-	paramsMap := params.AsMap()
-	if val, ok := paramsMap["limit"].(int); ok && val > 0 {
+	if val, ok := params.AsMap()["limit"].(int); ok {
 		limit = val
-	} else if ok && val < 0 {
-		return nil, util.NewAgentError("limit must be greater than or equal to 1", nil)
 	}
 
 	tokenString := ""
@@ -15,6 +15,7 @@ package cloudloggingadminlistlognames_test
 
 import (
 	"context"
+	"fmt"
 	"strings"
 	"testing"
 
@@ -23,6 +24,7 @@ import (
 	"github.com/googleapis/mcp-toolbox/internal/testutils"
 	"github.com/googleapis/mcp-toolbox/internal/tools"
 	"github.com/googleapis/mcp-toolbox/internal/tools/cloudloggingadmin/cloudloggingadminlistlognames"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
 )
 
 func TestParseFromYaml(t *testing.T) {
@@ -113,3 +115,37 @@ func TestFailParseFromYaml(t *testing.T) {
 		})
 	}
 }
+
+func TestLimitBounds(t *testing.T) {
+	cfg := cloudloggingadminlistlognames.Config{
+		ConfigBase: tools.ConfigBase{
+			Name:        "example_tool",
+			Description: "list log names",
+		},
+		Type:   "cloud-logging-admin-list-log-names",
+		Source: "my-logging-admin-source",
+	}
+	tool, err := cfg.Initialize(context.Background())
+	if err != nil {
+		t.Fatalf("failed to initialize tool: %v", err)
+	}
+	toolParams, err := tool.GetParameters(nil)
+	if err != nil {
+		t.Fatalf("failed to get parameters: %v", err)
+	}
+
+	got, err := parameters.ParseParams(toolParams, map[string]any{}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(map[string]any{"limit": 200}, got.AsMap()); diff != "" {
+		t.Errorf("incorrect default (-want +got):\n%s", diff)
+	}
+	for _, limit := range []int{0, 1001} {
+		_, err := parameters.ParseParams(toolParams, map[string]any{"limit": limit}, nil)
+		want := fmt.Sprintf("limit must be between 1 and 1000: %d", limit)
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("ParseParams(limit=%d) error = %v, want %q", limit, err, want)
+		}
+	}
+}
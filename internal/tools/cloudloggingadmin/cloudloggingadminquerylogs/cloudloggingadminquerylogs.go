@@ -15,25 +15,69 @@ package cloudloggingadminquerylogs
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"maps"
 	"net/http"
+	"regexp"
+	"strings"
 	"time"
 
 	"github.com/goccy/go-yaml"
+	"github.com/google/uuid"
 	"github.com/googleapis/mcp-toolbox/internal/embeddingmodels"
 	cla "github.com/googleapis/mcp-toolbox/internal/sources/cloudloggingadmin"
 	"github.com/googleapis/mcp-toolbox/internal/tools"
 	"github.com/googleapis/mcp-toolbox/internal/util"
+	"github.com/googleapis/mcp-toolbox/internal/util/logiter"
 	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/protobuf/types/known/structpb"
 )
 
 const (
 	resourceType string = "cloud-logging-admin-query-logs"
 
-	defaultLimit               int = 200
+	defaultLimit int = 200
+	// maxLimit bounds limit, so that a single call cannot return an
+	// unbounded number of entries.
+	maxLimit                   int = 10000
 	defaultStartTimeOffsetDays int = 30
+
+	defaultSignedURLExpiry = time.Hour
+	// maxSignedURLExpiry is the longest that V4 signed URLs can be valid.
+	maxSignedURLExpiry = 7 * 24 * time.Hour
+)
+
+// Values of the outputFormat parameter.
+const (
+	outputFormatJSON   = "json"
+	outputFormatNDJSON = "ndjson"
 )
 
+// severities are the log entry severities, in increasing order.
+var severities = []any{"DEFAULT", "DEBUG", "INFO", "NOTICE", "WARNING", "ERROR", "CRITICAL", "ALERT", "EMERGENCY"}
+
+var traceIDRegex = regexp.MustCompile(`^[0-9a-fA-F]{32}$`)
+
+// applicationIDRegex matches YARN application IDs, e.g.
+// application_1700000000000_0001, and Spark application IDs, e.g.
+// app-20251209100000-0000.
+var applicationIDRegex = regexp.MustCompile(`^(application_[0-9]+_[0-9]+|app-[0-9]+-[0-9]+)$`)
+
+// stackFrameRegex matches the lines of a Java or Scala stack trace that are
+// collapsed by collapseStackTraces, e.g. "\tat org.example.Main.run(Main.java:42)"
+// and "\t... 12 more". "Caused by:" lines are kept, since they name the cause.
+var stackFrameRegex = regexp.MustCompile(`^\s+at \S|^\s*\.\.\. \d+ more\s*$`)
+
+// projectIDRegex matches project IDs, including domain-scoped project IDs
+// such as example.com:my-project.
+var projectIDRegex = regexp.MustCompile(`^([a-z][a-z0-9.-]*[a-z0-9]:)?[a-z][a-z0-9-]{4,28}[a-z0-9]$`)
+
+// bucketNameRegex matches Cloud Storage bucket names.
+var bucketNameRegex = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]{1,220}[a-z0-9]$`)
+
 func init() {
 	if !tools.Register(resourceType, newConfig) {
 		panic(fmt.Sprintf("tool type %q already registered", resourceType))
@@ -50,7 +94,11 @@ func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.T
 
 type compatibleSource interface {
 	UseClientAuthorization() bool
+	GetDefaultLogLimit() int
 	QueryLogs(ctx context.Context, params cla.QueryLogsParams, accessToken string) ([]map[string]any, error)
+	BuildFilter(params cla.QueryLogsParams) string
+	ExportLogs(ctx context.Context, params cla.QueryLogsParams, accessToken, bucket, object string) (int, error)
+	SignExportURL(accessToken, bucket, object string, expires time.Time) (string, error)
 }
 
 type Config struct {
@@ -73,16 +121,32 @@ func (cfg Config) Initialize(context.Context) (tools.Tool, error) {
 	}
 
 	startTimeDescription := fmt.Sprintf("Start time in RFC3339 format (e.g., 2025-12-09T00:00:00Z). Defaults to %d days ago.", defaultStartTimeOffsetDays)
-	limitDescription := fmt.Sprintf("Maximum number of log entries to return. Default: %d.", defaultLimit)
+	limitDescription := fmt.Sprintf("Maximum number of log entries to return, at most %d. Defaults to the source's defaultLogLimit, or %d if unset, which 0 also selects.", maxLimit, defaultLimit)
 	params := parameters.Parameters{
 		parameters.NewStringParameter(
 			"filter",
 			"Cloud Logging filter query. Common fields: resource.type, resource.labels.*, logName, severity, textPayload, jsonPayload.*, protoPayload.*, labels.*, httpRequest.*. Operators: =, !=, <, <=, >, >=, :, =~, AND, OR, NOT.", parameters.WithStringRequired(false)),
 		parameters.NewBooleanParameter("newestFirst", "Set to true for newest logs first. Defaults to oldest first.", parameters.WithBooleanRequired(false)),
-		parameters.NewStringParameter("startTime", startTimeDescription, parameters.WithStringRequired(false)),
-		parameters.NewStringParameter("endTime", "End time in RFC3339 format (e.g., 2025-12-09T23:59:59Z). Defaults to now.", parameters.WithStringRequired(false)),
-		parameters.NewBooleanParameter("verbose", "Include additional fields (insertId, trace, spanId, httpRequest, labels, operation, sourceLocation). Defaults to false.", parameters.WithBooleanRequired(false)),
-		parameters.NewIntParameter("limit", limitDescription, parameters.WithIntRequired(false)),
+		parameters.NewTimestampParameter("startTime", startTimeDescription, parameters.WithTimestampRequired(false)),
+		parameters.NewTimestampParameter("endTime", "End time in RFC3339 format (e.g., 2025-12-09T23:59:59Z). Defaults to now.", parameters.WithTimestampRequired(false)),
+		parameters.NewStringParameter("last", "Relative time window ending now, as a duration (e.g., 30m, 2h). Cannot be combined with startTime or endTime.", parameters.WithStringRequired(false)),
+		parameters.NewStringParameter("traceId", "Only return entries of the trace with this ID, a 32-character hexadecimal string (e.g., 4bf92f3577b34da6a3ce929d0e0e4736).", parameters.WithStringRequired(false)),
+		parameters.NewStringParameter("applicationId", "Only return entries of the Spark application with this ID, e.g. to isolate one application's logs within a Dataproc batch (e.g., application_1700000000000_0001 or app-20251209100000-0000).", parameters.WithStringRequired(false)),
+		parameters.NewStringParameter("logName", "Only return entries of the log with this name, e.g. dataproc.googleapis.com/yarn for YARN logs or dataproc.googleapis.com/spark for Spark driver logs. The name is URL-escaped and qualified with the project.", parameters.WithStringRequired(false)),
+		parameters.NewStringParameter("minSeverity", "Only return entries of at least this severity, e.g. WARNING for warnings and errors. Cannot be combined with severity.", parameters.WithStringRequired(false), parameters.WithStringAllowedValues(severities)),
+		parameters.NewStringParameter("severity", "Only return entries of exactly this severity, e.g. ERROR to see errors but not CRITICAL entries. Cannot be combined with minSeverity.", parameters.WithStringRequired(false), parameters.WithStringAllowedValues(severities)),
+		parameters.NewStringParameter("project", "The ID of the project to query logs in. Defaults to the source's project.", parameters.WithStringRequired(false)),
+		parameters.NewBooleanParameter("verbose", "Include additional fields (insertId, trace, spanId, httpRequest, labels, operation, sourceLocation) and group entries by trace. Defaults to false.", parameters.WithBooleanRequired(false)),
+		parameters.NewBooleanParameter("includeLogUrl", "Set to true to add a logUrl to each entry linking to the Logs Explorer in the Google Cloud Console, anchored at the entry's timestamp. Defaults to false.", parameters.WithBooleanRequired(false)),
+		parameters.NewIntParameter("limit", limitDescription, parameters.WithIntRequired(false), parameters.WithIntRange(0, maxLimit)),
+		parameters.NewStringParameter("outputFormat", "Format of the returned entries: \"json\" for a JSON array, or \"ndjson\" for newline-delimited JSON text with one entry per line. Defaults to json.", parameters.WithStringDefault(outputFormatJSON), parameters.WithStringAllowedValues([]any{outputFormatJSON, outputFormatNDJSON})),
+		parameters.NewBooleanParameter("collapseStackTraces", "Set to true to collapse stack traces logged one frame per entry, as Java and Scala traces often are, into a single entry for the top frame with a stackLines count of the frames collapsed. Collapsing happens after limit is applied. Defaults to false.", parameters.WithBooleanRequired(false)),
+		parameters.NewBooleanParameter("summarize", "Set to true to return a summary of the matching entries (entryCount, errorCount, warningCount, firstError, lastError, timeRange) instead of the entries themselves. Only the first limit entries are summarized; truncated is true if more entries matched, in which case raise limit or narrow the query for complete counts. Defaults to false.", parameters.WithBooleanRequired(false)),
+		parameters.NewBooleanParameter("groupByExecutor", "Set to true to return a map from executor ID (e.g., driver or an executor number) to that executor's entries instead of a single list, e.g. to isolate the output of one failing executor of a Dataproc batch. Entries without an executor label are grouped under \"unknown\". Cannot be combined with summarize or outputFormat ndjson. Defaults to false.", parameters.WithBooleanRequired(false)),
+		parameters.NewBooleanParameter("includeFilter", "Set to true to return the full Cloud Logging filter that was run, with the clauses added for the other parameters, as \"filter\" alongside the entries (as \"entries\") or in the summary or export result, e.g. to see why entries did or did not match. Cannot be combined with outputFormat ndjson. Defaults to false.", parameters.WithBooleanRequired(false)),
+		parameters.NewStringParameter("exportToGcs", "Cloud Storage location to export the matching entries to as newline-delimited JSON, as gs://bucket or gs://bucket/prefix, e.g. to keep more entries than can be returned. The entries are written to a new object under the prefix, and its uri and the entryCount exported are returned instead of the entries. Every matching entry is exported unless a nonzero limit is set. Cannot be combined with summarize, groupByExecutor, collapseStackTraces, or outputFormat ndjson.", parameters.WithStringRequired(false)),
+		parameters.NewBooleanParameter("signedUrl", "Set to true to also return a signedUrl with which the exported object can be downloaded without Google Cloud credentials until signedUrlExpireTime. Requires exportToGcs. If the credentials cannot sign URLs, only the uri is returned, with a message explaining why. Defaults to false.", parameters.WithBooleanRequired(false)),
+		parameters.NewStringParameter("signedUrlExpiry", fmt.Sprintf("How long the signed URL is valid, as a duration (e.g., 30m, 24h), at most %s. Defaults to %s. Requires signedUrl.", maxSignedURLExpiry, defaultSignedURLExpiry), parameters.WithStringRequired(false)),
 	}
 
 	return Tool{
@@ -110,18 +174,91 @@ func (t Tool) Invoke(ctx context.Context, resourceMgr tools.SourceProvider, para
 
 	// Parse parameters
 	limit := defaultLimit
+	if l := source.GetDefaultLogLimit(); l > 0 {
+		limit = l
+	}
 	paramsMap := params.AsMap()
 	newestFirst, _ := paramsMap["newestFirst"].(bool)
 
-	// Check and set limit
+	// The source's default is only known here, so limit has no parameter
+	// default; its bounds are checked when it is parsed. 0 selects the
+	// default, as it did before limit was bounded.
 	if val, ok := paramsMap["limit"].(int); ok && val > 0 {
 		limit = val
-	} else if ok && val < 0 {
-		return nil, util.NewAgentError("limit must be greater than or equal to 1", nil)
 	}
 
 	// Check for verbosity of output
 	verbose, _ := paramsMap["verbose"].(bool)
+	includeLogURL, _ := paramsMap["includeLogUrl"].(bool)
+
+	outputFormat, _ := paramsMap["outputFormat"].(string)
+	if outputFormat != "" && outputFormat != outputFormatJSON && outputFormat != outputFormatNDJSON {
+		return nil, util.NewAgentError(fmt.Sprintf("outputFormat must be %q or %q: %q", outputFormatJSON, outputFormatNDJSON, outputFormat), nil)
+	}
+
+	collapse, _ := paramsMap["collapseStackTraces"].(bool)
+
+	summarize, _ := paramsMap["summarize"].(bool)
+	if summarize && outputFormat == outputFormatNDJSON {
+		return nil, util.NewAgentError("summarize cannot be combined with outputFormat ndjson", nil)
+	}
+
+	groupByExecutor, _ := paramsMap["groupByExecutor"].(bool)
+	if groupByExecutor && summarize {
+		return nil, util.NewAgentError("groupByExecutor cannot be combined with summarize", nil)
+	}
+	if groupByExecutor && outputFormat == outputFormatNDJSON {
+		return nil, util.NewAgentError("groupByExecutor cannot be combined with outputFormat ndjson", nil)
+	}
+
+	includeFilter, _ := paramsMap["includeFilter"].(bool)
+	if includeFilter && outputFormat == outputFormatNDJSON {
+		return nil, util.NewAgentError("includeFilter cannot be combined with outputFormat ndjson", nil)
+	}
+
+	exportToGcs, _ := paramsMap["exportToGcs"].(string)
+	var exportBucket, exportPrefix string
+	if exportToGcs != "" {
+		for _, c := range []struct {
+			name string
+			set  bool
+		}{
+			{"summarize", summarize},
+			{"groupByExecutor", groupByExecutor},
+			{"collapseStackTraces", collapse},
+			{"outputFormat ndjson", outputFormat == outputFormatNDJSON},
+		} {
+			if c.set {
+				return nil, util.NewAgentError(fmt.Sprintf("exportToGcs cannot be combined with %s", c.name), nil)
+			}
+		}
+		exportBucket, exportPrefix, err = parseGCSPrefix(exportToGcs)
+		if err != nil {
+			return nil, util.NewAgentError(err.Error(), nil)
+		}
+		// Exports are not bounded by the default limit.
+		if val, _ := paramsMap["limit"].(int); val == 0 {
+			limit = 0
+		}
+	}
+
+	signedURL, _ := paramsMap["signedUrl"].(bool)
+	if signedURL && exportToGcs == "" {
+		return nil, util.NewAgentError("signedUrl requires exportToGcs", nil)
+	}
+	signedURLExpiry := defaultSignedURLExpiry
+	if s, _ := paramsMap["signedUrlExpiry"].(string); s != "" {
+		if !signedURL {
+			return nil, util.NewAgentError("signedUrlExpiry requires signedUrl", nil)
+		}
+		signedURLExpiry, err = time.ParseDuration(s)
+		if err != nil {
+			return nil, util.NewAgentError(fmt.Sprintf("signedUrlExpiry must be a duration like 24h: %q", s), err)
+		}
+		if signedURLExpiry <= 0 || signedURLExpiry > maxSignedURLExpiry {
+			return nil, util.NewAgentError(fmt.Sprintf("signedUrlExpiry must be positive and at most %s: %s", maxSignedURLExpiry, signedURLExpiry), nil)
+		}
+	}
 
 	// Build filter
 	var filter string
@@ -129,27 +266,69 @@ func (t Tool) Invoke(ctx context.Context, resourceMgr tools.SourceProvider, para
 		if len(f) == 0 {
 			return nil, util.NewAgentError("filter cannot be empty if provided", nil)
 		}
+		if err := util.ValidateFilterSyntax(f); err != nil {
+			return nil, util.NewAgentError(err.Error(), nil)
+		}
 		filter = f
 	}
 
-	// Parse start time
-	var startTime string
-	if val, ok := paramsMap["startTime"].(string); ok && val != "" {
-		if _, err := time.Parse(time.RFC3339, val); err != nil {
-			return nil, util.NewAgentError(fmt.Sprintf("startTime must be in RFC3339 format (e.g., 2025-12-09T00:00:00Z): %v", err), err)
+	traceID, _ := paramsMap["traceId"].(string)
+	if traceID != "" && !traceIDRegex.MatchString(traceID) {
+		return nil, util.NewAgentError(fmt.Sprintf("traceId must be a 32-character hexadecimal string: %q", traceID), nil)
+	}
+
+	applicationID, _ := paramsMap["applicationId"].(string)
+	if applicationID != "" && !applicationIDRegex.MatchString(applicationID) {
+		return nil, util.NewAgentError(fmt.Sprintf("applicationId must be a YARN or Spark application ID like application_1700000000000_0001: %q", applicationID), nil)
+	}
+
+	logName, ok := paramsMap["logName"].(string)
+	if ok && strings.TrimSpace(logName) == "" {
+		return nil, util.NewAgentError("logName cannot be empty if provided", nil)
+	}
+
+	minSeverity, _ := paramsMap["minSeverity"].(string)
+	severity, _ := paramsMap["severity"].(string)
+	if minSeverity != "" && severity != "" {
+		return nil, util.NewAgentError("minSeverity and severity are mutually exclusive", nil)
+	}
+
+	project, _ := paramsMap["project"].(string)
+	if project != "" && !projectIDRegex.MatchString(project) {
+		return nil, util.NewAgentError(fmt.Sprintf("project must be a project ID like my-project: %q", project), nil)
+	}
+
+	// Resolve relative time window
+	start, hasStart := paramsMap["startTime"].(time.Time)
+	end, hasEnd := paramsMap["endTime"].(time.Time)
+	if last, ok := paramsMap["last"].(string); ok && last != "" {
+		if hasStart || hasEnd {
+			return nil, util.NewAgentError("last cannot be combined with startTime or endTime", nil)
+		}
+		d, err := time.ParseDuration(last)
+		if err != nil {
+			return nil, util.NewAgentError(fmt.Sprintf("last must be a duration (e.g., 30m, 2h): %v", err), err)
+		}
+		if d <= 0 {
+			return nil, util.NewAgentError(fmt.Sprintf("last must be positive: %s", last), nil)
 		}
-		startTime = val
-	} else {
-		startTime = time.Now().AddDate(0, 0, -defaultStartTimeOffsetDays).Format(time.RFC3339)
+		end = time.Now().Truncate(time.Second)
+		start = end.Add(-d)
+		hasStart, hasEnd = true, true
 	}
 
-	// Parse end time
+	if !hasStart {
+		start = time.Now().AddDate(0, 0, -defaultStartTimeOffsetDays).Truncate(time.Second)
+	}
+	startTime := start.Format(time.RFC3339Nano)
+
 	var endTime string
-	if val, ok := paramsMap["endTime"].(string); ok && val != "" {
-		if _, err := time.Parse(time.RFC3339, val); err != nil {
-			return nil, util.NewAgentError(fmt.Sprintf("endTime must be in RFC3339 format (e.g., 2025-12-09T23:59:59Z): %v", err), err)
+	if hasEnd {
+		// A reversed range silently matches no entries, so reject it.
+		if end.Before(start) {
+			return nil, util.NewAgentError(fmt.Sprintf("endTime %s must not be before startTime %s", end.Format(time.RFC3339Nano), startTime), nil)
 		}
-		endTime = val
+		endTime = end.Format(time.RFC3339Nano)
 	}
 
 	tokenString := ""
@@ -161,21 +340,326 @@ func (t Tool) Invoke(ctx context.Context, resourceMgr tools.SourceProvider, para
 	}
 
 	queryParams := cla.QueryLogsParams{
-		Filter:      filter,
-		NewestFirst: newestFirst,
-		StartTime:   startTime,
-		EndTime:     endTime,
-		Verbose:     verbose,
-		Limit:       limit,
+		Filter:        filter,
+		NewestFirst:   newestFirst,
+		StartTime:     startTime,
+		EndTime:       endTime,
+		Verbose:       verbose,
+		Limit:         limit,
+		TraceID:       traceID,
+		ApplicationID: applicationID,
+		LogName:       logName,
+		MinSeverity:   minSeverity,
+		Severity:      severity,
+		Project:       project,
+		IncludeLogURL: includeLogURL,
+		IncludeLabels: groupByExecutor,
+	}
+
+	if exportToGcs != "" {
+		object := exportObjectName(exportPrefix, time.Now())
+		uri := fmt.Sprintf("gs://%s/%s", exportBucket, object)
+		count, err := source.ExportLogs(ctx, queryParams, tokenString, exportBucket, object)
+		if err != nil {
+			return nil, exportError(uri, err)
+		}
+		result := map[string]any{"uri": uri, "entryCount": count}
+		if signedURL {
+			expires := time.Now().Add(signedURLExpiry)
+			if u, err := source.SignExportURL(tokenString, exportBucket, object, expires); err != nil {
+				result["message"] = fmt.Sprintf("The export succeeded, but no signed URL could be made for it, so only its uri is returned: %v. Signing requires service account credentials, with a private key or with iam.serviceAccounts.signBlob on the service account (e.g. roles/iam.serviceAccountTokenCreator).", err)
+			} else {
+				result["signedUrl"] = u
+				result["signedUrlExpireTime"] = expires.UTC().Format(time.RFC3339)
+			}
+		}
+		if includeFilter {
+			result["filter"] = source.BuildFilter(queryParams)
+		}
+		return result, nil
 	}
 
+	// Query one more entry than a summary counts, so that it can say
+	// whether more entries matched.
+	if summarize {
+		queryParams.Limit = limit + 1
+	}
 	resp, err := source.QueryLogs(ctx, queryParams, tokenString)
 	if err != nil {
 		return nil, util.ProcessGcpError(err)
 	}
+	// QueryLogs returns the entries read so far if the deadline passed.
+	timedOut := logiter.DeadlineExceeded(ctx)
+	truncated := timedOut
+	if summarize && len(resp) > limit {
+		resp = resp[:limit]
+		truncated = true
+	}
+	if groupByExecutor {
+		groups := groupEntriesByExecutor(resp, verbose)
+		if collapse {
+			// Collapse within each executor, whose frames are no longer
+			// interleaved with those of other executors.
+			for id, entries := range groups {
+				groups[id] = collapseStackTraces(entries, newestFirst)
+			}
+		}
+		if includeFilter {
+			return withDeadlineExceeded(map[string]any{"filter": source.BuildFilter(queryParams), "entries": groups}, timedOut), nil
+		}
+		return groups, nil
+	}
+	if collapse {
+		resp = collapseStackTraces(resp, newestFirst)
+	}
+	if summarize {
+		summary := summarizeEntries(resp)
+		summary.Truncated = truncated
+		summary.DeadlineExceeded = timedOut
+		if includeFilter {
+			summary.Filter = source.BuildFilter(queryParams)
+		}
+		return summary, nil
+	}
+	if outputFormat == outputFormatNDJSON {
+		ndjson, err := toNDJSON(resp)
+		if err != nil {
+			return nil, util.NewClientServerError("failed to format log entries", http.StatusInternalServerError, err)
+		}
+		return ndjson, nil
+	}
+	if includeFilter {
+		if resp == nil {
+			resp = []map[string]any{}
+		}
+		return withDeadlineExceeded(map[string]any{"filter": source.BuildFilter(queryParams), "entries": resp}, timedOut), nil
+	}
 	return resp, nil
 }
 
+// withDeadlineExceeded sets "deadlineExceeded" in result if timedOut, i.e. if
+// the entries in it are those read before the deadline passed.
+func withDeadlineExceeded(result map[string]any, timedOut bool) map[string]any {
+	if timedOut {
+		result["deadlineExceeded"] = true
+	}
+	return result
+}
+
+// logSummary is the result of a query with summarize set.
+type logSummary struct {
+	EntryCount   int           `json:"entryCount"`
+	ErrorCount   int           `json:"errorCount"`
+	WarningCount int           `json:"warningCount"`
+	FirstError   any           `json:"firstError,omitempty"`
+	LastError    any           `json:"lastError,omitempty"`
+	TimeRange    *logTimeRange `json:"timeRange,omitempty"`
+	// Truncated is set if more entries matched than the limit, so that
+	// only the first limit entries were summarized.
+	Truncated bool `json:"truncated"`
+	// DeadlineExceeded is set if the deadline passed while the entries
+	// were read, so that only those read before it were summarized.
+	DeadlineExceeded bool `json:"deadlineExceeded,omitempty"`
+	// Filter is the filter that was run, if includeFilter is set.
+	Filter string `json:"filter,omitempty"`
+}
+
+// logTimeRange is the range of timestamps of the summarized entries.
+type logTimeRange struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+// errorSeverities are the severities counted as errors in a summary.
+var errorSeverities = map[string]bool{
+	"Error":     true,
+	"Critical":  true,
+	"Alert":     true,
+	"Emergency": true,
+}
+
+// summarizeEntries counts the errors and warnings among entries and reports
+// the earliest and latest error messages and the time range of the entries.
+// Entries may be in either order.
+func summarizeEntries(entries []map[string]any) logSummary {
+	summary := logSummary{EntryCount: len(entries)}
+	var start, end, firstErr, lastErr time.Time
+	for _, entry := range entries {
+		ts, err := time.Parse(time.RFC3339, fmt.Sprint(entry["timestamp"]))
+		if err != nil {
+			continue
+		}
+		if start.IsZero() || ts.Before(start) {
+			start = ts
+		}
+		if end.IsZero() || ts.After(end) {
+			end = ts
+		}
+
+		severity, _ := entry["severity"].(string)
+		switch {
+		case errorSeverities[severity]:
+			summary.ErrorCount++
+			message := entryMessage(entry)
+			if firstErr.IsZero() || ts.Before(firstErr) {
+				firstErr = ts
+				summary.FirstError = message
+			}
+			if lastErr.IsZero() || !ts.Before(lastErr) {
+				lastErr = ts
+				summary.LastError = message
+			}
+		case severity == "Warning":
+			summary.WarningCount++
+		}
+	}
+	if !start.IsZero() {
+		summary.TimeRange = &logTimeRange{Start: start.Format(time.RFC3339), End: end.Format(time.RFC3339)}
+	}
+	return summary
+}
+
+// entryMessage returns the message of a log entry: the "message" field of a
+// structured payload, or otherwise the payload itself.
+func entryMessage(entry map[string]any) any {
+	payload := entry["payload"]
+	switch p := payload.(type) {
+	case map[string]any:
+		if msg, ok := p["message"]; ok {
+			return msg
+		}
+	case *structpb.Struct:
+		if msg, ok := p.GetFields()["message"]; ok {
+			return msg.AsInterface()
+		}
+	}
+	return payload
+}
+
+// executorLabels are the labels that identify the executor, or the driver, that
+// wrote a Dataproc log entry, in order of preference.
+var executorLabels = []string{"dataproc.googleapis.com/process_id", "dataproc.googleapis.com/container_id"}
+
+// unknownExecutor is the group of the entries without an executor label.
+const unknownExecutor = "unknown"
+
+// groupEntriesByExecutor groups entries by the executor that wrote them,
+// keeping their order within each group. The labels, which were only fetched
+// for grouping, are removed from the entries unless keepLabels is set.
+func groupEntriesByExecutor(entries []map[string]any, keepLabels bool) map[string][]map[string]any {
+	groups := map[string][]map[string]any{}
+	for _, entry := range entries {
+		id := unknownExecutor
+		labels, _ := entry["labels"].(map[string]string)
+		for _, label := range executorLabels {
+			if v := labels[label]; v != "" {
+				id = v
+				break
+			}
+		}
+		if !keepLabels {
+			entry = maps.Clone(entry)
+			delete(entry, "labels")
+		}
+		groups[id] = append(groups[id], entry)
+	}
+	return groups
+}
+
+// collapseStackTraces replaces each run of consecutive stack frame entries of
+// the same log with a copy of its top frame, i.e. its oldest entry, with a
+// stackLines count of the entries in the run. Other entries, including the
+// exception and any "Caused by:" lines, are kept as they are.
+func collapseStackTraces(entries []map[string]any, newestFirst bool) []map[string]any {
+	collapsed := make([]map[string]any, 0, len(entries))
+	for i := 0; i < len(entries); {
+		if !isStackFrame(entries[i]) {
+			collapsed = append(collapsed, entries[i])
+			i++
+			continue
+		}
+		j := i + 1
+		for j < len(entries) && isStackFrame(entries[j]) && entries[j]["logName"] == entries[i]["logName"] {
+			j++
+		}
+		top := entries[i]
+		if newestFirst {
+			top = entries[j-1]
+		}
+		entry := maps.Clone(top)
+		entry["stackLines"] = j - i
+		collapsed = append(collapsed, entry)
+		i = j
+	}
+	return collapsed
+}
+
+// isStackFrame reports whether the entry's text payload is a stack frame.
+func isStackFrame(entry map[string]any) bool {
+	text, ok := entry["payload"].(string)
+	return ok && stackFrameRegex.MatchString(text)
+}
+
+// parseGCSPrefix splits a gs://bucket or gs://bucket/prefix URI into its
+// bucket and prefix.
+func parseGCSPrefix(uri string) (bucket, prefix string, err error) {
+	rest, ok := strings.CutPrefix(uri, "gs://")
+	if !ok {
+		return "", "", fmt.Errorf("exportToGcs must be a Cloud Storage URI like gs://bucket/prefix: %q", uri)
+	}
+	bucket, prefix, _ = strings.Cut(rest, "/")
+	if !bucketNameRegex.MatchString(bucket) {
+		return "", "", fmt.Errorf("exportToGcs must name a valid bucket, of 3-222 lowercase letters, numbers, dots, hyphens, and underscores: %q", uri)
+	}
+	if strings.Contains(prefix, "//") || strings.ContainsAny(prefix, "\r\n") {
+		return "", "", fmt.Errorf("exportToGcs prefix must not contain empty path segments or newlines: %q", uri)
+	}
+	return bucket, prefix, nil
+}
+
+// exportObjectName returns the name of a new object under prefix to export
+// entries to at time now, e.g.
+// prefix/logs-20251209T100000Z-6ba7b810-9dad-11d1-80b4-00c04fd430c8.ndjson.
+// The UUID suffix keeps concurrent exports from overwriting each other.
+func exportObjectName(prefix string, now time.Time) string {
+	if prefix != "" && !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
+	return fmt.Sprintf("%slogs-%s-%s.ndjson", prefix, now.UTC().Format("20060102T150405Z"), uuid.NewString())
+}
+
+// exportError converts an error exporting entries to uri into an error for
+// the caller. Cloud Storage permission and missing bucket errors are agent
+// errors, since the agent can export to another location instead.
+func exportError(uri string, err error) util.ToolboxError {
+	var gErr *googleapi.Error
+	if errors.As(err, &gErr) {
+		switch gErr.Code {
+		case http.StatusForbidden:
+			return util.NewAgentError(fmt.Sprintf("permission denied writing %s: the credentials need storage.objects.create on the bucket (e.g. roles/storage.objectCreator); export to a bucket they can write to", uri), err)
+		case http.StatusNotFound:
+			return util.NewAgentError(fmt.Sprintf("cannot write %s: the bucket does not exist", uri), err)
+		}
+	}
+	return util.ProcessGcpError(err)
+}
+
+// toNDJSON serializes each entry as a single line of JSON, joined by newlines.
+func toNDJSON(entries []map[string]any) (string, error) {
+	var sb strings.Builder
+	for i, entry := range entries {
+		b, err := json.Marshal(entry)
+		if err != nil {
+			return "", err
+		}
+		if i > 0 {
+			sb.WriteByte('\n')
+		}
+		sb.Write(b)
+	}
+	return sb.String(), nil
+}
+
 func (t Tool) RequiresClientAuthorization(resourceMgr tools.SourceProvider) (bool, error) {
 	source, err := tools.GetCompatibleSource[compatibleSource](resourceMgr, t.Cfg.Source, t.Cfg.Name, t.Cfg.Type)
 	if err != nil {
@@ -15,14 +15,25 @@ package cloudloggingadminquerylogs_test
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/googleapis/mcp-toolbox/internal/server"
+	"github.com/googleapis/mcp-toolbox/internal/sources"
+	cla "github.com/googleapis/mcp-toolbox/internal/sources/cloudloggingadmin"
 	"github.com/googleapis/mcp-toolbox/internal/testutils"
 	"github.com/googleapis/mcp-toolbox/internal/tools"
 	"github.com/googleapis/mcp-toolbox/internal/tools/cloudloggingadmin/cloudloggingadminquerylogs"
+	"github.com/googleapis/mcp-toolbox/internal/util"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+	"google.golang.org/api/googleapi"
 )
 
 func TestParseFromYaml(t *testing.T) {
@@ -113,3 +124,1117 @@ func TestFailParseFromYaml(t *testing.T) {
 		})
 	}
 }
+
+type mockSource struct {
+	sources.Source
+	defaultLogLimit int
+	entries         []map[string]any
+	called          bool
+	gotParams       cla.QueryLogsParams
+	exportErr       error
+	gotBucket       string
+	gotObject       string
+	signErr         error
+	gotExpires      time.Time
+}
+
+func (m *mockSource) UseClientAuthorization() bool {
+	return false
+}
+
+func (m *mockSource) GetDefaultLogLimit() int {
+	return m.defaultLogLimit
+}
+
+func (m *mockSource) QueryLogs(ctx context.Context, params cla.QueryLogsParams, accessToken string) ([]map[string]any, error) {
+	m.called = true
+	m.gotParams = params
+	if m.entries != nil {
+		return m.entries, nil
+	}
+	return []map[string]any{}, nil
+}
+
+func (m *mockSource) ExportLogs(ctx context.Context, params cla.QueryLogsParams, accessToken, bucket, object string) (int, error) {
+	m.called = true
+	m.gotParams = params
+	m.gotBucket = bucket
+	m.gotObject = object
+	if m.exportErr != nil {
+		return 0, m.exportErr
+	}
+	return len(m.entries), nil
+}
+
+func (m *mockSource) SignExportURL(accessToken, bucket, object string, expires time.Time) (string, error) {
+	m.gotExpires = expires
+	if m.signErr != nil {
+		return "", m.signErr
+	}
+	return "https://storage.googleapis.com/" + bucket + "/" + object + "?X-Goog-Signature=abc", nil
+}
+
+func (m *mockSource) BuildFilter(params cla.QueryLogsParams) string {
+	return params.Filter + " AND timestamp>=\"" + params.StartTime + "\""
+}
+
+type mockSourceProvider struct {
+	tools.SourceProvider
+	source *mockSource
+}
+
+func (m *mockSourceProvider) GetSource(name string) (sources.Source, bool) {
+	return m.source, true
+}
+
+func TestInvokeTimeRangeValidation(t *testing.T) {
+	cfg := cloudloggingadminquerylogs.Config{
+		ConfigBase: tools.ConfigBase{
+			Name:        "example_tool",
+			Description: "query logs",
+		},
+		Type:   "cloud-logging-admin-query-logs",
+		Source: "my-logging-admin-source",
+	}
+	tool, err := cfg.Initialize(context.Background())
+	if err != nil {
+		t.Fatalf("failed to initialize tool: %v", err)
+	}
+
+	tcs := []struct {
+		desc       string
+		startTime  string
+		endTime    string
+		wantSubstr string
+	}{
+		{desc: "ordered range", startTime: "2025-12-09T00:00:00Z", endTime: "2025-12-09T23:59:59Z"},
+		{desc: "empty range", startTime: "2025-12-09T00:00:00Z", endTime: "2025-12-09T00:00:00Z"},
+		{desc: "reversed range", startTime: "2025-12-09T23:59:59Z", endTime: "2025-12-09T00:00:00Z", wantSubstr: "endTime 2025-12-09T00:00:00Z must not be before startTime 2025-12-09T23:59:59Z"},
+		{desc: "end before default start", endTime: "2000-01-01T00:00:00Z", wantSubstr: "must not be before startTime"},
+		{desc: "invalid end", startTime: "2025-12-09T00:00:00Z", endTime: "yesterday", wantSubstr: `unable to parse value for "endTime"`},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			src := &mockSource{}
+			resourceMgr := &mockSourceProvider{source: src}
+			toolParams, err := tool.GetParameters(nil)
+			if err != nil {
+				t.Fatalf("failed to get parameters: %v", err)
+			}
+			data := map[string]any{"startTime": tc.startTime, "endTime": tc.endTime}
+			params, toolErr := parameters.ParseParams(toolParams, data, nil)
+			if toolErr == nil {
+				_, toolErr = tool.Invoke(context.Background(), resourceMgr, params, "")
+			}
+			if tc.wantSubstr != "" {
+				if toolErr == nil {
+					t.Fatalf("expected error, got nil")
+				}
+				if _, ok := toolErr.(*util.AgentError); !ok {
+					t.Fatalf("expected *AgentError, got %T: %v", toolErr, toolErr)
+				}
+				if !strings.Contains(toolErr.Error(), tc.wantSubstr) {
+					t.Errorf("error %q does not contain %q", toolErr, tc.wantSubstr)
+				}
+				if src.called {
+					t.Errorf("expected source not to be called on validation failure")
+				}
+				return
+			}
+			if toolErr != nil {
+				t.Fatalf("unexpected error: %v", toolErr)
+			}
+			if src.gotParams.StartTime != tc.startTime || src.gotParams.EndTime != tc.endTime {
+				t.Errorf("got time range [%q, %q], want [%q, %q]", src.gotParams.StartTime, src.gotParams.EndTime, tc.startTime, tc.endTime)
+			}
+		})
+	}
+}
+
+func TestInvokeRelativeTimeWindow(t *testing.T) {
+	cfg := cloudloggingadminquerylogs.Config{
+		ConfigBase: tools.ConfigBase{
+			Name:        "example_tool",
+			Description: "query logs",
+		},
+		Type:   "cloud-logging-admin-query-logs",
+		Source: "my-logging-admin-source",
+	}
+	tool, err := cfg.Initialize(context.Background())
+	if err != nil {
+		t.Fatalf("failed to initialize tool: %v", err)
+	}
+
+	tcs := []struct {
+		desc       string
+		params     parameters.ParamValues
+		wantWindow time.Duration
+		wantSubstr string
+	}{
+		{
+			desc:       "last two hours",
+			params:     parameters.ParamValues{{Name: "last", Value: "2h"}},
+			wantWindow: 2 * time.Hour,
+		},
+		{
+			desc:       "combined with startTime",
+			params:     parameters.ParamValues{{Name: "last", Value: "2h"}, {Name: "startTime", Value: time.Date(2025, 12, 9, 0, 0, 0, 0, time.UTC)}},
+			wantSubstr: "last cannot be combined with startTime or endTime",
+		},
+		{
+			desc:       "combined with endTime",
+			params:     parameters.ParamValues{{Name: "last", Value: "2h"}, {Name: "endTime", Value: time.Date(2025, 12, 9, 0, 0, 0, 0, time.UTC)}},
+			wantSubstr: "last cannot be combined with startTime or endTime",
+		},
+		{
+			desc:       "invalid duration",
+			params:     parameters.ParamValues{{Name: "last", Value: "two hours"}},
+			wantSubstr: "last must be a duration",
+		},
+		{
+			desc:       "negative duration",
+			params:     parameters.ParamValues{{Name: "last", Value: "-30m"}},
+			wantSubstr: "last must be positive",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			src := &mockSource{}
+			resourceMgr := &mockSourceProvider{source: src}
+			_, toolErr := tool.Invoke(context.Background(), resourceMgr, tc.params, "")
+			if tc.wantSubstr != "" {
+				if toolErr == nil {
+					t.Fatalf("expected error, got nil")
+				}
+				if !strings.Contains(toolErr.Error(), tc.wantSubstr) {
+					t.Errorf("error %q does not contain %q", toolErr, tc.wantSubstr)
+				}
+				if src.called {
+					t.Errorf("expected source not to be called on validation failure")
+				}
+				return
+			}
+			if toolErr != nil {
+				t.Fatalf("unexpected error: %v", toolErr)
+			}
+			start, err := time.Parse(time.RFC3339, src.gotParams.StartTime)
+			if err != nil {
+				t.Fatalf("invalid start time %q: %v", src.gotParams.StartTime, err)
+			}
+			end, err := time.Parse(time.RFC3339, src.gotParams.EndTime)
+			if err != nil {
+				t.Fatalf("invalid end time %q: %v", src.gotParams.EndTime, err)
+			}
+			if got := end.Sub(start); got != tc.wantWindow {
+				t.Errorf("got window %v, want %v", got, tc.wantWindow)
+			}
+		})
+	}
+}
+
+func TestInvokeDefaultLimit(t *testing.T) {
+	cfg := cloudloggingadminquerylogs.Config{
+		ConfigBase: tools.ConfigBase{
+			Name:        "example_tool",
+			Description: "query logs",
+		},
+		Type:   "cloud-logging-admin-query-logs",
+		Source: "my-logging-admin-source",
+	}
+	tool, err := cfg.Initialize(context.Background())
+	if err != nil {
+		t.Fatalf("failed to initialize tool: %v", err)
+	}
+
+	tcs := []struct {
+		desc            string
+		defaultLogLimit int
+		params          parameters.ParamValues
+		wantLimit       int
+	}{
+		{desc: "tool default", wantLimit: 200},
+		{desc: "source default", defaultLogLimit: 20, wantLimit: 20},
+		{desc: "limit overrides source default", defaultLogLimit: 20, params: parameters.ParamValues{{Name: "limit", Value: 5}}, wantLimit: 5},
+		{desc: "zero limit uses source default", defaultLogLimit: 20, params: parameters.ParamValues{{Name: "limit", Value: 0}}, wantLimit: 20},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			src := &mockSource{defaultLogLimit: tc.defaultLogLimit}
+			resourceMgr := &mockSourceProvider{source: src}
+			if _, toolErr := tool.Invoke(context.Background(), resourceMgr, tc.params, ""); toolErr != nil {
+				t.Fatalf("unexpected error: %v", toolErr)
+			}
+			if src.gotParams.Limit != tc.wantLimit {
+				t.Errorf("got limit %d, want %d", src.gotParams.Limit, tc.wantLimit)
+			}
+		})
+	}
+
+	toolParams, err := tool.GetParameters(nil)
+	if err != nil {
+		t.Fatalf("failed to get parameters: %v", err)
+	}
+	if _, err := parameters.ParseParams(toolParams, map[string]any{"limit": 0}, nil); err != nil {
+		t.Errorf("ParseParams(limit=0) error = %v, want nil", err)
+	}
+	for _, limit := range []int{-1, 10001} {
+		_, err := parameters.ParseParams(toolParams, map[string]any{"limit": limit}, nil)
+		want := fmt.Sprintf("limit must be between 0 and 10000: %d", limit)
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("ParseParams(limit=%d) error = %v, want %q", limit, err, want)
+		}
+	}
+}
+
+func TestInvokeTraceID(t *testing.T) {
+	cfg := cloudloggingadminquerylogs.Config{
+		ConfigBase: tools.ConfigBase{
+			Name:        "example_tool",
+			Description: "query logs",
+		},
+		Type:   "cloud-logging-admin-query-logs",
+		Source: "my-logging-admin-source",
+	}
+	tool, err := cfg.Initialize(context.Background())
+	if err != nil {
+		t.Fatalf("failed to initialize tool: %v", err)
+	}
+
+	tcs := []struct {
+		desc       string
+		traceID    string
+		wantSubstr string
+	}{
+		{desc: "valid", traceID: "4bf92f3577b34da6a3ce929d0e0e4736"},
+		{desc: "too short", traceID: "4bf92f35", wantSubstr: "traceId must be a 32-character hexadecimal string"},
+		{desc: "filter injection", traceID: `4bf92f3577b34da6a3ce929d0e0e473" OR "`, wantSubstr: "traceId must be a 32-character hexadecimal string"},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			src := &mockSource{}
+			resourceMgr := &mockSourceProvider{source: src}
+			params := parameters.ParamValues{{Name: "traceId", Value: tc.traceID}}
+			_, toolErr := tool.Invoke(context.Background(), resourceMgr, params, "")
+			if tc.wantSubstr != "" {
+				if toolErr == nil || !strings.Contains(toolErr.Error(), tc.wantSubstr) {
+					t.Fatalf("expected error containing %q, got %v", tc.wantSubstr, toolErr)
+				}
+				if src.called {
+					t.Errorf("expected source not to be called on validation failure")
+				}
+				return
+			}
+			if toolErr != nil {
+				t.Fatalf("unexpected error: %v", toolErr)
+			}
+			if src.gotParams.TraceID != tc.traceID {
+				t.Errorf("got trace ID %q, want %q", src.gotParams.TraceID, tc.traceID)
+			}
+		})
+	}
+}
+
+func TestInvokeApplicationID(t *testing.T) {
+	cfg := cloudloggingadminquerylogs.Config{
+		ConfigBase: tools.ConfigBase{
+			Name:        "example_tool",
+			Description: "query logs",
+		},
+		Type:   "cloud-logging-admin-query-logs",
+		Source: "my-logging-admin-source",
+	}
+	tool, err := cfg.Initialize(context.Background())
+	if err != nil {
+		t.Fatalf("failed to initialize tool: %v", err)
+	}
+
+	tcs := []struct {
+		desc          string
+		applicationID string
+		wantSubstr    string
+	}{
+		{desc: "yarn", applicationID: "application_1700000000000_0001"},
+		{desc: "spark", applicationID: "app-20251209100000-0000"},
+		{desc: "invalid", applicationID: "my-app", wantSubstr: "applicationId must be a YARN or Spark application ID"},
+		{desc: "filter injection", applicationID: `application_1_1" OR "`, wantSubstr: "applicationId must be a YARN or Spark application ID"},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			src := &mockSource{}
+			resourceMgr := &mockSourceProvider{source: src}
+			params := parameters.ParamValues{{Name: "applicationId", Value: tc.applicationID}}
+			_, toolErr := tool.Invoke(context.Background(), resourceMgr, params, "")
+			if tc.wantSubstr != "" {
+				if toolErr == nil || !strings.Contains(toolErr.Error(), tc.wantSubstr) {
+					t.Fatalf("expected error containing %q, got %v", tc.wantSubstr, toolErr)
+				}
+				if src.called {
+					t.Errorf("expected source not to be called on validation failure")
+				}
+				return
+			}
+			if toolErr != nil {
+				t.Fatalf("unexpected error: %v", toolErr)
+			}
+			if src.gotParams.ApplicationID != tc.applicationID {
+				t.Errorf("got application ID %q, want %q", src.gotParams.ApplicationID, tc.applicationID)
+			}
+		})
+	}
+}
+
+func TestInvokeMalformedFilter(t *testing.T) {
+	cfg := cloudloggingadminquerylogs.Config{
+		ConfigBase: tools.ConfigBase{
+			Name:        "example_tool",
+			Description: "query logs",
+		},
+		Type:   "cloud-logging-admin-query-logs",
+		Source: "my-logging-admin-source",
+	}
+	tool, err := cfg.Initialize(context.Background())
+	if err != nil {
+		t.Fatalf("failed to initialize tool: %v", err)
+	}
+
+	src := &mockSource{}
+	params := parameters.ParamValues{{Name: "filter", Value: `severity>=ERROR AND (textPayload:"OOM"`}}
+	_, toolErr := tool.Invoke(context.Background(), &mockSourceProvider{source: src}, params, "")
+	if toolErr == nil || !strings.Contains(toolErr.Error(), "malformed filter") {
+		t.Fatalf("expected malformed filter error, got %v", toolErr)
+	}
+	if src.called {
+		t.Errorf("expected source not to be called on validation failure")
+	}
+}
+
+func TestInvokeLogName(t *testing.T) {
+	cfg := cloudloggingadminquerylogs.Config{
+		ConfigBase: tools.ConfigBase{
+			Name:        "example_tool",
+			Description: "query logs",
+		},
+		Type:   "cloud-logging-admin-query-logs",
+		Source: "my-logging-admin-source",
+	}
+	tool, err := cfg.Initialize(context.Background())
+	if err != nil {
+		t.Fatalf("failed to initialize tool: %v", err)
+	}
+
+	src := &mockSource{}
+	resourceMgr := &mockSourceProvider{source: src}
+	params := parameters.ParamValues{{Name: "logName", Value: "dataproc.googleapis.com/yarn"}}
+	if _, toolErr := tool.Invoke(context.Background(), resourceMgr, params, ""); toolErr != nil {
+		t.Fatalf("unexpected error: %v", toolErr)
+	}
+	if want := "dataproc.googleapis.com/yarn"; src.gotParams.LogName != want {
+		t.Errorf("got logName %q, want %q", src.gotParams.LogName, want)
+	}
+
+	src = &mockSource{}
+	params = parameters.ParamValues{{Name: "logName", Value: " "}}
+	if _, toolErr := tool.Invoke(context.Background(), &mockSourceProvider{source: src}, params, ""); toolErr == nil || !strings.Contains(toolErr.Error(), "logName cannot be empty") {
+		t.Errorf("expected logName error, got %v", toolErr)
+	}
+	if src.called {
+		t.Errorf("expected source not to be called on validation failure")
+	}
+}
+
+func TestInvokeSeverity(t *testing.T) {
+	cfg := cloudloggingadminquerylogs.Config{
+		ConfigBase: tools.ConfigBase{
+			Name:        "example_tool",
+			Description: "query logs",
+		},
+		Type:   "cloud-logging-admin-query-logs",
+		Source: "my-logging-admin-source",
+	}
+	tool, err := cfg.Initialize(context.Background())
+	if err != nil {
+		t.Fatalf("failed to initialize tool: %v", err)
+	}
+
+	tcs := []struct {
+		desc            string
+		data            map[string]any
+		wantMinSeverity string
+		wantSeverity    string
+		wantSubstr      string
+	}{
+		{desc: "neither", data: map[string]any{}},
+		{desc: "minimum", data: map[string]any{"minSeverity": "WARNING"}, wantMinSeverity: "WARNING"},
+		{desc: "exact", data: map[string]any{"severity": "ERROR"}, wantSeverity: "ERROR"},
+		{desc: "both", data: map[string]any{"minSeverity": "WARNING", "severity": "ERROR"}, wantSubstr: "minSeverity and severity are mutually exclusive"},
+		{desc: "unknown severity", data: map[string]any{"severity": "FATAL"}, wantSubstr: `unable to parse value for "severity"`},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			src := &mockSource{}
+			resourceMgr := &mockSourceProvider{source: src}
+			toolParams, err := tool.GetParameters(nil)
+			if err != nil {
+				t.Fatalf("failed to get parameters: %v", err)
+			}
+			params, toolErr := parameters.ParseParams(toolParams, tc.data, nil)
+			if toolErr == nil {
+				_, toolErr = tool.Invoke(context.Background(), resourceMgr, params, "")
+			}
+			if tc.wantSubstr != "" {
+				if toolErr == nil || !strings.Contains(toolErr.Error(), tc.wantSubstr) {
+					t.Fatalf("expected error containing %q, got %v", tc.wantSubstr, toolErr)
+				}
+				if src.called {
+					t.Errorf("expected source not to be called on validation failure")
+				}
+				return
+			}
+			if toolErr != nil {
+				t.Fatalf("unexpected error: %v", toolErr)
+			}
+			if src.gotParams.MinSeverity != tc.wantMinSeverity || src.gotParams.Severity != tc.wantSeverity {
+				t.Errorf("got (minSeverity %q, severity %q), want (%q, %q)", src.gotParams.MinSeverity, src.gotParams.Severity, tc.wantMinSeverity, tc.wantSeverity)
+			}
+		})
+	}
+}
+
+func TestInvokeProject(t *testing.T) {
+	cfg := cloudloggingadminquerylogs.Config{
+		ConfigBase: tools.ConfigBase{
+			Name:        "example_tool",
+			Description: "query logs",
+		},
+		Type:   "cloud-logging-admin-query-logs",
+		Source: "my-logging-admin-source",
+	}
+	tool, err := cfg.Initialize(context.Background())
+	if err != nil {
+		t.Fatalf("failed to initialize tool: %v", err)
+	}
+
+	tcs := []struct {
+		desc       string
+		params     parameters.ParamValues
+		want       string
+		wantSubstr string
+	}{
+		{desc: "default", params: parameters.ParamValues{}, want: ""},
+		{desc: "override", params: parameters.ParamValues{{Name: "project", Value: "other-project"}}, want: "other-project"},
+		{desc: "domain scoped", params: parameters.ParamValues{{Name: "project", Value: "example.com:other-project"}}, want: "example.com:other-project"},
+		{desc: "invalid", params: parameters.ParamValues{{Name: "project", Value: "projects/other-project"}}, wantSubstr: "project must be a project ID"},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			src := &mockSource{}
+			resourceMgr := &mockSourceProvider{source: src}
+			_, toolErr := tool.Invoke(context.Background(), resourceMgr, tc.params, "")
+			if tc.wantSubstr != "" {
+				if toolErr == nil || !strings.Contains(toolErr.Error(), tc.wantSubstr) {
+					t.Fatalf("expected error containing %q, got %v", tc.wantSubstr, toolErr)
+				}
+				if src.called {
+					t.Errorf("expected source not to be called on validation failure")
+				}
+				return
+			}
+			if toolErr != nil {
+				t.Fatalf("unexpected error: %v", toolErr)
+			}
+			if src.gotParams.Project != tc.want {
+				t.Errorf("got project %q, want %q", src.gotParams.Project, tc.want)
+			}
+		})
+	}
+}
+
+func TestInvokeNDJSON(t *testing.T) {
+	cfg := cloudloggingadminquerylogs.Config{
+		ConfigBase: tools.ConfigBase{
+			Name:        "example_tool",
+			Description: "query logs",
+		},
+		Type:   "cloud-logging-admin-query-logs",
+		Source: "my-logging-admin-source",
+	}
+	tool, err := cfg.Initialize(context.Background())
+	if err != nil {
+		t.Fatalf("failed to initialize tool: %v", err)
+	}
+
+	src := &mockSource{entries: []map[string]any{
+		{"severity": "INFO", "payload": "starting"},
+		{"severity": "ERROR", "payload": "line one\nline two"},
+	}}
+	resourceMgr := &mockSourceProvider{source: src}
+	params := parameters.ParamValues{{Name: "outputFormat", Value: "ndjson"}}
+	got, toolErr := tool.Invoke(context.Background(), resourceMgr, params, "")
+	if toolErr != nil {
+		t.Fatalf("unexpected error: %v", toolErr)
+	}
+	want := `{"payload":"starting","severity":"INFO"}` + "\n" + `{"payload":"line one\nline two","severity":"ERROR"}`
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	params = parameters.ParamValues{{Name: "outputFormat", Value: "csv"}}
+	if _, toolErr := tool.Invoke(context.Background(), resourceMgr, params, ""); toolErr == nil || !strings.Contains(toolErr.Error(), "outputFormat must be") {
+		t.Errorf("expected outputFormat error, got %v", toolErr)
+	}
+}
+
+func TestInvokeSummarize(t *testing.T) {
+	cfg := cloudloggingadminquerylogs.Config{
+		ConfigBase: tools.ConfigBase{
+			Name:        "example_tool",
+			Description: "query logs",
+		},
+		Type:   "cloud-logging-admin-query-logs",
+		Source: "my-logging-admin-source",
+	}
+	tool, err := cfg.Initialize(context.Background())
+	if err != nil {
+		t.Fatalf("failed to initialize tool: %v", err)
+	}
+
+	// Newest first, as with newestFirst set.
+	src := &mockSource{entries: []map[string]any{
+		{"timestamp": "2026-01-01T00:05:00Z", "severity": "Info", "payload": "done"},
+		{"timestamp": "2026-01-01T00:04:00Z", "severity": "Critical", "payload": map[string]any{"message": "executor lost"}},
+		{"timestamp": "2026-01-01T00:03:00Z", "severity": "Warning", "payload": "slow task"},
+		{"timestamp": "2026-01-01T00:02:00Z", "severity": "Error", "payload": "task failed"},
+		{"timestamp": "2026-01-01T00:01:00Z", "severity": "Info", "payload": "starting"},
+	}}
+	resourceMgr := &mockSourceProvider{source: src}
+	params := parameters.ParamValues{{Name: "summarize", Value: true}}
+	got, toolErr := tool.Invoke(context.Background(), resourceMgr, params, "")
+	if toolErr != nil {
+		t.Fatalf("unexpected error: %v", toolErr)
+	}
+	gotJSON, err := json.Marshal(got)
+	if err != nil {
+		t.Fatalf("failed to marshal result: %v", err)
+	}
+	want := `{"entryCount":5,"errorCount":2,"warningCount":1,"firstError":"task failed","lastError":"executor lost","timeRange":{"start":"2026-01-01T00:01:00Z","end":"2026-01-01T00:05:00Z"},"truncated":false}`
+	if string(gotJSON) != want {
+		t.Errorf("got %s, want %s", gotJSON, want)
+	}
+	if src.gotParams.Limit != 201 {
+		t.Errorf("got limit %d, want 201 to detect truncation", src.gotParams.Limit)
+	}
+
+	// The source returns one more entry than the limit, so the summary is
+	// truncated to the first four.
+	truncatedParams := parameters.ParamValues{{Name: "summarize", Value: true}, {Name: "limit", Value: 4}}
+	got, toolErr = tool.Invoke(context.Background(), resourceMgr, truncatedParams, "")
+	if toolErr != nil {
+		t.Fatalf("unexpected error: %v", toolErr)
+	}
+	gotJSON, _ = json.Marshal(got)
+	if want := `{"entryCount":4,"errorCount":2,"warningCount":1,"firstError":"task failed","lastError":"executor lost","timeRange":{"start":"2026-01-01T00:02:00Z","end":"2026-01-01T00:05:00Z"},"truncated":true}`; string(gotJSON) != want {
+		t.Errorf("got %s, want %s", gotJSON, want)
+	}
+
+	// The source returns the entries read before the deadline passed.
+	deadlineCtx, cancel := context.WithDeadline(context.Background(), time.Now())
+	defer cancel()
+	got, toolErr = tool.Invoke(deadlineCtx, resourceMgr, params, "")
+	if toolErr != nil {
+		t.Fatalf("unexpected error: %v", toolErr)
+	}
+	gotJSON, _ = json.Marshal(got)
+	if want := `"truncated":true,"deadlineExceeded":true}`; !strings.HasSuffix(string(gotJSON), want) {
+		t.Errorf("got %s, want suffix %s", gotJSON, want)
+	}
+
+	src = &mockSource{entries: []map[string]any{}}
+	got, toolErr = tool.Invoke(context.Background(), &mockSourceProvider{source: src}, params, "")
+	if toolErr != nil {
+		t.Fatalf("unexpected error: %v", toolErr)
+	}
+	gotJSON, _ = json.Marshal(got)
+	if want := `{"entryCount":0,"errorCount":0,"warningCount":0,"truncated":false}`; string(gotJSON) != want {
+		t.Errorf("got %s, want %s", gotJSON, want)
+	}
+
+	params = parameters.ParamValues{{Name: "summarize", Value: true}, {Name: "outputFormat", Value: "ndjson"}}
+	if _, toolErr := tool.Invoke(context.Background(), resourceMgr, params, ""); toolErr == nil || !strings.Contains(toolErr.Error(), "summarize cannot be combined") {
+		t.Errorf("expected summarize error, got %v", toolErr)
+	}
+}
+
+func TestInvokeIncludeLogURL(t *testing.T) {
+	cfg := cloudloggingadminquerylogs.Config{
+		ConfigBase: tools.ConfigBase{
+			Name:        "example_tool",
+			Description: "query logs",
+		},
+		Type:   "cloud-logging-admin-query-logs",
+		Source: "my-logging-admin-source",
+	}
+	tool, err := cfg.Initialize(context.Background())
+	if err != nil {
+		t.Fatalf("failed to initialize tool: %v", err)
+	}
+
+	for _, want := range []bool{false, true} {
+		src := &mockSource{}
+		resourceMgr := &mockSourceProvider{source: src}
+		var params parameters.ParamValues
+		if want {
+			params = parameters.ParamValues{{Name: "includeLogUrl", Value: true}}
+		}
+		if _, toolErr := tool.Invoke(context.Background(), resourceMgr, params, ""); toolErr != nil {
+			t.Fatalf("unexpected error: %v", toolErr)
+		}
+		if src.gotParams.IncludeLogURL != want {
+			t.Errorf("got IncludeLogURL %t, want %t", src.gotParams.IncludeLogURL, want)
+		}
+	}
+}
+
+func TestInvokeCollapseStackTraces(t *testing.T) {
+	cfg := cloudloggingadminquerylogs.Config{
+		ConfigBase: tools.ConfigBase{
+			Name:        "example_tool",
+			Description: "query logs",
+		},
+		Type:   "cloud-logging-admin-query-logs",
+		Source: "my-logging-admin-source",
+	}
+	tool, err := cfg.Initialize(context.Background())
+	if err != nil {
+		t.Fatalf("failed to initialize tool: %v", err)
+	}
+
+	entries := []map[string]any{
+		{"logName": "driver", "payload": "java.lang.IllegalStateException: boom"},
+		{"logName": "driver", "payload": "\tat org.example.Job.run(Job.java:42)"},
+		{"logName": "driver", "payload": "\tat org.example.Main.main(Main.java:7)"},
+		{"logName": "driver", "payload": "Caused by: java.io.IOException: disk full"},
+		{"logName": "driver", "payload": "\tat org.example.Io.write(Io.java:3)"},
+		{"logName": "driver", "payload": "\t... 2 more"},
+		{"logName": "driver", "payload": "shutting down"},
+	}
+	reversed := slices.Clone(entries)
+	slices.Reverse(reversed)
+	tcs := []struct {
+		desc    string
+		entries []map[string]any
+		params  parameters.ParamValues
+		want    []map[string]any
+	}{
+		{
+			desc:    "oldest first",
+			entries: entries,
+			params:  parameters.ParamValues{{Name: "collapseStackTraces", Value: true}},
+			want: []map[string]any{
+				{"logName": "driver", "payload": "java.lang.IllegalStateException: boom"},
+				{"logName": "driver", "payload": "\tat org.example.Job.run(Job.java:42)", "stackLines": 2},
+				{"logName": "driver", "payload": "Caused by: java.io.IOException: disk full"},
+				{"logName": "driver", "payload": "\tat org.example.Io.write(Io.java:3)", "stackLines": 2},
+				{"logName": "driver", "payload": "shutting down"},
+			},
+		},
+		{
+			desc:    "newest first",
+			entries: reversed,
+			params:  parameters.ParamValues{{Name: "collapseStackTraces", Value: true}, {Name: "newestFirst", Value: true}},
+			want: []map[string]any{
+				{"logName": "driver", "payload": "shutting down"},
+				{"logName": "driver", "payload": "\tat org.example.Io.write(Io.java:3)", "stackLines": 2},
+				{"logName": "driver", "payload": "Caused by: java.io.IOException: disk full"},
+				{"logName": "driver", "payload": "\tat org.example.Job.run(Job.java:42)", "stackLines": 2},
+				{"logName": "driver", "payload": "java.lang.IllegalStateException: boom"},
+			},
+		},
+		{
+			desc: "different logs",
+			entries: []map[string]any{
+				{"logName": "driver", "payload": "\tat org.example.Job.run(Job.java:42)"},
+				{"logName": "executor", "payload": "\tat org.example.Task.run(Task.java:9)"},
+			},
+			params: parameters.ParamValues{{Name: "collapseStackTraces", Value: true}},
+			want: []map[string]any{
+				{"logName": "driver", "payload": "\tat org.example.Job.run(Job.java:42)", "stackLines": 1},
+				{"logName": "executor", "payload": "\tat org.example.Task.run(Task.java:9)", "stackLines": 1},
+			},
+		},
+		{
+			desc:    "not set",
+			entries: entries,
+			want:    entries,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			src := &mockSource{entries: tc.entries}
+			got, toolErr := tool.Invoke(context.Background(), &mockSourceProvider{source: src}, tc.params, "")
+			if toolErr != nil {
+				t.Fatalf("unexpected error: %v", toolErr)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Invoke() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestInvokeIncludeFilter(t *testing.T) {
+	cfg := cloudloggingadminquerylogs.Config{
+		ConfigBase: tools.ConfigBase{
+			Name:        "example_tool",
+			Description: "query logs",
+		},
+		Type:   "cloud-logging-admin-query-logs",
+		Source: "my-logging-admin-source",
+	}
+	tool, err := cfg.Initialize(context.Background())
+	if err != nil {
+		t.Fatalf("failed to initialize tool: %v", err)
+	}
+
+	entries := []map[string]any{{"timestamp": "2026-01-01T00:01:00Z", "severity": "Error", "payload": "task failed"}}
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	wantFilter := `severity=ERROR AND timestamp>="2026-01-01T00:00:00Z"`
+	base := parameters.ParamValues{{Name: "filter", Value: "severity=ERROR"}, {Name: "startTime", Value: start}}
+
+	resourceMgr := &mockSourceProvider{source: &mockSource{entries: entries}}
+	got, toolErr := tool.Invoke(context.Background(), resourceMgr, base, "")
+	if toolErr != nil {
+		t.Fatalf("unexpected error: %v", toolErr)
+	}
+	if diff := cmp.Diff(entries, got); diff != "" {
+		t.Errorf("without includeFilter, entries mismatch (-want +got):\n%s", diff)
+	}
+
+	params := append(slices.Clone(base), parameters.ParamValue{Name: "includeFilter", Value: true})
+	got, toolErr = tool.Invoke(context.Background(), resourceMgr, params, "")
+	if toolErr != nil {
+		t.Fatalf("unexpected error: %v", toolErr)
+	}
+	if diff := cmp.Diff(map[string]any{"filter": wantFilter, "entries": entries}, got); diff != "" {
+		t.Errorf("with includeFilter, result mismatch (-want +got):\n%s", diff)
+	}
+
+	params = append(slices.Clone(base), parameters.ParamValue{Name: "includeFilter", Value: true}, parameters.ParamValue{Name: "summarize", Value: true})
+	got, toolErr = tool.Invoke(context.Background(), resourceMgr, params, "")
+	if toolErr != nil {
+		t.Fatalf("unexpected error: %v", toolErr)
+	}
+	gotJSON, err := json.Marshal(got)
+	if err != nil {
+		t.Fatalf("failed to marshal result: %v", err)
+	}
+	var summary map[string]any
+	if err := json.Unmarshal(gotJSON, &summary); err != nil {
+		t.Fatalf("failed to unmarshal summary: %v", err)
+	}
+	if summary["filter"] != wantFilter {
+		t.Errorf("got summary %s, want filter %q", gotJSON, wantFilter)
+	}
+
+	params = append(slices.Clone(base), parameters.ParamValue{Name: "includeFilter", Value: true}, parameters.ParamValue{Name: "outputFormat", Value: "ndjson"})
+	if _, toolErr := tool.Invoke(context.Background(), resourceMgr, params, ""); toolErr == nil || !strings.Contains(toolErr.Error(), "includeFilter cannot be combined") {
+		t.Errorf("expected includeFilter error, got %v", toolErr)
+	}
+}
+
+func TestInvokeGroupByExecutor(t *testing.T) {
+	cfg := cloudloggingadminquerylogs.Config{
+		ConfigBase: tools.ConfigBase{
+			Name:        "example_tool",
+			Description: "query logs",
+		},
+		Type:   "cloud-logging-admin-query-logs",
+		Source: "my-logging-admin-source",
+	}
+	tool, err := cfg.Initialize(context.Background())
+	if err != nil {
+		t.Fatalf("failed to initialize tool: %v", err)
+	}
+
+	driver := map[string]string{"dataproc.googleapis.com/process_id": "driver"}
+	executor1 := map[string]string{"dataproc.googleapis.com/process_id": "1"}
+	executor2 := map[string]string{"dataproc.googleapis.com/container_id": "container_2"}
+	entries := []map[string]any{
+		{"logName": "spark", "payload": "starting", "labels": driver},
+		{"logName": "spark", "payload": "java.lang.OutOfMemoryError", "labels": executor1},
+		{"logName": "spark", "payload": "task done", "labels": executor2},
+		{"logName": "spark", "payload": "\tat org.example.Task.run(Task.java:9)", "labels": executor1},
+		{"logName": "spark", "payload": "\tat org.example.Executor.run(Executor.java:3)", "labels": executor1},
+		{"logName": "spark", "payload": "no labels"},
+	}
+	tcs := []struct {
+		desc   string
+		params parameters.ParamValues
+		want   any
+	}{
+		{
+			desc:   "grouped",
+			params: parameters.ParamValues{{Name: "groupByExecutor", Value: true}},
+			want: map[string][]map[string]any{
+				"driver": {{"logName": "spark", "payload": "starting"}},
+				"1": {
+					{"logName": "spark", "payload": "java.lang.OutOfMemoryError"},
+					{"logName": "spark", "payload": "\tat org.example.Task.run(Task.java:9)"},
+					{"logName": "spark", "payload": "\tat org.example.Executor.run(Executor.java:3)"},
+				},
+				"container_2": {{"logName": "spark", "payload": "task done"}},
+				"unknown":     {{"logName": "spark", "payload": "no labels"}},
+			},
+		},
+		{
+			desc:   "collapsed within executor",
+			params: parameters.ParamValues{{Name: "groupByExecutor", Value: true}, {Name: "collapseStackTraces", Value: true}, {Name: "verbose", Value: true}},
+			want: map[string][]map[string]any{
+				"driver": {{"logName": "spark", "payload": "starting", "labels": driver}},
+				"1": {
+					{"logName": "spark", "payload": "java.lang.OutOfMemoryError", "labels": executor1},
+					{"logName": "spark", "payload": "\tat org.example.Task.run(Task.java:9)", "labels": executor1, "stackLines": 2},
+				},
+				"container_2": {{"logName": "spark", "payload": "task done", "labels": executor2}},
+				"unknown":     {{"logName": "spark", "payload": "no labels"}},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			src := &mockSource{entries: entries}
+			got, toolErr := tool.Invoke(context.Background(), &mockSourceProvider{source: src}, tc.params, "")
+			if toolErr != nil {
+				t.Fatalf("unexpected error: %v", toolErr)
+			}
+			if !src.gotParams.IncludeLabels {
+				t.Errorf("got IncludeLabels false, want true")
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Invoke() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	for _, other := range []parameters.ParamValue{{Name: "summarize", Value: true}, {Name: "outputFormat", Value: "ndjson"}} {
+		params := parameters.ParamValues{{Name: "groupByExecutor", Value: true}, other}
+		if _, toolErr := tool.Invoke(context.Background(), &mockSourceProvider{source: &mockSource{}}, params, ""); toolErr == nil || !strings.Contains(toolErr.Error(), "groupByExecutor cannot be combined") {
+			t.Errorf("with %s, expected groupByExecutor error, got %v", other.Name, toolErr)
+		}
+	}
+}
+
+func TestInvokeExportToGcs(t *testing.T) {
+	cfg := cloudloggingadminquerylogs.Config{
+		ConfigBase: tools.ConfigBase{
+			Name:        "example_tool",
+			Description: "query logs",
+		},
+		Type:   "cloud-logging-admin-query-logs",
+		Source: "my-logging-admin-source",
+	}
+	tool, err := cfg.Initialize(context.Background())
+	if err != nil {
+		t.Fatalf("failed to initialize tool: %v", err)
+	}
+
+	tcs := []struct {
+		desc             string
+		params           parameters.ParamValues
+		exportErr        error
+		wantBucket       string
+		wantObjectPrefix string
+		wantLimit        int
+		wantSubstr       string
+	}{
+		{
+			desc:             "bucket",
+			params:           parameters.ParamValues{{Name: "exportToGcs", Value: "gs://my-bucket"}},
+			wantBucket:       "my-bucket",
+			wantObjectPrefix: "logs-",
+		},
+		{
+			desc:             "prefix",
+			params:           parameters.ParamValues{{Name: "exportToGcs", Value: "gs://my-bucket/exports/batch"}},
+			wantBucket:       "my-bucket",
+			wantObjectPrefix: "exports/batch/logs-",
+		},
+		{
+			desc:             "prefix with trailing slash and limit",
+			params:           parameters.ParamValues{{Name: "exportToGcs", Value: "gs://my-bucket/exports/"}, {Name: "limit", Value: 5}},
+			wantBucket:       "my-bucket",
+			wantObjectPrefix: "exports/logs-",
+			wantLimit:        5,
+		},
+		{
+			desc:       "not a gs URI",
+			params:     parameters.ParamValues{{Name: "exportToGcs", Value: "https://storage.googleapis.com/my-bucket"}},
+			wantSubstr: "exportToGcs must be a Cloud Storage URI like gs://bucket/prefix",
+		},
+		{
+			desc:       "invalid bucket",
+			params:     parameters.ParamValues{{Name: "exportToGcs", Value: "gs://My_Bucket/logs"}},
+			wantSubstr: "exportToGcs must name a valid bucket",
+		},
+		{
+			desc:       "empty path segment",
+			params:     parameters.ParamValues{{Name: "exportToGcs", Value: "gs://my-bucket/a//b"}},
+			wantSubstr: "exportToGcs prefix must not contain empty path segments",
+		},
+		{
+			desc:       "combined with summarize",
+			params:     parameters.ParamValues{{Name: "exportToGcs", Value: "gs://my-bucket"}, {Name: "summarize", Value: true}},
+			wantSubstr: "exportToGcs cannot be combined with summarize",
+		},
+		{
+			desc:       "combined with ndjson",
+			params:     parameters.ParamValues{{Name: "exportToGcs", Value: "gs://my-bucket"}, {Name: "outputFormat", Value: "ndjson"}},
+			wantSubstr: "exportToGcs cannot be combined with outputFormat ndjson",
+		},
+		{
+			desc:       "permission denied",
+			params:     parameters.ParamValues{{Name: "exportToGcs", Value: "gs://my-bucket/logs"}},
+			exportErr:  &googleapi.Error{Code: http.StatusForbidden, Message: "permission denied"},
+			wantSubstr: "permission denied writing gs://my-bucket/logs/logs-",
+		},
+		{
+			desc:       "missing bucket",
+			params:     parameters.ParamValues{{Name: "exportToGcs", Value: "gs://my-bucket"}},
+			exportErr:  &googleapi.Error{Code: http.StatusNotFound, Message: "not found"},
+			wantSubstr: "the bucket does not exist",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			src := &mockSource{entries: []map[string]any{{"payload": "a"}, {"payload": "b"}}, exportErr: tc.exportErr}
+			resourceMgr := &mockSourceProvider{source: src}
+			got, toolErr := tool.Invoke(context.Background(), resourceMgr, tc.params, "")
+			if tc.wantSubstr != "" {
+				if toolErr == nil {
+					t.Fatalf("expected error, got nil")
+				}
+				if _, ok := toolErr.(*util.AgentError); !ok {
+					t.Fatalf("expected *AgentError, got %T: %v", toolErr, toolErr)
+				}
+				if !strings.Contains(toolErr.Error(), tc.wantSubstr) {
+					t.Errorf("error %q does not contain %q", toolErr, tc.wantSubstr)
+				}
+				return
+			}
+			if toolErr != nil {
+				t.Fatalf("unexpected error: %v", toolErr)
+			}
+			if src.gotBucket != tc.wantBucket || !strings.HasPrefix(src.gotObject, tc.wantObjectPrefix) || !strings.HasSuffix(src.gotObject, ".ndjson") {
+				t.Errorf("exported to gs://%s/%s, want gs://%s/%s*.ndjson", src.gotBucket, src.gotObject, tc.wantBucket, tc.wantObjectPrefix)
+			}
+			if src.gotParams.Limit != tc.wantLimit {
+				t.Errorf("got limit %d, want %d", src.gotParams.Limit, tc.wantLimit)
+			}
+			want := map[string]any{"uri": "gs://" + src.gotBucket + "/" + src.gotObject, "entryCount": 2}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("incorrect result (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestInvokeSignedURL(t *testing.T) {
+	cfg := cloudloggingadminquerylogs.Config{
+		ConfigBase: tools.ConfigBase{
+			Name:        "example_tool",
+			Description: "query logs",
+		},
+		Type:   "cloud-logging-admin-query-logs",
+		Source: "my-logging-admin-source",
+	}
+	tool, err := cfg.Initialize(context.Background())
+	if err != nil {
+		t.Fatalf("failed to initialize tool: %v", err)
+	}
+
+	tcs := []struct {
+		desc        string
+		params      parameters.ParamValues
+		signErr     error
+		wantExpiry  time.Duration
+		wantMessage string
+		wantSubstr  string
+	}{
+		{
+			desc:       "default expiry",
+			params:     parameters.ParamValues{{Name: "exportToGcs", Value: "gs://my-bucket"}, {Name: "signedUrl", Value: true}},
+			wantExpiry: time.Hour,
+		},
+		{
+			desc:       "expiry",
+			params:     parameters.ParamValues{{Name: "exportToGcs", Value: "gs://my-bucket"}, {Name: "signedUrl", Value: true}, {Name: "signedUrlExpiry", Value: "24h"}},
+			wantExpiry: 24 * time.Hour,
+		},
+		{
+			desc:        "cannot sign",
+			params:      parameters.ParamValues{{Name: "exportToGcs", Value: "gs://my-bucket"}, {Name: "signedUrl", Value: true}},
+			signErr:     errors.New("unable to detect default GoogleAccessID"),
+			wantMessage: "no signed URL could be made for it, so only its uri is returned: unable to detect default GoogleAccessID",
+		},
+		{
+			desc:       "without exportToGcs",
+			params:     parameters.ParamValues{{Name: "signedUrl", Value: true}},
+			wantSubstr: "signedUrl requires exportToGcs",
+		},
+		{
+			desc:       "expiry without signedUrl",
+			params:     parameters.ParamValues{{Name: "exportToGcs", Value: "gs://my-bucket"}, {Name: "signedUrlExpiry", Value: "1h"}},
+			wantSubstr: "signedUrlExpiry requires signedUrl",
+		},
+		{
+			desc:       "malformed expiry",
+			params:     parameters.ParamValues{{Name: "exportToGcs", Value: "gs://my-bucket"}, {Name: "signedUrl", Value: true}, {Name: "signedUrlExpiry", Value: "1 day"}},
+			wantSubstr: "signedUrlExpiry must be a duration like 24h",
+		},
+		{
+			desc:       "expiry too long",
+			params:     parameters.ParamValues{{Name: "exportToGcs", Value: "gs://my-bucket"}, {Name: "signedUrl", Value: true}, {Name: "signedUrlExpiry", Value: "169h"}},
+			wantSubstr: "signedUrlExpiry must be positive and at most 168h0m0s: 169h0m0s",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			src := &mockSource{signErr: tc.signErr}
+			before := time.Now()
+			got, toolErr := tool.Invoke(context.Background(), &mockSourceProvider{source: src}, tc.params, "")
+			if tc.wantSubstr != "" {
+				if toolErr == nil || !strings.Contains(toolErr.Error(), tc.wantSubstr) {
+					t.Fatalf("expected error containing %q, got %v", tc.wantSubstr, toolErr)
+				}
+				if src.called {
+					t.Errorf("expected logs not to be exported on validation failure")
+				}
+				return
+			}
+			if toolErr != nil {
+				t.Fatalf("unexpected error: %v", toolErr)
+			}
+			result := got.(map[string]any)
+			if result["uri"] != "gs://my-bucket/"+src.gotObject {
+				t.Errorf("got uri %v, want gs://my-bucket/%s", result["uri"], src.gotObject)
+			}
+			if tc.wantMessage != "" {
+				if msg, _ := result["message"].(string); !strings.Contains(msg, tc.wantMessage) {
+					t.Errorf("got message %q, want it to contain %q", msg, tc.wantMessage)
+				}
+				if _, ok := result["signedUrl"]; ok {
+					t.Errorf("got signedUrl %v, want none", result["signedUrl"])
+				}
+				return
+			}
+			if want := "https://storage.googleapis.com/my-bucket/" + src.gotObject + "?X-Goog-Signature=abc"; result["signedUrl"] != want {
+				t.Errorf("got signedUrl %v, want %q", result["signedUrl"], want)
+			}
+			if src.gotExpires.Before(before.Add(tc.wantExpiry)) || src.gotExpires.After(time.Now().Add(tc.wantExpiry)) {
+				t.Errorf("got expiry %s, want %s from now", src.gotExpires, tc.wantExpiry)
+			}
+			if want := src.gotExpires.UTC().Format(time.RFC3339); result["signedUrlExpireTime"] != want {
+				t.Errorf("got signedUrlExpireTime %v, want %q", result["signedUrlExpireTime"], want)
+			}
+		})
+	}
+}
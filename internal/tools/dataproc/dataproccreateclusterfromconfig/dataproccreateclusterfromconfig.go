@@ -0,0 +1,162 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataproccreateclusterfromconfig
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+
+	"cloud.google.com/go/dataproc/v2/apiv1/dataprocpb"
+	"github.com/goccy/go-yaml"
+	"github.com/googleapis/mcp-toolbox/internal/sources"
+	"github.com/googleapis/mcp-toolbox/internal/sources/dataproc"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/googleapis/mcp-toolbox/internal/util"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const kind = "dataproc-create-cluster-from-config"
+
+// clusterNameRegex matches the cluster names accepted by the API: up to 51
+// characters, lowercase letters, numbers, and hyphens.
+var clusterNameRegex = regexp.MustCompile(`^[a-z]([-a-z0-9]{0,49}[a-z0-9])?$`)
+
+func init() {
+	if !tools.Register(kind, newConfig) {
+		panic(fmt.Sprintf("tool kind %q already registered", kind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{ConfigBase: tools.ConfigBase{Name: name}}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type Config struct {
+	tools.ConfigBase `yaml:",inline"`
+	Type             string                 `yaml:"type" validate:"required"`
+	Source           string                 `yaml:"source" validate:"required"`
+	Annotations      *tools.ToolAnnotations `yaml:"annotations,omitempty"`
+}
+
+// validate interface
+var _ tools.ToolConfig = Config{}
+
+// ToolConfigType returns the unique name for this tool.
+func (cfg Config) ToolConfigType() string {
+	return kind
+}
+
+// Initialize creates a new Tool instance.
+func (cfg Config) Initialize(context.Context) (tools.Tool, error) {
+	desc := cfg.Description
+	if desc == "" {
+		desc = "Creates a Dataproc cluster from a cluster config, such as one exported by the dataproc-export-cluster-config tool. The tool returns once creation has started; it can take several minutes for the cluster to be running."
+	}
+
+	allParameters := parameters.Parameters{
+		parameters.NewStringParameter("clusterName", "The short name of the new cluster, e.g. \"my-cluster\" (the project and region are inherited from the source). Must be at most 51 characters of lowercase letters, numbers, and hyphens, starting with a letter and ending with a letter or number."),
+		parameters.NewStringParameter("config", "The cluster config as YAML, using the field names of the Dataproc REST API (e.g., masterConfig, softwareConfig), as returned by the dataproc-export-cluster-config tool. Server-generated fields, such as instanceNames, are not allowed."),
+	}
+
+	return Tool{
+		BaseTool: tools.NewBaseTool(
+			cfg,
+			tools.GetAnnotationsOrDefault(cfg.Annotations, tools.NewDestructiveAnnotations),
+			tools.Manifest{Description: desc, Parameters: allParameters.Manifest(), AuthRequired: cfg.AuthRequired},
+			allParameters,
+		),
+	}, nil
+}
+
+// validate interface
+var _ tools.Tool = Tool{}
+
+// Tool is the implementation of the tool.
+type Tool struct {
+	tools.BaseTool[Config]
+}
+
+func (t Tool) ToConfig() tools.ToolConfig {
+	return t.Cfg
+}
+
+func (t Tool) validate(srcs map[string]sources.Source) error {
+	_, err := tools.GetCompatibleSourceFromMap[compatibleSource](srcs, t.Cfg.Source, t.Cfg.Name, t.Cfg.Type)
+	return err
+}
+
+func (t Tool) GetParameters(srcs map[string]sources.Source) (parameters.Parameters, error) {
+	if err := t.validate(srcs); err != nil {
+		return nil, err
+	}
+	return t.BaseTool.GetParameters(srcs)
+}
+
+func (t Tool) Manifest(srcs map[string]sources.Source) (tools.Manifest, error) {
+	if err := t.validate(srcs); err != nil {
+		return tools.Manifest{}, err
+	}
+	return t.BaseTool.Manifest(srcs)
+}
+
+type compatibleSource interface {
+	GetProject() string
+	GetRegion() string
+	CreateClusterFromConfig(context.Context, string, *dataprocpb.ClusterConfig) (map[string]any, error)
+}
+
+// Invoke executes the tool's operation.
+func (t Tool) Invoke(ctx context.Context, resourceMgr tools.SourceProvider, params parameters.ParamValues, accessToken tools.AccessToken) (_ any, toolErr util.ToolboxError) {
+	ctx, span := tools.StartInvokeSpan(ctx, t.Cfg.Type, t.Cfg.Name)
+	defer func() { tools.EndInvokeSpan(span, toolErr) }()
+
+	source, err := tools.GetCompatibleSource[compatibleSource](resourceMgr, t.Cfg.Source, t.Cfg.Name, kind)
+	if err != nil {
+		return nil, util.NewClientServerError("source used is not compatible with the tool", http.StatusInternalServerError, err)
+	}
+	span.SetAttributes(attribute.String("project", source.GetProject()), attribute.String("location", source.GetRegion()))
+
+	paramMap := params.AsMap()
+	name, _ := paramMap["clusterName"].(string)
+	if name == "" {
+		return nil, util.NewAgentError("missing required parameter: clusterName", nil)
+	}
+	if !clusterNameRegex.MatchString(name) {
+		return nil, util.NewAgentError(fmt.Sprintf("invalid clusterName %q: must be at most 51 characters of lowercase letters, numbers, and hyphens, starting with a letter and ending with a letter or number", name), nil)
+	}
+	span.SetAttributes(attribute.String("cluster_name", name))
+
+	configYAML, _ := paramMap["config"].(string)
+	if configYAML == "" {
+		return nil, util.NewAgentError("missing required parameter: config", nil)
+	}
+	config, err := dataproc.ParseClusterConfigYAML(configYAML)
+	if err != nil {
+		return nil, util.NewAgentError(err.Error(), err)
+	}
+
+	res, err := source.CreateClusterFromConfig(ctx, name, config)
+	if err != nil {
+		return nil, util.ProcessGcpError(err)
+	}
+	return res, nil
+}
@@ -0,0 +1,174 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataproccreateclusterfromconfig_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"cloud.google.com/go/dataproc/v2/apiv1/dataprocpb"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/mcp-toolbox/internal/server"
+	"github.com/googleapis/mcp-toolbox/internal/sources"
+	"github.com/googleapis/mcp-toolbox/internal/testutils"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/googleapis/mcp-toolbox/internal/tools/dataproc/dataproccreateclusterfromconfig"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+func TestParseFromYaml(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ToolConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			kind: tool
+			name: example_tool
+			type: dataproc-create-cluster-from-config
+			source: my-instance
+			description: some description
+			`,
+			want: server.ToolConfigs{
+				"example_tool": dataproccreateclusterfromconfig.Config{
+					ConfigBase: tools.ConfigBase{
+						Name:         "example_tool",
+						Description:  "some description",
+						AuthRequired: []string{},
+					},
+					Type:   "dataproc-create-cluster-from-config",
+					Source: "my-instance",
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, _, _, got, _, _, err := server.UnmarshalResourceConfig(ctx, testutils.FormatYaml(tc.in))
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+type mockSource struct {
+	sources.Source
+	gotName   string
+	gotConfig *dataprocpb.ClusterConfig
+}
+
+func (m *mockSource) GetProject() string {
+	return "my-project"
+}
+
+func (m *mockSource) GetRegion() string {
+	return "us-central1"
+}
+
+func (m *mockSource) CreateClusterFromConfig(ctx context.Context, clusterName string, config *dataprocpb.ClusterConfig) (map[string]any, error) {
+	m.gotName = clusterName
+	m.gotConfig = config
+	return map[string]any{}, nil
+}
+
+type mockSourceProvider struct {
+	tools.SourceProvider
+	source *mockSource
+}
+
+func (m *mockSourceProvider) GetSource(name string) (sources.Source, bool) {
+	return m.source, true
+}
+
+func TestInvoke(t *testing.T) {
+	cfg := dataproccreateclusterfromconfig.Config{
+		ConfigBase: tools.ConfigBase{Name: "example_tool"},
+		Type:       "dataproc-create-cluster-from-config",
+		Source:     "my-instance",
+	}
+	tool, err := cfg.Initialize(context.Background())
+	if err != nil {
+		t.Fatalf("failed to initialize tool: %v", err)
+	}
+
+	tcs := []struct {
+		desc       string
+		name       string
+		config     string
+		want       *dataprocpb.ClusterConfig
+		wantSubstr string
+	}{
+		{
+			desc:   "valid",
+			name:   "my-clone",
+			config: "masterConfig:\n  numInstances: 1\n  machineTypeUri: n1-standard-4\n",
+			want: &dataprocpb.ClusterConfig{
+				MasterConfig: &dataprocpb.InstanceGroupConfig{NumInstances: 1, MachineTypeUri: "n1-standard-4"},
+			},
+		},
+		{desc: "missing name", config: "configBucket: my-bucket\n", wantSubstr: "missing required parameter: clusterName"},
+		{desc: "invalid name", name: "My_Cluster", config: "configBucket: my-bucket\n", wantSubstr: `invalid clusterName "My_Cluster"`},
+		{desc: "name too long", name: strings.Repeat("a", 52), config: "configBucket: my-bucket\n", wantSubstr: "invalid clusterName"},
+		{desc: "missing config", name: "my-clone", wantSubstr: "missing required parameter: config"},
+		{desc: "unknown field", name: "my-clone", config: "masterConfg: {}\n", wantSubstr: "config is not a valid cluster config"},
+		{
+			desc:       "output-only field",
+			name:       "my-clone",
+			config:     "masterConfig:\n  instanceNames: [my-cluster-m]\n",
+			wantSubstr: "config sets output-only fields: masterConfig.instanceNames",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			src := &mockSource{}
+			params := parameters.ParamValues{
+				{Name: "clusterName", Value: tc.name},
+				{Name: "config", Value: tc.config},
+			}
+			_, toolErr := tool.Invoke(context.Background(), &mockSourceProvider{source: src}, params, "")
+			if tc.wantSubstr != "" {
+				if toolErr == nil || !strings.Contains(toolErr.Error(), tc.wantSubstr) {
+					t.Fatalf("expected error containing %q, got %v", tc.wantSubstr, toolErr)
+				}
+				if src.gotConfig != nil {
+					t.Errorf("expected source not to be called on validation failure")
+				}
+				return
+			}
+			if toolErr != nil {
+				t.Fatalf("unexpected error: %v", toolErr)
+			}
+			if src.gotName != tc.name {
+				t.Errorf("got cluster name %q, want %q", src.gotName, tc.name)
+			}
+			if diff := cmp.Diff(tc.want, src.gotConfig, protocmp.Transform()); diff != "" {
+				t.Errorf("incorrect config (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataprocexportclusterconfig_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/mcp-toolbox/internal/server"
+	"github.com/googleapis/mcp-toolbox/internal/sources"
+	"github.com/googleapis/mcp-toolbox/internal/testutils"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/googleapis/mcp-toolbox/internal/tools/dataproc/dataprocexportclusterconfig"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+)
+
+func TestParseFromYaml(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ToolConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			kind: tool
+			name: example_tool
+			type: dataproc-export-cluster-config
+			source: my-instance
+			description: some description
+			`,
+			want: server.ToolConfigs{
+				"example_tool": dataprocexportclusterconfig.Config{
+					ConfigBase: tools.ConfigBase{
+						Name:         "example_tool",
+						Description:  "some description",
+						AuthRequired: []string{},
+					},
+					Type:   "dataproc-export-cluster-config",
+					Source: "my-instance",
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, _, _, got, _, _, err := server.UnmarshalResourceConfig(ctx, testutils.FormatYaml(tc.in))
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+type mockSource struct {
+	sources.Source
+	gotName string
+}
+
+func (m *mockSource) GetProject() string {
+	return "my-project"
+}
+
+func (m *mockSource) GetRegion() string {
+	return "us-central1"
+}
+
+func (m *mockSource) ExportClusterConfig(ctx context.Context, clusterName string) (map[string]any, error) {
+	m.gotName = clusterName
+	return map[string]any{"clusterName": clusterName, "config": "configBucket: my-bucket\n"}, nil
+}
+
+type mockSourceProvider struct {
+	tools.SourceProvider
+	source *mockSource
+}
+
+func (m *mockSourceProvider) GetSource(name string) (sources.Source, bool) {
+	return m.source, true
+}
+
+func TestInvoke(t *testing.T) {
+	cfg := dataprocexportclusterconfig.Config{
+		ConfigBase: tools.ConfigBase{Name: "example_tool"},
+		Type:       "dataproc-export-cluster-config",
+		Source:     "my-instance",
+	}
+	tool, err := cfg.Initialize(context.Background())
+	if err != nil {
+		t.Fatalf("failed to initialize tool: %v", err)
+	}
+
+	tcs := []struct {
+		desc       string
+		name       string
+		wantSubstr string
+	}{
+		{desc: "short name", name: "my-cluster"},
+		{desc: "full name", name: "projects/my-project/regions/us-central1/clusters/my-cluster", wantSubstr: "must be a short name"},
+		{desc: "missing", wantSubstr: "missing required parameter"},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			src := &mockSource{}
+			params := parameters.ParamValues{{Name: "clusterName", Value: tc.name}}
+			got, toolErr := tool.Invoke(context.Background(), &mockSourceProvider{source: src}, params, "")
+			if tc.wantSubstr != "" {
+				if toolErr == nil || !strings.Contains(toolErr.Error(), tc.wantSubstr) {
+					t.Fatalf("expected error containing %q, got %v", tc.wantSubstr, toolErr)
+				}
+				if src.gotName != "" {
+					t.Errorf("expected source not to be called on validation failure")
+				}
+				return
+			}
+			if toolErr != nil {
+				t.Fatalf("unexpected error: %v", toolErr)
+			}
+			want := map[string]any{"clusterName": tc.name, "config": "configBucket: my-bucket\n"}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("incorrect result (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
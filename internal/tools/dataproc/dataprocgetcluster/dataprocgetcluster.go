@@ -25,6 +25,7 @@ import (
 	"github.com/googleapis/mcp-toolbox/internal/tools"
 	"github.com/googleapis/mcp-toolbox/internal/util"
 	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 const kind = "dataproc-get-cluster"
@@ -67,6 +68,7 @@ func (cfg Config) Initialize(context.Context) (tools.Tool, error) {
 
 	allParameters := parameters.Parameters{
 		parameters.NewStringParameter("clusterName", "The short name of the cluster, e.g. for \"projects/my-project/regions/us-central1/clusters/my-cluster\", pass \"my-cluster\" (the project and region are inherited from the source)", parameters.WithStringRequired(false)),
+		parameters.NewStringParameter("clusterUuid", "The UUID of the cluster, e.g. \"a1b2c3d4-e5f6-7890-1234-567890abcdef\". Use this instead of clusterName to identify a cluster unambiguously, since cluster names may be reused after deletion", parameters.WithStringRequired(false)),
 	}
 
 	return Tool{
@@ -111,24 +113,44 @@ func (t Tool) Manifest(srcs map[string]sources.Source) (tools.Manifest, error) {
 }
 
 type compatibleSource interface {
+	GetProject() string
+	GetRegion() string
 	GetCluster(context.Context, string) (any, error)
+	GetClusterByUUID(context.Context, string) (any, error)
 }
 
 // Invoke executes the tool's operation.
-func (t Tool) Invoke(ctx context.Context, resourceMgr tools.SourceProvider, params parameters.ParamValues, accessToken tools.AccessToken) (any, util.ToolboxError) {
+func (t Tool) Invoke(ctx context.Context, resourceMgr tools.SourceProvider, params parameters.ParamValues, accessToken tools.AccessToken) (_ any, toolErr util.ToolboxError) {
+	ctx, span := tools.StartInvokeSpan(ctx, t.Cfg.Type, t.Cfg.Name)
+	defer func() { tools.EndInvokeSpan(span, toolErr) }()
+
 	source, err := tools.GetCompatibleSource[compatibleSource](resourceMgr, t.Cfg.Source, t.Cfg.Name, kind)
 	if err != nil {
 		return nil, util.NewClientServerError("source used is not compatible with the tool", http.StatusInternalServerError, err)
 	}
+	span.SetAttributes(attribute.String("project", source.GetProject()), attribute.String("location", source.GetRegion()))
 
 	paramMap := params.AsMap()
-	name, ok := paramMap["clusterName"].(string)
-	if !ok {
-		return nil, util.NewAgentError("missing required parameter: clusterName", nil)
+	name, _ := paramMap["clusterName"].(string)
+	uuid, _ := paramMap["clusterUuid"].(string)
+	if name != "" && uuid != "" {
+		return nil, util.NewAgentError("clusterName and clusterUuid are mutually exclusive", nil)
+	}
+	if uuid != "" {
+		span.SetAttributes(attribute.String("cluster_uuid", uuid))
+		res, err := source.GetClusterByUUID(ctx, uuid)
+		if err != nil {
+			return nil, util.ProcessGcpError(err)
+		}
+		return res, nil
+	}
+	if name == "" {
+		return nil, util.NewAgentError("missing required parameter: clusterName or clusterUuid", nil)
 	}
 	if strings.Contains(name, "/") {
 		return nil, util.NewAgentError(fmt.Sprintf("clusterName must be a short name without '/': %s", name), nil)
 	}
+	span.SetAttributes(attribute.String("cluster_name", name))
 
 	res, err := source.GetCluster(ctx, name)
 	if err != nil {
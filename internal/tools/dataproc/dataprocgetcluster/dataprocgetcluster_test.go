@@ -15,13 +15,17 @@
 package dataprocgetcluster_test
 
 import (
+	"context"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/googleapis/mcp-toolbox/internal/server"
+	"github.com/googleapis/mcp-toolbox/internal/sources"
 	"github.com/googleapis/mcp-toolbox/internal/testutils"
 	"github.com/googleapis/mcp-toolbox/internal/tools"
 	"github.com/googleapis/mcp-toolbox/internal/tools/dataproc/dataprocgetcluster"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
 )
 
 func TestParseFromYaml(t *testing.T) {
@@ -69,3 +73,84 @@ func TestParseFromYaml(t *testing.T) {
 		})
 	}
 }
+
+type mockSource struct {
+	sources.Source
+	gotName string
+	gotUUID string
+}
+
+func (m *mockSource) GetProject() string {
+	return "my-project"
+}
+
+func (m *mockSource) GetRegion() string {
+	return "us-central1"
+}
+
+func (m *mockSource) GetCluster(ctx context.Context, clusterName string) (any, error) {
+	m.gotName = clusterName
+	return map[string]any{}, nil
+}
+
+func (m *mockSource) GetClusterByUUID(ctx context.Context, clusterUUID string) (any, error) {
+	m.gotUUID = clusterUUID
+	return map[string]any{}, nil
+}
+
+type mockSourceProvider struct {
+	tools.SourceProvider
+	source *mockSource
+}
+
+func (m *mockSourceProvider) GetSource(name string) (sources.Source, bool) {
+	return m.source, true
+}
+
+func TestInvokeClusterIdentifier(t *testing.T) {
+	cfg := dataprocgetcluster.Config{
+		ConfigBase: tools.ConfigBase{Name: "example_tool"},
+		Type:       "dataproc-get-cluster",
+		Source:     "my-instance",
+	}
+	tool, err := cfg.Initialize(context.Background())
+	if err != nil {
+		t.Fatalf("failed to initialize tool: %v", err)
+	}
+
+	tcs := []struct {
+		desc       string
+		name       string
+		uuid       string
+		wantName   string
+		wantUUID   string
+		wantSubstr string
+	}{
+		{desc: "by name", name: "my-cluster", wantName: "my-cluster"},
+		{desc: "by uuid", uuid: "a1b2c3d4", wantUUID: "a1b2c3d4"},
+		{desc: "both", name: "my-cluster", uuid: "a1b2c3d4", wantSubstr: "mutually exclusive"},
+		{desc: "neither", wantSubstr: "missing required parameter"},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			src := &mockSource{}
+			params := parameters.ParamValues{
+				{Name: "clusterName", Value: tc.name},
+				{Name: "clusterUuid", Value: tc.uuid},
+			}
+			_, toolErr := tool.Invoke(context.Background(), &mockSourceProvider{source: src}, params, "")
+			if tc.wantSubstr != "" {
+				if toolErr == nil || !strings.Contains(toolErr.Error(), tc.wantSubstr) {
+					t.Fatalf("expected error containing %q, got %v", tc.wantSubstr, toolErr)
+				}
+				return
+			}
+			if toolErr != nil {
+				t.Fatalf("unexpected error: %v", toolErr)
+			}
+			if src.gotName != tc.wantName || src.gotUUID != tc.wantUUID {
+				t.Errorf("got (name %q, uuid %q), want (name %q, uuid %q)", src.gotName, src.gotUUID, tc.wantName, tc.wantUUID)
+			}
+		})
+	}
+}
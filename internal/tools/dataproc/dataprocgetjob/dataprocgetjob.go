@@ -25,6 +25,7 @@ import (
 	"github.com/googleapis/mcp-toolbox/internal/tools"
 	"github.com/googleapis/mcp-toolbox/internal/util"
 	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 const kind = "dataproc-get-job"
@@ -110,15 +111,21 @@ func (t Tool) Manifest(srcs map[string]sources.Source) (tools.Manifest, error) {
 }
 
 type compatibleSource interface {
+	GetProject() string
+	GetRegion() string
 	GetJob(context.Context, string) (any, error)
 }
 
 // Invoke executes the tool's operation.
-func (t Tool) Invoke(ctx context.Context, resourceMgr tools.SourceProvider, params parameters.ParamValues, accessToken tools.AccessToken) (any, util.ToolboxError) {
+func (t Tool) Invoke(ctx context.Context, resourceMgr tools.SourceProvider, params parameters.ParamValues, accessToken tools.AccessToken) (_ any, toolErr util.ToolboxError) {
+	ctx, span := tools.StartInvokeSpan(ctx, t.Cfg.Type, t.Cfg.Name)
+	defer func() { tools.EndInvokeSpan(span, toolErr) }()
+
 	source, err := tools.GetCompatibleSource[compatibleSource](resourceMgr, t.Cfg.Source, t.Cfg.Name, kind)
 	if err != nil {
 		return nil, util.NewClientServerError("source used is not compatible with the tool", http.StatusInternalServerError, err)
 	}
+	span.SetAttributes(attribute.String("project", source.GetProject()), attribute.String("location", source.GetRegion()))
 
 	paramMap := params.AsMap()
 	jobId, ok := paramMap["jobId"].(string)
@@ -128,6 +135,7 @@ func (t Tool) Invoke(ctx context.Context, resourceMgr tools.SourceProvider, para
 	if strings.Contains(jobId, "/") {
 		return nil, util.NewAgentError(fmt.Sprintf("jobId must be a short name without '/': %s", jobId), nil)
 	}
+	span.SetAttributes(attribute.String("job_id", jobId))
 
 	res, err := source.GetJob(ctx, jobId)
 	if err != nil {
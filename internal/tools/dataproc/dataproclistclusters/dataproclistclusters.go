@@ -24,6 +24,7 @@ import (
 	"github.com/googleapis/mcp-toolbox/internal/tools"
 	"github.com/googleapis/mcp-toolbox/internal/util"
 	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 const kind = "dataproc-list-clusters"
@@ -66,8 +67,9 @@ func (cfg Config) Initialize(context.Context) (tools.Tool, error) {
 
 	allParameters := parameters.Parameters{
 		parameters.NewStringParameter("filter", `A filter constraining the clusters to list. Filters are case-sensitive and have the following syntax: field = value [AND [field = value]] ...  where field is one of status.state, clusterName, or labels.[KEY], and [KEY] is a label key. value can be * to match all values. status.state can be one of the following: ACTIVE, INACTIVE, CREATING, RUNNING, ERROR, DELETING, UPDATING, STOPPING, or STOPPED. ACTIVE contains the CREATING, UPDATING, and RUNNING states. INACTIVE contains the DELETING, ERROR, STOPPING, and STOPPED states. clusterName is the name of the cluster provided at creation time. Only the logical AND operator is supported; space-separated items are treated as having an implicit AND operator.`, parameters.WithStringRequired(false)),
-		parameters.NewIntParameter("pageSize", "The maximum number of clusters to return in a single page (default 20)", parameters.WithIntDefault(20)),
+		parameters.NewBoundedIntParameter("pageSize", 1, parameters.MaxAPIPageSize, 20, fmt.Sprintf("The maximum number of clusters to return in a single page, between 1 and %d (default 20)", parameters.MaxAPIPageSize)),
 		parameters.NewStringParameter("pageToken", "A page token, received from a previous `ListClusters` call", parameters.WithStringRequired(false)),
+		parameters.NewBooleanParameter("summary", "Set to true to return the number of clusters in each state, e.g. {\"RUNNING\": 3, \"ERROR\": 1}, counted across all pages, instead of the clusters. pageSize sets the size of the pages fetched, and pageToken is ignored. Defaults to false.", parameters.WithBooleanDefault(false)),
 	}
 
 	return Tool{
@@ -112,28 +114,39 @@ func (t Tool) Manifest(srcs map[string]sources.Source) (tools.Manifest, error) {
 }
 
 type compatibleSource interface {
+	GetProject() string
+	GetRegion() string
 	ListClusters(context.Context, *int, string, string) (any, error)
+	SummarizeClusters(context.Context, *int, string) (map[string]int, error)
 }
 
 // Invoke executes the tool's operation.
-func (t Tool) Invoke(ctx context.Context, resourceMgr tools.SourceProvider, params parameters.ParamValues, accessToken tools.AccessToken) (any, util.ToolboxError) {
+func (t Tool) Invoke(ctx context.Context, resourceMgr tools.SourceProvider, params parameters.ParamValues, accessToken tools.AccessToken) (_ any, toolErr util.ToolboxError) {
+	ctx, span := tools.StartInvokeSpan(ctx, t.Cfg.Type, t.Cfg.Name)
+	defer func() { tools.EndInvokeSpan(span, toolErr) }()
+
 	source, err := tools.GetCompatibleSource[compatibleSource](resourceMgr, t.Cfg.Source, t.Cfg.Name, kind)
 	if err != nil {
 		return nil, util.NewClientServerError("source used is not compatible with the tool", http.StatusInternalServerError, err)
 	}
+	span.SetAttributes(attribute.String("project", source.GetProject()), attribute.String("location", source.GetRegion()))
 
 	paramMap := params.AsMap()
 	var pageSize *int
-	if ps, ok := paramMap["pageSize"]; ok && ps != nil {
-		pageSizeV := ps.(int)
-		if pageSizeV <= 0 {
-			return nil, util.NewAgentError(fmt.Sprintf("pageSize must be positive: %d", pageSizeV), nil)
-		}
-		pageSize = &pageSizeV
+	if ps, ok := paramMap["pageSize"].(int); ok {
+		pageSize = &ps
 	}
 	pt, _ := paramMap["pageToken"].(string)
 	filter, _ := paramMap["filter"].(string)
 
+	if summary, _ := paramMap["summary"].(bool); summary {
+		counts, err := source.SummarizeClusters(ctx, pageSize, filter)
+		if err != nil {
+			return nil, util.ProcessGcpError(err)
+		}
+		return counts, nil
+	}
+
 	res, err := source.ListClusters(ctx, pageSize, pt, filter)
 	if err != nil {
 		return nil, util.ProcessGcpError(err)
@@ -15,6 +15,7 @@
 package dataproclistclusters_test
 
 import (
+	"context"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -69,3 +70,35 @@ func TestParseFromYaml(t *testing.T) {
 		})
 	}
 }
+
+func TestAuthorized(t *testing.T) {
+	cfg := dataproclistclusters.Config{
+		ConfigBase: tools.ConfigBase{
+			Name:         "example_tool",
+			AuthRequired: []string{"my-google-auth"},
+		},
+		Type:   "dataproc-list-clusters",
+		Source: "my-instance",
+	}
+	tool, err := cfg.Initialize(context.Background())
+	if err != nil {
+		t.Fatalf("failed to initialize tool: %v", err)
+	}
+
+	tcs := []struct {
+		desc     string
+		services []string
+		want     bool
+	}{
+		{desc: "required service verified", services: []string{"my-google-auth"}, want: true},
+		{desc: "other service verified", services: []string{"other-auth"}, want: false},
+		{desc: "no service verified", services: nil, want: false},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := tool.Authorized(tc.services); got != tc.want {
+				t.Errorf("Authorized(%v) = %v, want %v", tc.services, got, tc.want)
+			}
+		})
+	}
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/googleapis/mcp-toolbox/internal/tools"
 	"github.com/googleapis/mcp-toolbox/internal/util"
 	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 const kind = "dataproc-list-jobs"
@@ -67,7 +68,7 @@ func (cfg Config) Initialize(context.Context) (tools.Tool, error) {
 	allParameters := parameters.Parameters{
 		parameters.NewStringParameter("filter", `A filter constraining the jobs to list. Filters are case-sensitive and have the following syntax: field = value [AND [field = value]] ... where field is clusterName, status.state, or labels.[KEY], and [KEY] is a label key. value can be * to match all values. status.state can be one of the following: PENDING, RUNNING, CANCEL_PENDING, JOB_STATE_CANCELLED, DONE, ERROR, or ATTEMPT_FAILURE. Only the logical AND operator is supported; space-separated items are treated as having an implicit AND operator. Filtering by clusterName is recommended to improve query performance.`, parameters.WithStringRequired(false)),
 		parameters.NewStringParameter("jobStateMatcher", "Specifies if the job state matcher should match ALL jobs, only ACTIVE jobs, or only NON_ACTIVE jobs. Defaults to ALL. Supported values: ALL, ACTIVE, NON_ACTIVE.", parameters.WithStringRequired(false)),
-		parameters.NewIntParameter("pageSize", "The maximum number of jobs to return in a single page (default 20)", parameters.WithIntDefault(20)),
+		parameters.NewBoundedIntParameter("pageSize", 1, parameters.MaxAPIPageSize, 20, fmt.Sprintf("The maximum number of jobs to return in a single page, between 1 and %d (default 20)", parameters.MaxAPIPageSize)),
 		parameters.NewStringParameter("pageToken", "A page token, received from a previous `ListJobs` call", parameters.WithStringRequired(false)),
 	}
 
@@ -113,24 +114,26 @@ func (t Tool) Manifest(srcs map[string]sources.Source) (tools.Manifest, error) {
 }
 
 type compatibleSource interface {
+	GetProject() string
+	GetRegion() string
 	ListJobs(context.Context, *int, string, string, string) (any, error)
 }
 
 // Invoke executes the tool's operation.
-func (t Tool) Invoke(ctx context.Context, resourceMgr tools.SourceProvider, params parameters.ParamValues, accessToken tools.AccessToken) (any, util.ToolboxError) {
+func (t Tool) Invoke(ctx context.Context, resourceMgr tools.SourceProvider, params parameters.ParamValues, accessToken tools.AccessToken) (_ any, toolErr util.ToolboxError) {
+	ctx, span := tools.StartInvokeSpan(ctx, t.Cfg.Type, t.Cfg.Name)
+	defer func() { tools.EndInvokeSpan(span, toolErr) }()
+
 	source, err := tools.GetCompatibleSource[compatibleSource](resourceMgr, t.Cfg.Source, t.Cfg.Name, kind)
 	if err != nil {
 		return nil, util.NewClientServerError("source used is not compatible with the tool", http.StatusInternalServerError, err)
 	}
+	span.SetAttributes(attribute.String("project", source.GetProject()), attribute.String("location", source.GetRegion()))
 
 	paramMap := params.AsMap()
 	var pageSize *int
-	if ps, ok := paramMap["pageSize"]; ok && ps != nil {
-		pageSizeV := ps.(int)
-		if pageSizeV <= 0 {
-			return nil, util.NewAgentError(fmt.Sprintf("pageSize must be positive: %d", pageSizeV), nil)
-		}
-		pageSize = &pageSizeV
+	if ps, ok := paramMap["pageSize"].(int); ok {
+		pageSize = &ps
 	}
 	pt, _ := paramMap["pageToken"].(string)
 	filter, _ := paramMap["filter"].(string)
@@ -36,7 +36,11 @@ func unmarshalProto(data any, m proto.Message) error {
 }
 
 type compatibleSource interface {
-	CreateBatch(context.Context, *dataprocpb.Batch) (map[string]any, error)
+	GetProject() string
+	GetLocation() string
+	GetUniverseDomain() string
+	GetDefaultLabels() map[string]string
+	CreateBatch(context.Context, string, *dataprocpb.Batch, string) (map[string]any, error)
 }
 
 // Config is a common config that can be used with any type of create batch tool. However, each tool
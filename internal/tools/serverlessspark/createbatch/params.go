@@ -0,0 +1,234 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package createbatch
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	dataprocpb "cloud.google.com/go/dataproc/v2/apiv1/dataprocpb"
+	"github.com/googleapis/mcp-toolbox/internal/sources/serverlessspark"
+	"github.com/googleapis/mcp-toolbox/internal/util"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+)
+
+// commonParameters returns the parameters accepted by every create batch tool,
+// in addition to those defined by its BatchBuilder.
+func commonParameters() parameters.Parameters {
+	return parameters.Parameters{
+		parameters.NewStringParameter("version", "Optional. The Serverless runtime version to execute with, e.g. 2.2.", parameters.WithStringRequired(false)),
+		parameters.NewMapParameter("properties", "Optional. Spark properties to set on the batch, e.g. {\"spark.executor.memory\": \"4g\"}. These are added to any properties configured on the tool, overriding properties with the same key.", "string", parameters.WithMapRequired(false)),
+		parameters.NewMapParameter("labels", "Optional. Labels to attach to the batch, e.g. for cost attribution. Keys must start with a lowercase letter, and keys and values may contain at most 63 lowercase letters, digits, underscores, or dashes.", "string", parameters.WithMapRequired(false)),
+		parameters.NewStringParameter("subnetwork", "Optional. The VPC subnetwork to run the batch in, either as a full resource URI (projects/PROJECT/regions/REGION/subnetworks/NAME) or as a short subnetwork name in the source's project and location.", parameters.WithStringRequired(false)),
+		parameters.NewArrayParameter("networkTags", "Optional. A list of network tags to apply to the batch's VMs, e.g. to match firewall rules.", parameters.NewStringParameter("networkTag", "A network tag."), parameters.WithArrayRequired(false)),
+		parameters.NewStringParameter("serviceAccount", "Optional. The email of the service account the batch runs as. If unset, the Dataproc default service account is used.", parameters.WithStringRequired(false)),
+		parameters.NewArrayParameter("autotuningScenarios", fmt.Sprintf("Optional. Scenarios for Serverless Spark autotuning to optimize the batch for, from %s. If unset, autotuning is not enabled.", strings.Join(autotuningScenarioNames(), ", ")), parameters.NewStringParameter("scenario", "An autotuning scenario."), parameters.WithArrayRequired(false)),
+		parameters.NewStringParameter("historyServerCluster", "Optional. The Dataproc cluster to use as a Persistent History Server, to retain the Spark UI after the batch finishes, either as a full resource name (projects/PROJECT/regions/REGION/clusters/NAME) or as a short cluster name in the source's project and location.", parameters.WithStringRequired(false)),
+		parameters.NewStringParameter("batchId", "Optional. The ID to use for the batch, which becomes the last part of its name. Must be 4-63 lowercase letters, numbers, and hyphens. If unset, an ID is generated.", parameters.WithStringRequired(false)),
+		parameters.NewStringParameter("requestId", "Optional. A unique ID for this request, e.g. a UUID, of at most 40 letters, numbers, underscores, and hyphens. If a batch was already created with this ID, it is not created again, so pass the requestId returned by a previous call when retrying it, e.g. after a timeout. If unset, an ID is generated.", parameters.WithStringRequired(false)),
+		parameters.NewBooleanParameter("dryRun", "Optional. If true, validate the inputs and return the request that would be sent, without creating the batch.", parameters.WithBooleanDefault(false)),
+	}
+}
+
+var (
+	// runtimeVersionRegex matches Serverless runtime versions, e.g. 2.2 or 2.2.45.
+	runtimeVersionRegex = regexp.MustCompile(`^\d+\.\d+(\.\d+)?$`)
+	subnetworkURIRegex  = regexp.MustCompile(`^(https://www\.googleapis\.com/compute/v1/)?projects/[^/]+/regions/[^/]+/subnetworks/[^/]+$`)
+	clusterNameRegex    = regexp.MustCompile(`^projects/[^/]+/regions/[^/]+/clusters/[^/]+$`)
+	// rfc1035NameRegex matches the names Compute Engine accepts for subnetworks and network tags.
+	rfc1035NameRegex = regexp.MustCompile(`^[a-z]([-a-z0-9]{0,61}[a-z0-9])?$`)
+	// requestIDRegex matches the request IDs the Dataproc API accepts.
+	requestIDRegex = regexp.MustCompile(`^[a-zA-Z0-9_-]{1,40}$`)
+	// serviceAccountRegex matches user-managed and Google-managed service account emails.
+	serviceAccountRegex = regexp.MustCompile(`^[a-zA-Z0-9._-]+@[a-zA-Z0-9.-]+\.gserviceaccount\.com$`)
+)
+
+// autotuningScenarioNames returns the names of the autotuning scenarios that can be requested,
+// in the order they are defined by the API.
+func autotuningScenarioNames() []string {
+	values := make([]int32, 0, len(dataprocpb.AutotuningConfig_Scenario_name))
+	for v := range dataprocpb.AutotuningConfig_Scenario_name {
+		if v != int32(dataprocpb.AutotuningConfig_SCENARIO_UNSPECIFIED) {
+			values = append(values, v)
+		}
+	}
+	slices.Sort(values)
+	names := make([]string, len(values))
+	for i, v := range values {
+		names[i] = dataprocpb.AutotuningConfig_Scenario_name[v]
+	}
+	return names
+}
+
+// parseAutotuningScenarios returns the autotuning scenarios with the given names, ignoring case
+// and duplicates.
+func parseAutotuningScenarios(rawScenarios []any) ([]dataprocpb.AutotuningConfig_Scenario, error) {
+	scenarios := make([]dataprocpb.AutotuningConfig_Scenario, 0, len(rawScenarios))
+	for _, rawScenario := range rawScenarios {
+		name := strings.ToUpper(strings.TrimSpace(fmt.Sprintf("%v", rawScenario)))
+		v, ok := dataprocpb.AutotuningConfig_Scenario_value[name]
+		if !ok || v == int32(dataprocpb.AutotuningConfig_SCENARIO_UNSPECIFIED) {
+			return nil, fmt.Errorf("invalid autotuning scenario %q: must be one of %s", rawScenario, strings.Join(autotuningScenarioNames(), ", "))
+		}
+		scenario := dataprocpb.AutotuningConfig_Scenario(v)
+		if !slices.Contains(scenarios, scenario) {
+			scenarios = append(scenarios, scenario)
+		}
+	}
+	return scenarios, nil
+}
+
+// validateRequestID returns an error if requestID is not a valid request ID.
+func validateRequestID(requestID string) error {
+	if !requestIDRegex.MatchString(requestID) {
+		return fmt.Errorf("requestId must be at most 40 letters, numbers, underscores, and hyphens: %q", requestID)
+	}
+	return nil
+}
+
+// resolveSubnetwork returns the full resource URI for the given subnetwork, expanding a short
+// subnetwork name into a URI in the given project and location.
+func resolveSubnetwork(subnetwork, project, location string) (string, error) {
+	if subnetworkURIRegex.MatchString(subnetwork) {
+		return subnetwork, nil
+	}
+	if rfc1035NameRegex.MatchString(subnetwork) {
+		return fmt.Sprintf("projects/%s/regions/%s/subnetworks/%s", project, location, subnetwork), nil
+	}
+	return "", fmt.Errorf("invalid subnetwork %q: must be a full resource URI like projects/PROJECT/regions/REGION/subnetworks/NAME or a short subnetwork name", subnetwork)
+}
+
+// resolveHistoryServerCluster returns the full resource name for the given Persistent History
+// Server cluster, expanding a short cluster name into a name in the given project and location.
+func resolveHistoryServerCluster(cluster, project, location string) (string, error) {
+	if clusterNameRegex.MatchString(cluster) {
+		return cluster, nil
+	}
+	if rfc1035NameRegex.MatchString(cluster) {
+		return fmt.Sprintf("projects/%s/regions/%s/clusters/%s", project, location, cluster), nil
+	}
+	return "", fmt.Errorf("invalid historyServerCluster %q: must be a full resource name like projects/PROJECT/regions/REGION/clusters/NAME or a short cluster name", cluster)
+}
+
+// runtimeConfig returns the batch's runtime config, creating it if necessary.
+func runtimeConfig(batch *dataprocpb.Batch) *dataprocpb.RuntimeConfig {
+	if batch.RuntimeConfig == nil {
+		batch.RuntimeConfig = &dataprocpb.RuntimeConfig{}
+	}
+	return batch.RuntimeConfig
+}
+
+// executionConfig returns the batch's execution config, creating it if necessary.
+func executionConfig(batch *dataprocpb.Batch) *dataprocpb.ExecutionConfig {
+	if batch.EnvironmentConfig == nil {
+		batch.EnvironmentConfig = &dataprocpb.EnvironmentConfig{}
+	}
+	if batch.EnvironmentConfig.ExecutionConfig == nil {
+		batch.EnvironmentConfig.ExecutionConfig = &dataprocpb.ExecutionConfig{}
+	}
+	return batch.EnvironmentConfig.ExecutionConfig
+}
+
+// peripheralsConfig returns the batch's peripherals config, creating it if necessary.
+func peripheralsConfig(batch *dataprocpb.Batch) *dataprocpb.PeripheralsConfig {
+	if batch.EnvironmentConfig == nil {
+		batch.EnvironmentConfig = &dataprocpb.EnvironmentConfig{}
+	}
+	if batch.EnvironmentConfig.PeripheralsConfig == nil {
+		batch.EnvironmentConfig.PeripheralsConfig = &dataprocpb.PeripheralsConfig{}
+	}
+	return batch.EnvironmentConfig.PeripheralsConfig
+}
+
+// applyCommonParameters validates the common parameters and sets them on the batch. Parameters
+// that are not set leave the corresponding batch fields unchanged.
+func applyCommonParameters(batch *dataprocpb.Batch, paramMap map[string]any, project, location string) error {
+	if version, ok := paramMap["version"].(string); ok && version != "" {
+		if !runtimeVersionRegex.MatchString(version) {
+			return fmt.Errorf("invalid version %q: must be a Serverless runtime version like 2.2", version)
+		}
+		runtimeConfig(batch).Version = version
+	}
+
+	if rawProperties, ok := paramMap["properties"].(map[string]any); ok && len(rawProperties) > 0 {
+		rc := runtimeConfig(batch)
+		if rc.Properties == nil {
+			rc.Properties = make(map[string]string, len(rawProperties))
+		}
+		for k, v := range rawProperties {
+			if strings.TrimSpace(k) == "" {
+				return fmt.Errorf("invalid properties: keys must not be empty")
+			}
+			rc.Properties[k] = fmt.Sprintf("%v", v)
+		}
+	}
+
+	if rawLabels, ok := paramMap["labels"].(map[string]any); ok && len(rawLabels) > 0 {
+		labels := make(map[string]string, len(rawLabels))
+		for k, v := range rawLabels {
+			labels[k] = fmt.Sprintf("%v", v)
+		}
+		if err := util.ValidateLabels(labels, serverlessspark.MaxLabels); err != nil {
+			return err
+		}
+		batch.Labels = labels
+	}
+
+	if subnetwork, ok := paramMap["subnetwork"].(string); ok && subnetwork != "" {
+		uri, err := resolveSubnetwork(subnetwork, project, location)
+		if err != nil {
+			return err
+		}
+		executionConfig(batch).Network = &dataprocpb.ExecutionConfig_SubnetworkUri{SubnetworkUri: uri}
+	}
+
+	if rawTags, ok := paramMap["networkTags"].([]any); ok && len(rawTags) > 0 {
+		tags := make([]string, 0, len(rawTags))
+		for _, rawTag := range rawTags {
+			tag := fmt.Sprintf("%v", rawTag)
+			if !rfc1035NameRegex.MatchString(tag) {
+				return fmt.Errorf("invalid network tag %q: tags must start with a lowercase letter and contain at most 63 lowercase letters, digits, or dashes", tag)
+			}
+			tags = append(tags, tag)
+		}
+		executionConfig(batch).NetworkTags = tags
+	}
+
+	if serviceAccount, ok := paramMap["serviceAccount"].(string); ok && serviceAccount != "" {
+		if !serviceAccountRegex.MatchString(serviceAccount) {
+			return fmt.Errorf("invalid serviceAccount %q: must be a service account email like NAME@PROJECT.iam.gserviceaccount.com", serviceAccount)
+		}
+		executionConfig(batch).ServiceAccount = serviceAccount
+	}
+
+	if rawScenarios, ok := paramMap["autotuningScenarios"].([]any); ok && len(rawScenarios) > 0 {
+		scenarios, err := parseAutotuningScenarios(rawScenarios)
+		if err != nil {
+			return err
+		}
+		runtimeConfig(batch).AutotuningConfig = &dataprocpb.AutotuningConfig{Scenarios: scenarios}
+	}
+
+	if cluster, ok := paramMap["historyServerCluster"].(string); ok && cluster != "" {
+		name, err := resolveHistoryServerCluster(cluster, project, location)
+		if err != nil {
+			return err
+		}
+		peripheralsConfig(batch).SparkHistoryServerConfig = &dataprocpb.SparkHistoryServerConfig{DataprocCluster: name}
+	}
+	return nil
+}
@@ -0,0 +1,275 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package createbatch
+
+import (
+	"strings"
+	"testing"
+
+	dataprocpb "cloud.google.com/go/dataproc/v2/apiv1/dataprocpb"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+func TestApplyCommonParameters(t *testing.T) {
+	tcs := []struct {
+		desc     string
+		batch    *dataprocpb.Batch
+		paramMap map[string]any
+		want     *dataprocpb.Batch
+		wantErr  string
+	}{
+		{
+			desc:     "no parameters",
+			batch:    &dataprocpb.Batch{},
+			paramMap: map[string]any{"version": nil, "properties": nil, "labels": nil, "subnetwork": nil, "networkTags": nil, "serviceAccount": nil, "autotuningScenarios": nil, "historyServerCluster": nil},
+			want:     &dataprocpb.Batch{},
+		},
+		{
+			desc: "version overrides tool runtime config",
+			batch: &dataprocpb.Batch{
+				RuntimeConfig: &dataprocpb.RuntimeConfig{
+					Version:    "2.1",
+					Properties: map[string]string{"spark.driver.memory": "1g"},
+				},
+			},
+			paramMap: map[string]any{"version": "2.2"},
+			want: &dataprocpb.Batch{
+				RuntimeConfig: &dataprocpb.RuntimeConfig{
+					Version:    "2.2",
+					Properties: map[string]string{"spark.driver.memory": "1g"},
+				},
+			},
+		},
+		{
+			desc:     "invalid version",
+			batch:    &dataprocpb.Batch{},
+			paramMap: map[string]any{"version": "latest"},
+			wantErr:  `invalid version "latest"`,
+		},
+		{
+			desc: "properties merge with tool runtime config",
+			batch: &dataprocpb.Batch{
+				RuntimeConfig: &dataprocpb.RuntimeConfig{
+					Properties: map[string]string{"spark.driver.memory": "1g", "spark.executor.memory": "2g"},
+				},
+			},
+			paramMap: map[string]any{"properties": map[string]any{"spark.executor.memory": "4g", "spark.dynamicAllocation.enabled": "false"}},
+			want: &dataprocpb.Batch{
+				RuntimeConfig: &dataprocpb.RuntimeConfig{
+					Properties: map[string]string{
+						"spark.driver.memory":             "1g",
+						"spark.executor.memory":           "4g",
+						"spark.dynamicAllocation.enabled": "false",
+					},
+				},
+			},
+		},
+		{
+			desc:     "properties without tool runtime config",
+			batch:    &dataprocpb.Batch{},
+			paramMap: map[string]any{"properties": map[string]any{"spark.executor.cores": "4"}},
+			want: &dataprocpb.Batch{
+				RuntimeConfig: &dataprocpb.RuntimeConfig{
+					Properties: map[string]string{"spark.executor.cores": "4"},
+				},
+			},
+		},
+		{
+			desc:     "empty property key",
+			batch:    &dataprocpb.Batch{},
+			paramMap: map[string]any{"properties": map[string]any{" ": "4"}},
+			wantErr:  "keys must not be empty",
+		},
+		{
+			desc:     "labels",
+			batch:    &dataprocpb.Batch{},
+			paramMap: map[string]any{"labels": map[string]any{"team": "data-eng"}},
+			want:     &dataprocpb.Batch{Labels: map[string]string{"team": "data-eng"}},
+		},
+		{
+			desc:     "invalid labels",
+			batch:    &dataprocpb.Batch{},
+			paramMap: map[string]any{"labels": map[string]any{"Team": "data-eng"}},
+			wantErr:  `invalid label key "Team"`,
+		},
+		{
+			desc:     "short subnetwork name",
+			batch:    &dataprocpb.Batch{},
+			paramMap: map[string]any{"subnetwork": "my-subnet"},
+			want: &dataprocpb.Batch{
+				EnvironmentConfig: &dataprocpb.EnvironmentConfig{
+					ExecutionConfig: &dataprocpb.ExecutionConfig{
+						Network: &dataprocpb.ExecutionConfig_SubnetworkUri{SubnetworkUri: "projects/my-project/regions/us-central1/subnetworks/my-subnet"},
+					},
+				},
+			},
+		},
+		{
+			desc:     "full subnetwork uri",
+			batch:    &dataprocpb.Batch{},
+			paramMap: map[string]any{"subnetwork": "https://www.googleapis.com/compute/v1/projects/host-project/regions/us-east1/subnetworks/shared"},
+			want: &dataprocpb.Batch{
+				EnvironmentConfig: &dataprocpb.EnvironmentConfig{
+					ExecutionConfig: &dataprocpb.ExecutionConfig{
+						Network: &dataprocpb.ExecutionConfig_SubnetworkUri{SubnetworkUri: "https://www.googleapis.com/compute/v1/projects/host-project/regions/us-east1/subnetworks/shared"},
+					},
+				},
+			},
+		},
+		{
+			desc:     "invalid subnetwork",
+			batch:    &dataprocpb.Batch{},
+			paramMap: map[string]any{"subnetwork": "projects/p/subnetworks/s"},
+			wantErr:  `invalid subnetwork "projects/p/subnetworks/s"`,
+		},
+		{
+			desc: "network tags preserve tool environment config",
+			batch: &dataprocpb.Batch{
+				EnvironmentConfig: &dataprocpb.EnvironmentConfig{
+					ExecutionConfig: &dataprocpb.ExecutionConfig{
+						Network: &dataprocpb.ExecutionConfig_NetworkUri{NetworkUri: "my-network"},
+					},
+				},
+			},
+			paramMap: map[string]any{"networkTags": []any{"allow-ssh", "spark"}},
+			want: &dataprocpb.Batch{
+				EnvironmentConfig: &dataprocpb.EnvironmentConfig{
+					ExecutionConfig: &dataprocpb.ExecutionConfig{
+						Network:     &dataprocpb.ExecutionConfig_NetworkUri{NetworkUri: "my-network"},
+						NetworkTags: []string{"allow-ssh", "spark"},
+					},
+				},
+			},
+		},
+		{
+			desc:     "invalid network tag",
+			batch:    &dataprocpb.Batch{},
+			paramMap: map[string]any{"networkTags": []any{"Allow_SSH"}},
+			wantErr:  `invalid network tag "Allow_SSH"`,
+		},
+		{
+			desc:     "service account",
+			batch:    &dataprocpb.Batch{},
+			paramMap: map[string]any{"serviceAccount": "spark-runner@my-project.iam.gserviceaccount.com"},
+			want: &dataprocpb.Batch{
+				EnvironmentConfig: &dataprocpb.EnvironmentConfig{
+					ExecutionConfig: &dataprocpb.ExecutionConfig{
+						ServiceAccount: "spark-runner@my-project.iam.gserviceaccount.com",
+					},
+				},
+			},
+		},
+		{
+			desc: "autotuning scenarios",
+			batch: &dataprocpb.Batch{
+				RuntimeConfig: &dataprocpb.RuntimeConfig{Version: "2.2"},
+			},
+			paramMap: map[string]any{"autotuningScenarios": []any{"SCALING", "memory", "SCALING"}},
+			want: &dataprocpb.Batch{
+				RuntimeConfig: &dataprocpb.RuntimeConfig{
+					Version: "2.2",
+					AutotuningConfig: &dataprocpb.AutotuningConfig{
+						Scenarios: []dataprocpb.AutotuningConfig_Scenario{
+							dataprocpb.AutotuningConfig_SCALING,
+							dataprocpb.AutotuningConfig_MEMORY,
+						},
+					},
+				},
+			},
+		},
+		{
+			desc:     "invalid autotuning scenario",
+			batch:    &dataprocpb.Batch{},
+			paramMap: map[string]any{"autotuningScenarios": []any{"SCALING", "SPEED"}},
+			wantErr:  `invalid autotuning scenario "SPEED": must be one of SCALING, BROADCAST_HASH_JOIN, MEMORY, NONE, AUTO`,
+		},
+		{
+			desc:     "unspecified autotuning scenario",
+			batch:    &dataprocpb.Batch{},
+			paramMap: map[string]any{"autotuningScenarios": []any{"SCENARIO_UNSPECIFIED"}},
+			wantErr:  `invalid autotuning scenario "SCENARIO_UNSPECIFIED"`,
+		},
+		{
+			desc:     "short history server cluster name",
+			batch:    &dataprocpb.Batch{},
+			paramMap: map[string]any{"historyServerCluster": "my-phs"},
+			want: &dataprocpb.Batch{
+				EnvironmentConfig: &dataprocpb.EnvironmentConfig{
+					PeripheralsConfig: &dataprocpb.PeripheralsConfig{
+						SparkHistoryServerConfig: &dataprocpb.SparkHistoryServerConfig{DataprocCluster: "projects/my-project/regions/us-central1/clusters/my-phs"},
+					},
+				},
+			},
+		},
+		{
+			desc:     "full history server cluster name",
+			batch:    &dataprocpb.Batch{},
+			paramMap: map[string]any{"historyServerCluster": "projects/shared/regions/us-east1/clusters/phs"},
+			want: &dataprocpb.Batch{
+				EnvironmentConfig: &dataprocpb.EnvironmentConfig{
+					PeripheralsConfig: &dataprocpb.PeripheralsConfig{
+						SparkHistoryServerConfig: &dataprocpb.SparkHistoryServerConfig{DataprocCluster: "projects/shared/regions/us-east1/clusters/phs"},
+					},
+				},
+			},
+		},
+		{
+			desc:     "invalid history server cluster",
+			batch:    &dataprocpb.Batch{},
+			paramMap: map[string]any{"historyServerCluster": "projects/p/clusters/c"},
+			wantErr:  `invalid historyServerCluster "projects/p/clusters/c"`,
+		},
+		{
+			desc:     "invalid service account",
+			batch:    &dataprocpb.Batch{},
+			paramMap: map[string]any{"serviceAccount": "someone@example.com"},
+			wantErr:  `invalid serviceAccount "someone@example.com"`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := applyCommonParameters(tc.batch, tc.paramMap, "my-project", "us-central1")
+			if tc.wantErr != "" {
+				if err == nil {
+					t.Fatalf("expected error containing %q, got nil", tc.wantErr)
+				}
+				if !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("expected error to contain %q, got %q", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, tc.batch, protocmp.Transform()); diff != "" {
+				t.Fatalf("incorrect batch: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestValidateRequestID(t *testing.T) {
+	for _, id := range []string{"0b4f7c2e-5a1d-4b8e-9c3f-2d6e8a1b7c4d", "retry_1", strings.Repeat("a", 40)} {
+		if err := validateRequestID(id); err != nil {
+			t.Errorf("validateRequestID(%q) = %v, want nil", id, err)
+		}
+	}
+	for _, id := range []string{"", "has space", "a/b", strings.Repeat("a", 41)} {
+		if err := validateRequestID(id); err == nil {
+			t.Errorf("validateRequestID(%q) = nil, want error", id)
+		}
+	}
+}
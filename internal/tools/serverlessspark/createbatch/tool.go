@@ -17,13 +17,17 @@ package createbatch
 import (
 	"context"
 	"fmt"
+	"maps"
 	"net/http"
 
 	dataprocpb "cloud.google.com/go/dataproc/v2/apiv1/dataprocpb"
+	"github.com/google/uuid"
 	"github.com/googleapis/mcp-toolbox/internal/embeddingmodels"
+	"github.com/googleapis/mcp-toolbox/internal/sources/serverlessspark"
 	"github.com/googleapis/mcp-toolbox/internal/tools"
 	"github.com/googleapis/mcp-toolbox/internal/util"
 	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+	"go.opentelemetry.io/otel/attribute"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -38,7 +42,7 @@ func NewTool(cfg Config, originalCfg tools.ToolConfig, builder BatchBuilder) (*T
 		desc = fmt.Sprintf("Creates a Serverless Spark (aka Dataproc Serverless) %s operation.", cfg.Type)
 	}
 
-	allParameters := builder.Parameters()
+	allParameters := append(builder.Parameters(), commonParameters()...)
 
 	return &Tool{
 		BaseTool: tools.NewBaseTool(
@@ -58,11 +62,15 @@ type Tool struct {
 	Builder        BatchBuilder
 }
 
-func (t *Tool) Invoke(ctx context.Context, resourceMgr tools.SourceProvider, params parameters.ParamValues, accessToken tools.AccessToken) (any, util.ToolboxError) {
+func (t *Tool) Invoke(ctx context.Context, resourceMgr tools.SourceProvider, params parameters.ParamValues, accessToken tools.AccessToken) (_ any, toolErr util.ToolboxError) {
+	ctx, span := tools.StartInvokeSpan(ctx, t.Cfg.Type, t.Cfg.Name)
+	defer func() { tools.EndInvokeSpan(span, toolErr) }()
+
 	source, err := tools.GetCompatibleSource[compatibleSource](resourceMgr, t.Cfg.Source, t.Cfg.Name, t.Cfg.Type)
 	if err != nil {
 		return nil, util.NewClientServerError("source used is not compatible with the tool", http.StatusInternalServerError, err)
 	}
+	span.SetAttributes(attribute.String("project", source.GetProject()), attribute.String("location", source.GetLocation()))
 
 	batch, err := t.Builder.BuildBatch(params)
 	if err != nil {
@@ -80,19 +88,63 @@ func (t *Tool) Invoke(ctx context.Context, resourceMgr tools.SourceProvider, par
 		batch.EnvironmentConfig = proto.Clone(t.Cfg.EnvironmentConfig).(*dataprocpb.EnvironmentConfig)
 	}
 
-	// Common override for version if present in params
 	paramMap := params.AsMap()
-	if version, ok := paramMap["version"].(string); ok && version != "" {
-		if batch.RuntimeConfig == nil {
-			batch.RuntimeConfig = &dataprocpb.RuntimeConfig{}
+	if err := applyCommonParameters(batch, paramMap, source.GetProject(), source.GetLocation()); err != nil {
+		return nil, util.NewAgentError("failed to build batch", err)
+	}
+	if defaults := source.GetDefaultLabels(); len(defaults) > 0 {
+		// Labels passed to the tool take precedence over the defaults.
+		labels := maps.Clone(defaults)
+		maps.Copy(labels, batch.Labels)
+		if err := util.ValidateLabels(labels, serverlessspark.MaxLabels); err != nil {
+			return nil, util.NewAgentError("failed to build batch", err)
+		}
+		batch.Labels = labels
+	}
+
+	batchID, _ := paramMap["batchId"].(string)
+	if batchID != "" {
+		if err := serverlessspark.ValidateBatchID(batchID); err != nil {
+			return nil, util.NewAgentError("invalid batchId", err)
+		}
+	}
+
+	requestID, _ := paramMap["requestId"].(string)
+	if requestID != "" {
+		if err := validateRequestID(requestID); err != nil {
+			return nil, util.NewAgentError("invalid requestId", err)
 		}
-		batch.RuntimeConfig.Version = version
 	}
 
-	resp, err := source.CreateBatch(ctx, batch)
+	if dryRun, _ := paramMap["dryRun"].(bool); dryRun {
+		resp, err := serverlessspark.CreateBatchDryRun(source.GetUniverseDomain(), source.GetProject(), source.GetLocation(), batchID, batch, requestID)
+		if err != nil {
+			return nil, util.NewClientServerError("failed to describe batch request", http.StatusInternalServerError, err)
+		}
+		return resp, nil
+	}
+
+	if requestID == "" {
+		// Generate an ID so that the request can be retried with it, e.g. if
+		// the call times out before the response is seen.
+		requestID = uuid.NewString()
+		logger, err := util.LoggerFromContext(ctx)
+		if err != nil {
+			return nil, util.NewClientServerError("error getting logger", http.StatusInternalServerError, err)
+		}
+		logger.InfoContext(ctx, fmt.Sprintf("creating batch with generated requestId %s", requestID))
+	}
+
+	resp, err := source.CreateBatch(ctx, batchID, batch, requestID)
 	if err != nil {
-		return nil, util.ProcessGcpError(err)
+		return nil, serverlessspark.ProcessError(err)
+	}
+	if meta, ok := resp["opMetadata"].(*dataprocpb.BatchOperationMetadata); ok {
+		if _, _, batchID, err := serverlessspark.ExtractBatchDetails(meta.GetBatch()); err == nil {
+			span.SetAttributes(attribute.String("batch_id", batchID))
+		}
 	}
+	resp["requestId"] = requestID
 	return resp, nil
 }
 
@@ -0,0 +1,207 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package createbatch_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	dataprocpb "cloud.google.com/go/dataproc/v2/apiv1/dataprocpb"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/mcp-toolbox/internal/sources"
+	"github.com/googleapis/mcp-toolbox/internal/testutils"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/googleapis/mcp-toolbox/internal/tools/serverlessspark/createbatch"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+)
+
+type mockSource struct {
+	sources.Source
+	defaultLabels map[string]string
+	called        bool
+	gotBatchID    string
+}
+
+func (m *mockSource) GetProject() string {
+	return "my-project"
+}
+
+func (m *mockSource) GetLocation() string {
+	return "us-central1"
+}
+
+func (m *mockSource) GetUniverseDomain() string {
+	return ""
+}
+
+func (m *mockSource) GetDefaultLabels() map[string]string {
+	return m.defaultLabels
+}
+
+func (m *mockSource) CreateBatch(ctx context.Context, batchID string, batch *dataprocpb.Batch, requestID string) (map[string]any, error) {
+	m.called = true
+	m.gotBatchID = batchID
+	return map[string]any{}, nil
+}
+
+type mockSourceProvider struct {
+	tools.SourceProvider
+	source *mockSource
+}
+
+func (m *mockSourceProvider) GetSource(name string) (sources.Source, bool) {
+	return m.source, true
+}
+
+// sparkSQLBuilder builds a Spark SQL batch with no type-specific parameters.
+type sparkSQLBuilder struct{}
+
+func (sparkSQLBuilder) Parameters() parameters.Parameters {
+	return nil
+}
+
+func (sparkSQLBuilder) BuildBatch(parameters.ParamValues) (*dataprocpb.Batch, error) {
+	return &dataprocpb.Batch{
+		BatchConfig: &dataprocpb.Batch_SparkSqlBatch{SparkSqlBatch: &dataprocpb.SparkSqlBatch{QueryFileUri: "gs://bucket/query.sql"}},
+	}, nil
+}
+
+func TestInvokeDefaultLabels(t *testing.T) {
+	cfg := createbatch.Config{
+		ConfigBase: tools.ConfigBase{Name: "example_tool"},
+		Type:       "serverless-spark-create-spark-sql-batch",
+		Source:     "my-instance",
+	}
+	tool, err := createbatch.NewTool(cfg, nil, sparkSQLBuilder{})
+	if err != nil {
+		t.Fatalf("failed to create tool: %v", err)
+	}
+
+	tcs := []struct {
+		desc          string
+		defaultLabels map[string]string
+		labels        map[string]any
+		want          any
+		wantSubstr    string
+	}{
+		{
+			desc:   "no defaults",
+			labels: map[string]any{"team": "ml"},
+			want:   map[string]any{"team": "ml"},
+		},
+		{
+			desc:          "defaults only",
+			defaultLabels: map[string]string{"team": "data-eng", "env": "prod"},
+			want:          map[string]any{"team": "data-eng", "env": "prod"},
+		},
+		{
+			desc:          "tool labels take precedence",
+			defaultLabels: map[string]string{"team": "data-eng", "env": "prod"},
+			labels:        map[string]any{"team": "ml", "job": "nightly"},
+			want:          map[string]any{"team": "ml", "env": "prod", "job": "nightly"},
+		},
+		{
+			desc:          "too many merged labels",
+			defaultLabels: map[string]string{"team": "data-eng"},
+			labels:        manyLabels(32),
+			wantSubstr:    "too many labels: got 33",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			params := parameters.ParamValues{{Name: "dryRun", Value: true}}
+			if tc.labels != nil {
+				params = append(params, parameters.ParamValue{Name: "labels", Value: tc.labels})
+			}
+			got, toolErr := tool.Invoke(context.Background(), &mockSourceProvider{source: &mockSource{defaultLabels: tc.defaultLabels}}, params, "")
+			if tc.wantSubstr != "" {
+				if toolErr == nil || !strings.Contains(toolErr.Error(), tc.wantSubstr) {
+					t.Fatalf("expected error containing %q, got %v", tc.wantSubstr, toolErr)
+				}
+				return
+			}
+			if toolErr != nil {
+				t.Fatalf("unexpected error: %v", toolErr)
+			}
+			batch := got.(map[string]any)["request"].(map[string]any)["batch"].(map[string]any)
+			if diff := cmp.Diff(tc.want, batch["labels"]); diff != "" {
+				t.Errorf("incorrect labels (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestInvokeBatchID(t *testing.T) {
+	cfg := createbatch.Config{
+		ConfigBase: tools.ConfigBase{Name: "example_tool"},
+		Type:       "serverless-spark-create-spark-sql-batch",
+		Source:     "my-instance",
+	}
+	tool, err := createbatch.NewTool(cfg, nil, sparkSQLBuilder{})
+	if err != nil {
+		t.Fatalf("failed to create tool: %v", err)
+	}
+
+	tcs := []struct {
+		desc       string
+		batchID    string
+		wantSubstr string
+	}{
+		{desc: "generated", batchID: ""},
+		{desc: "valid", batchID: "nightly-etl-1"},
+		{desc: "too long", batchID: strings.Repeat("a", 64), wantSubstr: "batchId must be a batch ID of 4-63 lowercase letters, numbers, and hyphens, not 64 characters"},
+		{desc: "uppercase", batchID: "Nightly-ETL", wantSubstr: "without uppercase letters"},
+		{desc: "underscore", batchID: "nightly_etl", wantSubstr: `without '_'`},
+	}
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			src := &mockSource{}
+			var params parameters.ParamValues
+			if tc.batchID != "" {
+				params = parameters.ParamValues{{Name: "batchId", Value: tc.batchID}}
+			}
+			_, toolErr := tool.Invoke(ctx, &mockSourceProvider{source: src}, params, "")
+			if tc.wantSubstr != "" {
+				if toolErr == nil || !strings.Contains(toolErr.Error(), tc.wantSubstr) {
+					t.Fatalf("expected error containing %q, got %v", tc.wantSubstr, toolErr)
+				}
+				if src.called {
+					t.Errorf("expected source not to be called on validation failure")
+				}
+				return
+			}
+			if toolErr != nil {
+				t.Fatalf("unexpected error: %v", toolErr)
+			}
+			if src.gotBatchID != tc.batchID {
+				t.Errorf("got batch ID %q, want %q", src.gotBatchID, tc.batchID)
+			}
+		})
+	}
+}
+
+func manyLabels(n int) map[string]any {
+	labels := make(map[string]any, n)
+	for i := range n {
+		labels[fmt.Sprintf("key-%d", i)] = "value"
+	}
+	return labels
+}
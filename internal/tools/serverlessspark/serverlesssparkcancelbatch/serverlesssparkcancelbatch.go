@@ -22,9 +22,11 @@ import (
 
 	dataproc "cloud.google.com/go/dataproc/v2/apiv1"
 	"github.com/goccy/go-yaml"
+	"github.com/googleapis/mcp-toolbox/internal/sources/serverlessspark"
 	"github.com/googleapis/mcp-toolbox/internal/tools"
 	"github.com/googleapis/mcp-toolbox/internal/util"
 	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 const resourceType = "serverless-spark-cancel-batch"
@@ -44,6 +46,9 @@ func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.T
 }
 
 type compatibleSource interface {
+	GetProject() string
+	GetLocation() string
+	GetUniverseDomain() string
 	GetBatchControllerClient() *dataproc.BatchControllerClient
 	CancelOperation(context.Context, string) (any, error)
 }
@@ -72,6 +77,7 @@ func (cfg Config) Initialize(context.Context) (tools.Tool, error) {
 
 	allParameters := parameters.Parameters{
 		parameters.NewStringParameter("operation", "The name of the operation to cancel, e.g. for \"projects/my-project/locations/us-central1/operations/my-operation\", pass \"my-operation\""),
+		parameters.NewBooleanParameter("dryRun", "If true, validate the inputs and return the request that would be sent, without cancelling the operation.", parameters.WithBooleanDefault(false)),
 	}
 
 	return Tool{
@@ -93,11 +99,15 @@ type Tool struct {
 }
 
 // Invoke executes the tool's operation.
-func (t Tool) Invoke(ctx context.Context, resourceMgr tools.SourceProvider, params parameters.ParamValues, accessToken tools.AccessToken) (any, util.ToolboxError) {
+func (t Tool) Invoke(ctx context.Context, resourceMgr tools.SourceProvider, params parameters.ParamValues, accessToken tools.AccessToken) (_ any, toolErr util.ToolboxError) {
+	ctx, span := tools.StartInvokeSpan(ctx, t.Cfg.Type, t.Cfg.Name)
+	defer func() { tools.EndInvokeSpan(span, toolErr) }()
+
 	source, err := tools.GetCompatibleSource[compatibleSource](resourceMgr, t.Cfg.Source, t.Cfg.Name, t.Cfg.Type)
 	if err != nil {
 		return nil, util.NewClientServerError("source used is not compatible with the tool", http.StatusInternalServerError, err)
 	}
+	span.SetAttributes(attribute.String("project", source.GetProject()), attribute.String("location", source.GetLocation()))
 
 	paramMap := params.AsMap()
 	operation, ok := paramMap["operation"].(string)
@@ -107,10 +117,19 @@ func (t Tool) Invoke(ctx context.Context, resourceMgr tools.SourceProvider, para
 	if strings.Contains(operation, "/") {
 		return nil, util.NewAgentError(fmt.Sprintf("operation must be a short operation name without '/': %s", operation), nil)
 	}
+	span.SetAttributes(attribute.String("operation", operation))
+
+	if dryRun, _ := paramMap["dryRun"].(bool); dryRun {
+		resp, err := serverlessspark.CancelOperationDryRun(source.GetUniverseDomain(), source.GetProject(), source.GetLocation(), operation)
+		if err != nil {
+			return nil, util.NewClientServerError("failed to describe cancel request", http.StatusInternalServerError, err)
+		}
+		return resp, nil
+	}
 
 	resp, err := source.CancelOperation(ctx, operation)
 	if err != nil {
-		return nil, util.ProcessGcpError(err)
+		return nil, serverlessspark.ProcessError(err)
 	}
 	return resp, nil
 }
@@ -66,7 +66,6 @@ func (b *PySparkBatchBuilder) Parameters() parameters.Parameters {
 	return parameters.Parameters{
 		parameters.NewStringParameter("mainFile", "The path to the main Python file, as a gs://... URI.", parameters.WithStringRequired(true)),
 		parameters.NewArrayParameter("args", "Optional. A list of arguments passed to the main file.", parameters.NewStringParameter("arg", "An argument."), parameters.WithArrayRequired(false)),
-		parameters.NewStringParameter("version", "Optional. The Serverless runtime version to execute with.", parameters.WithStringRequired(false)),
 	}
 }
 
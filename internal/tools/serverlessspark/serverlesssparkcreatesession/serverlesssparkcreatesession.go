@@ -0,0 +1,211 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serverlesssparkcreatesession
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	dataprocpb "cloud.google.com/go/dataproc/v2/apiv1/dataprocpb"
+	"github.com/goccy/go-yaml"
+	"github.com/googleapis/mcp-toolbox/internal/sources/serverlessspark"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/googleapis/mcp-toolbox/internal/util"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+	"go.opentelemetry.io/otel/attribute"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+const resourceType = "serverless-spark-create-session"
+
+// minTTL and maxTTL bound idleTtl and ttl, as enforced by the API.
+const (
+	minTTL = 10 * time.Minute
+	maxTTL = 14 * 24 * time.Hour
+)
+
+var (
+	// sessionIDRegex matches the session IDs accepted by the API: 4-63
+	// characters, lowercase letters, numbers, and hyphens.
+	sessionIDRegex           = regexp.MustCompile(`^[a-z][a-z0-9-]{2,61}[a-z0-9]$`)
+	sessionTemplateNameRegex = regexp.MustCompile(`^projects/[^/]+/locations/[^/]+/sessionTemplates/[^/]+$`)
+	runtimeVersionRegex      = regexp.MustCompile(`^\d+\.\d+(\.\d+)?$`)
+)
+
+func init() {
+	if !tools.Register(resourceType, newConfig) {
+		panic(fmt.Sprintf("tool type %q already registered", resourceType))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{ConfigBase: tools.ConfigBase{Name: name}}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type compatibleSource interface {
+	GetProject() string
+	GetLocation() string
+	GetUniverseDomain() string
+	GetDefaultLabels() map[string]string
+	CreateSession(context.Context, string, *dataprocpb.Session) (map[string]any, error)
+}
+
+type Config struct {
+	tools.ConfigBase `yaml:",inline"`
+	Type             string                 `yaml:"type" validate:"required"`
+	Source           string                 `yaml:"source" validate:"required"`
+	Annotations      *tools.ToolAnnotations `yaml:"annotations,omitempty"`
+}
+
+// validate interface
+var _ tools.ToolConfig = Config{}
+
+// ToolConfigType returns the unique name for this tool.
+func (cfg Config) ToolConfigType() string {
+	return resourceType
+}
+
+// Initialize creates a new Tool instance.
+func (cfg Config) Initialize(context.Context) (tools.Tool, error) {
+	desc := cfg.Description
+	if desc == "" {
+		desc = "Creates a Serverless Spark (aka Dataproc Serverless) interactive session. The tool returns once creation has started; it can take a minute or so for the session to become active."
+	}
+
+	allParameters := parameters.Parameters{
+		parameters.NewStringParameter("sessionId", "The ID to use for the session, which becomes the last part of its name. Must be 4-63 characters of lowercase letters, numbers, and hyphens, starting with a letter and ending with a letter or number."),
+		parameters.NewStringParameter("sessionTemplate", "The session template to create the session from, either a short name, e.g. \"my-template\", or a full name, e.g. \"projects/my-project/locations/us-central1/sessionTemplates/my-template\"", parameters.WithStringRequired(false)),
+		parameters.NewStringParameter("version", "The Serverless runtime version to use for the session, e.g. \"2.2\". Overrides the version of the session template, if any.", parameters.WithStringRequired(false)),
+		parameters.NewStringParameter("idleTtl", fmt.Sprintf("How long the session may be idle before it is terminated, as a duration (e.g., 30m, 4h), from %s to %s. Defaults to the session template's value, if any, or the API default.", minTTL, maxTTL), parameters.WithStringRequired(false)),
+		parameters.NewStringParameter("ttl", fmt.Sprintf("The maximum lifetime of the session, after which it is terminated whether or not it is idle, as a duration (e.g., 8h), from %s to %s. Defaults to the session template's value, if any, or the API default.", minTTL, maxTTL), parameters.WithStringRequired(false)),
+		parameters.NewBooleanParameter("dryRun", "If true, validate the inputs and return the request that would be sent, without creating the session.", parameters.WithBooleanDefault(false)),
+	}
+
+	return Tool{
+		BaseTool: tools.NewBaseTool(
+			cfg,
+			tools.GetAnnotationsOrDefault(cfg.Annotations, tools.NewDestructiveAnnotations),
+			tools.Manifest{Description: desc, Parameters: allParameters.Manifest(), AuthRequired: cfg.AuthRequired},
+			allParameters,
+		),
+	}, nil
+}
+
+// validate interface
+var _ tools.Tool = Tool{}
+
+// Tool is the implementation of the tool.
+type Tool struct {
+	tools.BaseTool[Config]
+}
+
+// Invoke executes the tool's operation.
+func (t Tool) Invoke(ctx context.Context, resourceMgr tools.SourceProvider, params parameters.ParamValues, accessToken tools.AccessToken) (_ any, toolErr util.ToolboxError) {
+	ctx, span := tools.StartInvokeSpan(ctx, t.Cfg.Type, t.Cfg.Name)
+	defer func() { tools.EndInvokeSpan(span, toolErr) }()
+
+	source, err := tools.GetCompatibleSource[compatibleSource](resourceMgr, t.Cfg.Source, t.Cfg.Name, t.Cfg.Type)
+	if err != nil {
+		return nil, util.NewClientServerError("source used is not compatible with the tool", http.StatusInternalServerError, err)
+	}
+	span.SetAttributes(attribute.String("project", source.GetProject()), attribute.String("location", source.GetLocation()))
+
+	paramMap := params.AsMap()
+	sessionID, ok := paramMap["sessionId"].(string)
+	if !ok {
+		return nil, util.NewAgentError("missing required parameter: sessionId", nil)
+	}
+	if !sessionIDRegex.MatchString(sessionID) {
+		return nil, util.NewAgentError(fmt.Sprintf("invalid sessionId %q: must be 4-63 characters of lowercase letters, numbers, and hyphens, starting with a letter and ending with a letter or number", sessionID), nil)
+	}
+	span.SetAttributes(attribute.String("session_id", sessionID))
+
+	session := &dataprocpb.Session{}
+	if template, _ := paramMap["sessionTemplate"].(string); template != "" {
+		if !strings.Contains(template, "/") {
+			template = fmt.Sprintf("projects/%s/locations/%s/sessionTemplates/%s", source.GetProject(), source.GetLocation(), template)
+		}
+		if !sessionTemplateNameRegex.MatchString(template) {
+			return nil, util.NewAgentError(fmt.Sprintf("invalid sessionTemplate %q: must be a short name or of the form projects/{project}/locations/{location}/sessionTemplates/{template}", template), nil)
+		}
+		session.SessionTemplate = template
+	}
+	if version, _ := paramMap["version"].(string); version != "" {
+		if !runtimeVersionRegex.MatchString(version) {
+			return nil, util.NewAgentError(fmt.Sprintf("invalid version %q: must be a Serverless runtime version like 2.2", version), nil)
+		}
+		session.RuntimeConfig = &dataprocpb.RuntimeConfig{Version: version}
+	}
+	idleTTL, toolErr := ttlParam(paramMap, "idleTtl")
+	if toolErr != nil {
+		return nil, toolErr
+	}
+	ttl, toolErr := ttlParam(paramMap, "ttl")
+	if toolErr != nil {
+		return nil, toolErr
+	}
+	if defaults := source.GetDefaultLabels(); len(defaults) > 0 {
+		session.Labels = maps.Clone(defaults)
+	}
+	if idleTTL != nil || ttl != nil {
+		session.EnvironmentConfig = &dataprocpb.EnvironmentConfig{
+			ExecutionConfig: &dataprocpb.ExecutionConfig{IdleTtl: idleTTL, Ttl: ttl},
+		}
+	}
+
+	if dryRun, _ := paramMap["dryRun"].(bool); dryRun {
+		resp, err := serverlessspark.CreateSessionDryRun(source.GetUniverseDomain(), source.GetProject(), source.GetLocation(), sessionID, session)
+		if err != nil {
+			return nil, util.NewClientServerError("failed to describe session request", http.StatusInternalServerError, err)
+		}
+		return resp, nil
+	}
+
+	resp, err := source.CreateSession(ctx, sessionID, session)
+	if err != nil {
+		return nil, serverlessspark.ProcessError(err)
+	}
+	return resp, nil
+}
+
+// ttlParam parses the optional duration parameter name, returning nil if it is
+// unset.
+func ttlParam(paramMap map[string]any, name string) (*durationpb.Duration, util.ToolboxError) {
+	s, _ := paramMap[name].(string)
+	if s == "" {
+		return nil, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return nil, util.NewAgentError(fmt.Sprintf("%s must be a duration like 30m: %q", name, s), err)
+	}
+	if d < minTTL || d > maxTTL {
+		return nil, util.NewAgentError(fmt.Sprintf("%s must be between %s and %s: %s", name, minTTL, maxTTL, d), nil)
+	}
+	return durationpb.New(d), nil
+}
+
+func (t Tool) ToConfig() tools.ToolConfig {
+	return t.Cfg
+}
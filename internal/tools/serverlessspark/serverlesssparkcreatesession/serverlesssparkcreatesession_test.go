@@ -0,0 +1,313 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serverlesssparkcreatesession_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	dataprocpb "cloud.google.com/go/dataproc/v2/apiv1/dataprocpb"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/mcp-toolbox/internal/server"
+	"github.com/googleapis/mcp-toolbox/internal/sources"
+	"github.com/googleapis/mcp-toolbox/internal/testutils"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/googleapis/mcp-toolbox/internal/tools/serverlessspark/serverlesssparkcreatesession"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func TestParseFromYaml(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ToolConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			kind: tool
+			name: example_tool
+			type: serverless-spark-create-session
+			source: my-instance
+			description: some description
+			`,
+			want: server.ToolConfigs{
+				"example_tool": serverlesssparkcreatesession.Config{
+					ConfigBase: tools.ConfigBase{
+						Name:         "example_tool",
+						Description:  "some description",
+						AuthRequired: []string{},
+					},
+					Type:   "serverless-spark-create-session",
+					Source: "my-instance",
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, _, _, got, _, _, err := server.UnmarshalResourceConfig(ctx, testutils.FormatYaml(tc.in))
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+type mockSource struct {
+	sources.Source
+	defaultLabels map[string]string
+	gotSessionID  string
+	gotSession    *dataprocpb.Session
+}
+
+func (m *mockSource) GetProject() string {
+	return "my-project"
+}
+
+func (m *mockSource) GetLocation() string {
+	return "us-central1"
+}
+
+func (m *mockSource) GetUniverseDomain() string {
+	return ""
+}
+
+func (m *mockSource) GetDefaultLabels() map[string]string {
+	return m.defaultLabels
+}
+
+func (m *mockSource) CreateSession(ctx context.Context, sessionID string, session *dataprocpb.Session) (map[string]any, error) {
+	m.gotSessionID = sessionID
+	m.gotSession = session
+	return map[string]any{}, nil
+}
+
+type mockSourceProvider struct {
+	tools.SourceProvider
+	source *mockSource
+}
+
+func (m *mockSourceProvider) GetSource(name string) (sources.Source, bool) {
+	return m.source, true
+}
+
+func TestInvoke(t *testing.T) {
+	cfg := serverlesssparkcreatesession.Config{
+		ConfigBase: tools.ConfigBase{Name: "example_tool"},
+		Type:       "serverless-spark-create-session",
+		Source:     "my-instance",
+	}
+	tool, err := cfg.Initialize(context.Background())
+	if err != nil {
+		t.Fatalf("failed to initialize tool: %v", err)
+	}
+
+	tcs := []struct {
+		desc          string
+		params        parameters.ParamValues
+		defaultLabels map[string]string
+		want          *dataprocpb.Session
+		wantSubstr    string
+	}{
+		{
+			desc:   "session id only",
+			params: parameters.ParamValues{{Name: "sessionId", Value: "my-session"}},
+			want:   &dataprocpb.Session{},
+		},
+		{
+			desc:          "default labels",
+			params:        parameters.ParamValues{{Name: "sessionId", Value: "my-session"}},
+			defaultLabels: map[string]string{"team": "data-eng"},
+			want:          &dataprocpb.Session{Labels: map[string]string{"team": "data-eng"}},
+		},
+		{
+			desc: "short template name and version",
+			params: parameters.ParamValues{
+				{Name: "sessionId", Value: "my-session"},
+				{Name: "sessionTemplate", Value: "my-template"},
+				{Name: "version", Value: "2.2"},
+			},
+			want: &dataprocpb.Session{
+				SessionTemplate: "projects/my-project/locations/us-central1/sessionTemplates/my-template",
+				RuntimeConfig:   &dataprocpb.RuntimeConfig{Version: "2.2"},
+			},
+		},
+		{
+			desc: "full template name",
+			params: parameters.ParamValues{
+				{Name: "sessionId", Value: "my-session"},
+				{Name: "sessionTemplate", Value: "projects/other-project/locations/us-east1/sessionTemplates/shared"},
+			},
+			want: &dataprocpb.Session{SessionTemplate: "projects/other-project/locations/us-east1/sessionTemplates/shared"},
+		},
+		{
+			desc: "idle ttl and ttl",
+			params: parameters.ParamValues{
+				{Name: "sessionId", Value: "my-session"},
+				{Name: "idleTtl", Value: "30m"},
+				{Name: "ttl", Value: "8h"},
+			},
+			want: &dataprocpb.Session{
+				EnvironmentConfig: &dataprocpb.EnvironmentConfig{
+					ExecutionConfig: &dataprocpb.ExecutionConfig{
+						IdleTtl: durationpb.New(30 * time.Minute),
+						Ttl:     durationpb.New(8 * time.Hour),
+					},
+				},
+			},
+		},
+		{
+			desc: "idle ttl only",
+			params: parameters.ParamValues{
+				{Name: "sessionId", Value: "my-session"},
+				{Name: "idleTtl", Value: "336h"},
+			},
+			want: &dataprocpb.Session{
+				EnvironmentConfig: &dataprocpb.EnvironmentConfig{
+					ExecutionConfig: &dataprocpb.ExecutionConfig{IdleTtl: durationpb.New(14 * 24 * time.Hour)},
+				},
+			},
+		},
+		{
+			desc:       "invalid session id",
+			params:     parameters.ParamValues{{Name: "sessionId", Value: "My_Session"}},
+			wantSubstr: `invalid sessionId "My_Session"`,
+		},
+		{
+			desc:       "session id too short",
+			params:     parameters.ParamValues{{Name: "sessionId", Value: "abc"}},
+			wantSubstr: `invalid sessionId "abc"`,
+		},
+		{
+			desc: "malformed template",
+			params: parameters.ParamValues{
+				{Name: "sessionId", Value: "my-session"},
+				{Name: "sessionTemplate", Value: "projects/p/sessionTemplates/t"},
+			},
+			wantSubstr: `invalid sessionTemplate "projects/p/sessionTemplates/t"`,
+		},
+		{
+			desc: "invalid version",
+			params: parameters.ParamValues{
+				{Name: "sessionId", Value: "my-session"},
+				{Name: "version", Value: "latest"},
+			},
+			wantSubstr: `invalid version "latest"`,
+		},
+		{
+			desc: "malformed idle ttl",
+			params: parameters.ParamValues{
+				{Name: "sessionId", Value: "my-session"},
+				{Name: "idleTtl", Value: "1 hour"},
+			},
+			wantSubstr: `idleTtl must be a duration like 30m: "1 hour"`,
+		},
+		{
+			desc: "idle ttl too short",
+			params: parameters.ParamValues{
+				{Name: "sessionId", Value: "my-session"},
+				{Name: "idleTtl", Value: "5m"},
+			},
+			wantSubstr: "idleTtl must be between 10m0s and 336h0m0s: 5m0s",
+		},
+		{
+			desc: "ttl too long",
+			params: parameters.ParamValues{
+				{Name: "sessionId", Value: "my-session"},
+				{Name: "ttl", Value: "337h"},
+			},
+			wantSubstr: "ttl must be between 10m0s and 336h0m0s: 337h0m0s",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			src := &mockSource{defaultLabels: tc.defaultLabels}
+			_, toolErr := tool.Invoke(context.Background(), &mockSourceProvider{source: src}, tc.params, "")
+			if tc.wantSubstr != "" {
+				if toolErr == nil || !strings.Contains(toolErr.Error(), tc.wantSubstr) {
+					t.Fatalf("expected error containing %q, got %v", tc.wantSubstr, toolErr)
+				}
+				if src.gotSession != nil {
+					t.Errorf("expected source not to be called on validation failure")
+				}
+				return
+			}
+			if toolErr != nil {
+				t.Fatalf("unexpected error: %v", toolErr)
+			}
+			if src.gotSessionID != "my-session" {
+				t.Errorf("got session ID %q, want %q", src.gotSessionID, "my-session")
+			}
+			if diff := cmp.Diff(tc.want, src.gotSession, protocmp.Transform()); diff != "" {
+				t.Errorf("incorrect session: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestInvokeDryRun(t *testing.T) {
+	cfg := serverlesssparkcreatesession.Config{
+		ConfigBase: tools.ConfigBase{Name: "example_tool"},
+		Type:       "serverless-spark-create-session",
+		Source:     "my-instance",
+	}
+	tool, err := cfg.Initialize(context.Background())
+	if err != nil {
+		t.Fatalf("failed to initialize tool: %v", err)
+	}
+
+	src := &mockSource{}
+	params := parameters.ParamValues{
+		{Name: "sessionId", Value: "my-session"},
+		{Name: "version", Value: "2.2"},
+		{Name: "dryRun", Value: true},
+	}
+	got, toolErr := tool.Invoke(context.Background(), &mockSourceProvider{source: src}, params, "")
+	if toolErr != nil {
+		t.Fatalf("unexpected error: %v", toolErr)
+	}
+	if src.gotSession != nil {
+		t.Errorf("expected source not to be called on a dry run")
+	}
+	want := map[string]any{
+		"dryRun":       true,
+		"resourceName": "projects/my-project/locations/us-central1/sessions/my-session",
+		"method":       "POST",
+		"url":          "https://dataproc.googleapis.com/v1/projects/my-project/locations/us-central1/sessions?sessionId=my-session",
+		"request": map[string]any{
+			"parent":    "projects/my-project/locations/us-central1",
+			"sessionId": "my-session",
+			"session": map[string]any{
+				"runtimeConfig": map[string]any{"version": "2.2"},
+			},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("incorrect dry run result (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serverlesssparkcreatesparksqlbatch
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	dataproc "cloud.google.com/go/dataproc/v2/apiv1/dataprocpb"
+	"github.com/goccy/go-yaml"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/googleapis/mcp-toolbox/internal/tools/serverlessspark/createbatch"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+)
+
+const resourceType = "serverless-spark-create-spark-sql-batch"
+
+func init() {
+	if !tools.Register(resourceType, newConfig) {
+		panic(fmt.Sprintf("tool type %q already registered", resourceType))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	baseCfg, err := createbatch.NewConfig(ctx, name, decoder)
+	if err != nil {
+		return nil, err
+	}
+	return Config{Config: baseCfg}, nil
+}
+
+type Config struct {
+	createbatch.Config
+
+	ScopesRequired []string `yaml:"scopesRequired"`
+}
+
+var _ tools.ToolConfig = Config{}
+
+func (cfg Config) ToolConfigType() string {
+	return resourceType
+}
+
+func (cfg Config) Initialize(context.Context) (tools.Tool, error) {
+	return createbatch.NewTool(cfg.Config, cfg, &SparkSQLBatchBuilder{})
+}
+
+type SparkSQLBatchBuilder struct{}
+
+func (b *SparkSQLBatchBuilder) Parameters() parameters.Parameters {
+	return parameters.Parameters{
+		parameters.NewStringParameter("queryFileUri", "The gs:// URI of the script that contains the Spark SQL queries to execute.", parameters.WithStringRequired(true)),
+		parameters.NewMapParameter("queryVariables", "Optional. Query variable names and values, equivalent to running SET name=\"value\"; before the script.", "string", parameters.WithMapRequired(false)),
+		parameters.NewArrayParameter("jarFiles", "Optional. A list of gs:// URIs of jar files to add to the Spark CLASSPATH.", parameters.NewStringParameter("jarFile", "A jar file URI."), parameters.WithArrayRequired(false)),
+	}
+}
+
+func (b *SparkSQLBatchBuilder) BuildBatch(params parameters.ParamValues) (*dataproc.Batch, error) {
+	paramMap := params.AsMap()
+
+	queryFile, _ := paramMap["queryFileUri"].(string)
+	if !strings.HasPrefix(queryFile, "gs://") {
+		return nil, fmt.Errorf("invalid queryFileUri %q: must be a gs:// URI", queryFile)
+	}
+
+	sqlBatch := &dataproc.SparkSqlBatch{QueryFileUri: queryFile}
+
+	if vars, ok := paramMap["queryVariables"].(map[string]any); ok && len(vars) > 0 {
+		sqlBatch.QueryVariables = make(map[string]string, len(vars))
+		for k, v := range vars {
+			sqlBatch.QueryVariables[k] = fmt.Sprintf("%v", v)
+		}
+	}
+
+	if jarFileUris, ok := paramMap["jarFiles"].([]any); ok {
+		for _, uri := range jarFileUris {
+			sqlBatch.JarFileUris = append(sqlBatch.JarFileUris, fmt.Sprintf("%v", uri))
+		}
+	}
+
+	return &dataproc.Batch{
+		BatchConfig: &dataproc.Batch_SparkSqlBatch{
+			SparkSqlBatch: sqlBatch,
+		},
+	}, nil
+}
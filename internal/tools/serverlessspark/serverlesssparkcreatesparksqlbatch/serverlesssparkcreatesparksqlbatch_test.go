@@ -0,0 +1,69 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serverlesssparkcreatesparksqlbatch_test
+
+import (
+	"strings"
+	"testing"
+
+	dataproc "cloud.google.com/go/dataproc/v2/apiv1/dataprocpb"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/googleapis/mcp-toolbox/internal/tools/serverlessspark/createbatch"
+	"github.com/googleapis/mcp-toolbox/internal/tools/serverlessspark/serverlesssparkcreatesparksqlbatch"
+	"github.com/googleapis/mcp-toolbox/internal/tools/serverlessspark/testutils"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+func TestParseFromYaml(t *testing.T) {
+	testutils.RunParseFromYAMLTests(t, "serverless-spark-create-spark-sql-batch", func(c createbatch.Config) tools.ToolConfig {
+		return serverlesssparkcreatesparksqlbatch.Config{Config: c}
+	})
+}
+
+func TestBuildBatch(t *testing.T) {
+	b := &serverlesssparkcreatesparksqlbatch.SparkSQLBatchBuilder{}
+	params := parameters.ParamValues{
+		{Name: "queryFileUri", Value: "gs://bucket/query.sql"},
+		{Name: "queryVariables", Value: map[string]any{"table": "events"}},
+		{Name: "jarFiles", Value: []any{"gs://bucket/udf.jar"}},
+	}
+	got, err := b.BuildBatch(params)
+	if err != nil {
+		t.Fatalf("BuildBatch() error = %v", err)
+	}
+	want := &dataproc.Batch{
+		BatchConfig: &dataproc.Batch_SparkSqlBatch{
+			SparkSqlBatch: &dataproc.SparkSqlBatch{
+				QueryFileUri:   "gs://bucket/query.sql",
+				QueryVariables: map[string]string{"table": "events"},
+				JarFileUris:    []string{"gs://bucket/udf.jar"},
+			},
+		},
+	}
+	if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+		t.Errorf("BuildBatch() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestBuildBatchInvalidQueryFileURI(t *testing.T) {
+	b := &serverlesssparkcreatesparksqlbatch.SparkSQLBatchBuilder{}
+	params := parameters.ParamValues{{Name: "queryFileUri", Value: "/tmp/query.sql"}}
+	_, err := b.BuildBatch(params)
+	if err == nil || !strings.Contains(err.Error(), "must be a gs:// URI") {
+		t.Errorf("BuildBatch() error = %v, want gs:// URI error", err)
+	}
+}
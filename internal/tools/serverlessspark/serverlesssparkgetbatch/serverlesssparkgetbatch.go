@@ -18,17 +18,26 @@ import (
 	"context"
 	"fmt"
 	"net/http"
-	"strings"
 
 	dataproc "cloud.google.com/go/dataproc/v2/apiv1"
 	"github.com/goccy/go-yaml"
+	"github.com/googleapis/mcp-toolbox/internal/sources/serverlessspark"
 	"github.com/googleapis/mcp-toolbox/internal/tools"
 	"github.com/googleapis/mcp-toolbox/internal/util"
 	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 const resourceType = "serverless-spark-get-batch"
 
+const (
+	viewBasic = "BASIC"
+	viewFull  = "FULL"
+)
+
+// basicFields are the fields of the response kept with view BASIC.
+var basicFields = []string{"state", "stateMessage", "stateTime", "consoleUrl", "logsUrl"}
+
 func init() {
 	if !tools.Register(resourceType, newConfig) {
 		panic(fmt.Sprintf("tool type %q already registered", resourceType))
@@ -44,6 +53,8 @@ func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.T
 }
 
 type compatibleSource interface {
+	GetProject() string
+	GetLocation() string
 	GetBatchControllerClient() *dataproc.BatchControllerClient
 	GetBatch(context.Context, string) (map[string]any, error)
 }
@@ -72,6 +83,8 @@ func (cfg Config) Initialize(context.Context) (tools.Tool, error) {
 
 	allParameters := parameters.Parameters{
 		parameters.NewStringParameter("name", "The short name of the batch, e.g. for \"projects/my-project/locations/us-central1/batches/my-batch\", pass \"my-batch\" (the project and location are inherited from the source)"),
+		parameters.NewStringParameter("view", "How much of the batch to return: \"FULL\" for the whole batch, or \"BASIC\" for just its name, state, stateMessage, stateTime, consoleUrl, and logsUrl, e.g. for quick state checks. Defaults to FULL.", parameters.WithStringDefault(viewFull), parameters.WithStringAllowedValues([]any{viewBasic, viewFull})),
+		parameters.NewStringParameter("consoleTab", "The tab of the batch's Cloud Console page that consoleUrl links to: \"summary\", \"monitoring\", or \"configuration\". Defaults to summary.", parameters.WithStringDefault(serverlessspark.BatchConsoleTabSummary), parameters.WithStringAllowedValues([]any{serverlessspark.BatchConsoleTabSummary, serverlessspark.BatchConsoleTabMonitoring, serverlessspark.BatchConsoleTabConfiguration})),
 	}
 
 	return Tool{
@@ -93,23 +106,51 @@ type Tool struct {
 }
 
 // Invoke executes the tool's operation.
-func (t Tool) Invoke(ctx context.Context, resourceMgr tools.SourceProvider, params parameters.ParamValues, accessToken tools.AccessToken) (any, util.ToolboxError) {
+func (t Tool) Invoke(ctx context.Context, resourceMgr tools.SourceProvider, params parameters.ParamValues, accessToken tools.AccessToken) (_ any, toolErr util.ToolboxError) {
+	ctx, span := tools.StartInvokeSpan(ctx, t.Cfg.Type, t.Cfg.Name)
+	defer func() { tools.EndInvokeSpan(span, toolErr) }()
+
 	source, err := tools.GetCompatibleSource[compatibleSource](resourceMgr, t.Cfg.Source, t.Cfg.Name, t.Cfg.Type)
 	if err != nil {
 		return nil, util.NewClientServerError("source used is not compatible with the tool", http.StatusInternalServerError, err)
 	}
+	span.SetAttributes(attribute.String("project", source.GetProject()), attribute.String("location", source.GetLocation()))
 	paramMap := params.AsMap()
 	name, ok := paramMap["name"].(string)
 	if !ok {
 		return nil, util.NewAgentError("missing required parameter: name", nil)
 	}
-	if strings.Contains(name, "/") {
-		return nil, util.NewAgentError(fmt.Sprintf("name must be a short batch name without '/': %s", name), nil)
+	resourceName, err := serverlessspark.BatchResourceName(source.GetProject(), source.GetLocation(), name)
+	if err != nil {
+		return nil, util.NewAgentError(fmt.Sprintf("name %v", err), err)
+	}
+	span.SetAttributes(attribute.String("batch_id", name))
+	view, _ := paramMap["view"].(string)
+	if view != "" && view != viewBasic && view != viewFull {
+		return nil, util.NewAgentError(fmt.Sprintf("view must be %q or %q: %q", viewBasic, viewFull, view), nil)
+	}
+	consoleTab, _ := paramMap["consoleTab"].(string)
+	consoleURL, err := serverlessspark.BatchConsoleTabURL(source.GetProject(), source.GetLocation(), name, consoleTab)
+	if err != nil {
+		return nil, util.NewAgentError(fmt.Sprintf("consoleTab %v", err), err)
 	}
 
-	resp, err := source.GetBatch(ctx, name)
+	resp, err := source.GetBatch(ctx, resourceName)
 	if err != nil {
-		return nil, util.ProcessGcpError(err)
+		return nil, serverlessspark.ProcessError(err)
+	}
+	if consoleTab != "" && consoleTab != serverlessspark.BatchConsoleTabSummary {
+		resp["consoleUrl"] = consoleURL
+	}
+	if view == viewBasic {
+		// The API has no partial view of batches, so trim the response here.
+		basic := map[string]any{"name": resourceName}
+		for _, k := range basicFields {
+			if v, ok := resp[k]; ok {
+				basic[k] = v
+			}
+		}
+		return basic, nil
 	}
 	return resp, nil
 }
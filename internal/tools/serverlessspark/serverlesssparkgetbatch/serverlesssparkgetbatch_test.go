@@ -15,13 +15,18 @@
 package serverlesssparkgetbatch_test
 
 import (
+	"context"
+	"strings"
 	"testing"
 
+	dataproc "cloud.google.com/go/dataproc/v2/apiv1"
 	"github.com/google/go-cmp/cmp"
 	"github.com/googleapis/mcp-toolbox/internal/server"
+	"github.com/googleapis/mcp-toolbox/internal/sources"
 	"github.com/googleapis/mcp-toolbox/internal/testutils"
 	"github.com/googleapis/mcp-toolbox/internal/tools"
 	"github.com/googleapis/mcp-toolbox/internal/tools/serverlessspark/serverlesssparkgetbatch"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
 )
 
 func TestParseFromYaml(t *testing.T) {
@@ -69,3 +74,157 @@ func TestParseFromYaml(t *testing.T) {
 		})
 	}
 }
+
+type mockSource struct {
+	sources.Source
+}
+
+func (m *mockSource) GetProject() string {
+	return "my-project"
+}
+
+func (m *mockSource) GetLocation() string {
+	return "us-central1"
+}
+
+func (m *mockSource) GetBatchControllerClient() *dataproc.BatchControllerClient {
+	return nil
+}
+
+func (m *mockSource) GetBatch(ctx context.Context, name string) (map[string]any, error) {
+	return map[string]any{
+		"state":        "FAILED",
+		"stateMessage": "Job failed",
+		"consoleUrl":   "https://console.cloud.google.com/dataproc/batches/us-central1/my-batch/summary?project=my-project",
+		"batch":        map[string]any{"name": name, "uuid": "1234"},
+	}, nil
+}
+
+type mockSourceProvider struct {
+	tools.SourceProvider
+	source *mockSource
+}
+
+func (m *mockSourceProvider) GetSource(name string) (sources.Source, bool) {
+	return m.source, true
+}
+
+func TestInvokeView(t *testing.T) {
+	cfg := serverlesssparkgetbatch.Config{
+		ConfigBase: tools.ConfigBase{Name: "example_tool"},
+		Type:       "serverless-spark-get-batch",
+		Source:     "my-instance",
+	}
+	tool, err := cfg.Initialize(context.Background())
+	if err != nil {
+		t.Fatalf("failed to initialize tool: %v", err)
+	}
+
+	tcs := []struct {
+		desc       string
+		view       string
+		want       any
+		wantSubstr string
+	}{
+		{
+			desc: "full",
+			view: "FULL",
+			want: map[string]any{
+				"state":        "FAILED",
+				"stateMessage": "Job failed",
+				"consoleUrl":   "https://console.cloud.google.com/dataproc/batches/us-central1/my-batch/summary?project=my-project",
+				"batch":        map[string]any{"name": "projects/my-project/locations/us-central1/batches/my-batch", "uuid": "1234"},
+			},
+		},
+		{
+			desc: "basic",
+			view: "BASIC",
+			want: map[string]any{
+				"name":         "projects/my-project/locations/us-central1/batches/my-batch",
+				"state":        "FAILED",
+				"stateMessage": "Job failed",
+				"consoleUrl":   "https://console.cloud.google.com/dataproc/batches/us-central1/my-batch/summary?project=my-project",
+			},
+		},
+		{
+			desc:       "invalid",
+			view:       "PARTIAL",
+			wantSubstr: "view must be",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			params := parameters.ParamValues{{Name: "name", Value: "my-batch"}, {Name: "view", Value: tc.view}}
+			got, toolErr := tool.Invoke(context.Background(), &mockSourceProvider{source: &mockSource{}}, params, "")
+			if tc.wantSubstr != "" {
+				if toolErr == nil || !strings.Contains(toolErr.Error(), tc.wantSubstr) {
+					t.Fatalf("expected error containing %q, got %v", tc.wantSubstr, toolErr)
+				}
+				return
+			}
+			if toolErr != nil {
+				t.Fatalf("unexpected error: %v", toolErr)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("incorrect result (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestInvokeConsoleTab(t *testing.T) {
+	cfg := serverlesssparkgetbatch.Config{
+		ConfigBase: tools.ConfigBase{Name: "example_tool"},
+		Type:       "serverless-spark-get-batch",
+		Source:     "my-instance",
+	}
+	tool, err := cfg.Initialize(context.Background())
+	if err != nil {
+		t.Fatalf("failed to initialize tool: %v", err)
+	}
+
+	tcs := []struct {
+		desc       string
+		tab        string
+		want       string
+		wantSubstr string
+	}{
+		{
+			desc: "default",
+			want: "https://console.cloud.google.com/dataproc/batches/us-central1/my-batch/summary?project=my-project",
+		},
+		{
+			desc: "monitoring",
+			tab:  "monitoring",
+			want: "https://console.cloud.google.com/dataproc/batches/us-central1/my-batch/monitoring?project=my-project",
+		},
+		{
+			desc: "configuration",
+			tab:  "configuration",
+			want: "https://console.cloud.google.com/dataproc/batches/us-central1/my-batch/configuration?project=my-project",
+		},
+		{
+			desc:       "invalid",
+			tab:        "logs",
+			wantSubstr: "consoleTab must be one of",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			params := parameters.ParamValues{{Name: "name", Value: "my-batch"}, {Name: "consoleTab", Value: tc.tab}}
+			got, toolErr := tool.Invoke(context.Background(), &mockSourceProvider{source: &mockSource{}}, params, "")
+			if tc.wantSubstr != "" {
+				if toolErr == nil || !strings.Contains(toolErr.Error(), tc.wantSubstr) {
+					t.Fatalf("expected error containing %q, got %v", tc.wantSubstr, toolErr)
+				}
+				return
+			}
+			if toolErr != nil {
+				t.Fatalf("unexpected error: %v", toolErr)
+			}
+			if resp, _ := got.(map[string]any); resp["consoleUrl"] != tc.want {
+				t.Errorf("got consoleUrl %v, want %v", resp["consoleUrl"], tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serverlesssparkgetbatchdiagnostics
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/goccy/go-yaml"
+	"github.com/googleapis/mcp-toolbox/internal/sources/serverlessspark"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/googleapis/mcp-toolbox/internal/util"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const resourceType = "serverless-spark-get-batch-diagnostics"
+
+func init() {
+	if !tools.Register(resourceType, newConfig) {
+		panic(fmt.Sprintf("tool type %q already registered", resourceType))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{ConfigBase: tools.ConfigBase{Name: name}}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type compatibleSource interface {
+	GetProject() string
+	GetLocation() string
+	GetBatchDiagnostics(context.Context, string, int) (map[string]any, error)
+}
+
+type Config struct {
+	tools.ConfigBase `yaml:",inline"`
+	Type             string                 `yaml:"type" validate:"required"`
+	Source           string                 `yaml:"source" validate:"required"`
+	Annotations      *tools.ToolAnnotations `yaml:"annotations,omitempty"`
+}
+
+// validate interface
+var _ tools.ToolConfig = Config{}
+
+// ToolConfigType returns the unique name for this tool.
+func (cfg Config) ToolConfigType() string {
+	return resourceType
+}
+
+// Initialize creates a new Tool instance.
+func (cfg Config) Initialize(context.Context) (tools.Tool, error) {
+	desc := cfg.Description
+	if desc == "" {
+		desc = "Gets the diagnostics of a Serverless Spark (aka Dataproc Serverless) batch, which Dataproc writes to Cloud Storage when a batch finishes, typically after a failure. Returns the text files in the diagnostics, up to a byte limit."
+	}
+
+	allParameters := parameters.Parameters{
+		parameters.NewStringParameter("name", "The short name of the batch, e.g. for \"projects/my-project/locations/us-central1/batches/my-batch\", pass \"my-batch\" (the project and location are inherited from the source)"),
+		parameters.NewBoundedIntParameter("maxBytes", 1, serverlessspark.MaxDiagnosticsMaxBytes, serverlessspark.DefaultDiagnosticsMaxBytes, fmt.Sprintf("The maximum number of bytes of diagnostics content to return, between 1 and %d (default %d)", serverlessspark.MaxDiagnosticsMaxBytes, serverlessspark.DefaultDiagnosticsMaxBytes)),
+	}
+
+	return Tool{
+		BaseTool: tools.NewBaseTool(
+			cfg,
+			tools.GetAnnotationsOrDefault(cfg.Annotations, tools.NewReadOnlyAnnotations),
+			tools.Manifest{Description: desc, Parameters: allParameters.Manifest(), AuthRequired: cfg.AuthRequired},
+			allParameters,
+		),
+	}, nil
+}
+
+// validate interface
+var _ tools.Tool = Tool{}
+
+// Tool is the implementation of the tool.
+type Tool struct {
+	tools.BaseTool[Config]
+}
+
+// Invoke executes the tool's operation.
+func (t Tool) Invoke(ctx context.Context, resourceMgr tools.SourceProvider, params parameters.ParamValues, accessToken tools.AccessToken) (_ any, toolErr util.ToolboxError) {
+	ctx, span := tools.StartInvokeSpan(ctx, t.Cfg.Type, t.Cfg.Name)
+	defer func() { tools.EndInvokeSpan(span, toolErr) }()
+
+	source, err := tools.GetCompatibleSource[compatibleSource](resourceMgr, t.Cfg.Source, t.Cfg.Name, t.Cfg.Type)
+	if err != nil {
+		return nil, util.NewClientServerError("source used is not compatible with the tool", http.StatusInternalServerError, err)
+	}
+	span.SetAttributes(attribute.String("project", source.GetProject()), attribute.String("location", source.GetLocation()))
+	paramMap := params.AsMap()
+	name, ok := paramMap["name"].(string)
+	if !ok {
+		return nil, util.NewAgentError("missing required parameter: name", nil)
+	}
+	resourceName, err := serverlessspark.BatchResourceName(source.GetProject(), source.GetLocation(), name)
+	if err != nil {
+		return nil, util.NewAgentError(fmt.Sprintf("name %v", err), err)
+	}
+	span.SetAttributes(attribute.String("batch_id", name))
+
+	maxBytes := serverlessspark.DefaultDiagnosticsMaxBytes
+	if v, ok := paramMap["maxBytes"].(int); ok {
+		maxBytes = v
+	}
+
+	resp, err := source.GetBatchDiagnostics(ctx, resourceName, maxBytes)
+	if err != nil {
+		return nil, serverlessspark.ProcessError(err)
+	}
+	return resp, nil
+}
+
+func (t Tool) ToConfig() tools.ToolConfig {
+	return t.Cfg
+}
@@ -0,0 +1,169 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serverlesssparkgetbatchloghistogram
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/goccy/go-yaml"
+	"github.com/googleapis/mcp-toolbox/internal/sources/serverlessspark"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/googleapis/mcp-toolbox/internal/util"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const resourceType = "serverless-spark-get-batch-log-histogram"
+
+func init() {
+	if !tools.Register(resourceType, newConfig) {
+		panic(fmt.Sprintf("tool type %q already registered", resourceType))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{ConfigBase: tools.ConfigBase{Name: name}}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type compatibleSource interface {
+	GetProject() string
+	GetLocation() string
+	IsLocationAllowed(string) bool
+	BatchLogHistogram(context.Context, string, serverlessspark.LogTimeRange, bool, string) (map[string]any, error)
+}
+
+type Config struct {
+	tools.ConfigBase `yaml:",inline"`
+	Type             string                 `yaml:"type" validate:"required"`
+	Source           string                 `yaml:"source" validate:"required"`
+	Annotations      *tools.ToolAnnotations `yaml:"annotations,omitempty"`
+}
+
+// validate interface
+var _ tools.ToolConfig = Config{}
+
+// ToolConfigType returns the unique name for this tool.
+func (cfg Config) ToolConfigType() string {
+	return resourceType
+}
+
+// Initialize creates a new Tool instance.
+func (cfg Config) Initialize(context.Context) (tools.Tool, error) {
+	desc := cfg.Description
+	if desc == "" {
+		desc = "Counts the log entries of a Serverless Spark (aka Dataproc Serverless) batch by severity and, optionally, by hour, without returning the entries, e.g. to see when errors started."
+	}
+
+	allParameters := parameters.Parameters{
+		parameters.NewStringParameter("name", "The short name of the batch, e.g. for \"projects/my-project/locations/us-central1/batches/my-batch\", pass \"my-batch\" (the project and location are inherited from the source). Exactly one of name or batchName must be set.", parameters.WithStringRequired(false)),
+		parameters.NewStringParameter("batchName", "The full resource name of the batch, e.g. \"projects/my-project/locations/us-central1/batches/my-batch\" as returned by other tools. Unlike name, the project and location are taken from the name. Exactly one of name or batchName must be set.", parameters.WithStringRequired(false)),
+		parameters.NewTimestampParameter("startTime", "Start time in RFC3339 format (e.g., 2025-12-09T00:00:00Z). Defaults to shortly before the batch was created.", parameters.WithTimestampRequired(false)),
+		parameters.NewTimestampParameter("endTime", "End time in RFC3339 format (e.g., 2025-12-09T23:59:59Z). Defaults to shortly after the batch finished, or now if it is still running.", parameters.WithTimestampRequired(false)),
+		parameters.NewBooleanParameter("byHour", "Set to true to also return the counts for each hour, oldest first. Defaults to false.", parameters.WithBooleanDefault(false)),
+		parameters.NewStringParameter("logsProject", "Optional. The project to read the batch's logs from, if they are routed to a different project than the batch's, e.g. a central logging project. Defaults to the batch's project.", parameters.WithStringRequired(false)),
+		parameters.NewBooleanParameter("includeFilter", "Set to true to also return the Cloud Logging filter that was run, as \"filter\", e.g. to see why entries were or were not counted. Defaults to false.", parameters.WithBooleanDefault(false)),
+	}
+
+	return Tool{
+		BaseTool: tools.NewBaseTool(
+			cfg,
+			tools.GetAnnotationsOrDefault(cfg.Annotations, tools.NewReadOnlyAnnotations),
+			tools.Manifest{Description: desc, Parameters: allParameters.Manifest(), AuthRequired: cfg.AuthRequired},
+			allParameters,
+		),
+	}, nil
+}
+
+// validate interface
+var _ tools.Tool = Tool{}
+
+// Tool is the implementation of the tool.
+type Tool struct {
+	tools.BaseTool[Config]
+}
+
+// Invoke executes the tool's operation.
+func (t Tool) Invoke(ctx context.Context, resourceMgr tools.SourceProvider, params parameters.ParamValues, accessToken tools.AccessToken) (_ any, toolErr util.ToolboxError) {
+	ctx, span := tools.StartInvokeSpan(ctx, t.Cfg.Type, t.Cfg.Name)
+	defer func() { tools.EndInvokeSpan(span, toolErr) }()
+
+	source, err := tools.GetCompatibleSource[compatibleSource](resourceMgr, t.Cfg.Source, t.Cfg.Name, t.Cfg.Type)
+	if err != nil {
+		return nil, util.NewClientServerError("source used is not compatible with the tool", http.StatusInternalServerError, err)
+	}
+	span.SetAttributes(attribute.String("project", source.GetProject()), attribute.String("location", source.GetLocation()))
+
+	paramMap := params.AsMap()
+	name, _ := paramMap["name"].(string)
+	batchName, _ := paramMap["batchName"].(string)
+	var resourceName string
+	switch {
+	case name != "" && batchName != "":
+		return nil, util.NewAgentError("name and batchName are mutually exclusive", nil)
+	case name != "":
+		resourceName, err = serverlessspark.BatchResourceName(source.GetProject(), source.GetLocation(), name)
+		if err != nil {
+			return nil, util.NewAgentError(fmt.Sprintf("name %v", err), err)
+		}
+		span.SetAttributes(attribute.String("batch_id", name))
+	case batchName != "":
+		if err := serverlessspark.ValidateBatchName(batchName); err != nil {
+			return nil, util.NewAgentError(fmt.Sprintf("batchName %v", err), err)
+		}
+		_, location, batchID, _ := serverlessspark.ExtractBatchDetails(batchName)
+		if !source.IsLocationAllowed(location) {
+			return nil, util.NewAgentError(fmt.Sprintf("access denied to location %q because it is not in the configured list of allowed locations", location), nil)
+		}
+		resourceName = batchName
+		span.SetAttributes(attribute.String("batch_id", batchID))
+	default:
+		return nil, util.NewAgentError("one of name or batchName is required", nil)
+	}
+
+	var r serverlessspark.LogTimeRange
+	r.Start, _ = paramMap["startTime"].(time.Time)
+	r.End, _ = paramMap["endTime"].(time.Time)
+	if !r.Start.IsZero() && !r.End.IsZero() && r.End.Before(r.Start) {
+		return nil, util.NewAgentError(fmt.Sprintf("endTime %s must not be before startTime %s", r.End.Format(time.RFC3339Nano), r.Start.Format(time.RFC3339Nano)), nil)
+	}
+	byHour, _ := paramMap["byHour"].(bool)
+	includeFilter, _ := paramMap["includeFilter"].(bool)
+	logsProject, _ := paramMap["logsProject"].(string)
+	if logsProject != "" {
+		if err := serverlessspark.ValidateProjectID(logsProject); err != nil {
+			return nil, util.NewAgentError(err.Error(), nil)
+		}
+	}
+
+	resp, err := source.BatchLogHistogram(ctx, resourceName, r, byHour, logsProject)
+	if err != nil {
+		return nil, serverlessspark.ProcessError(err)
+	}
+	if !includeFilter {
+		delete(resp, "filter")
+	}
+	return resp, nil
+}
+
+func (t Tool) ToConfig() tools.ToolConfig {
+	return t.Cfg
+}
@@ -0,0 +1,243 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serverlesssparkgetbatchloghistogram_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/mcp-toolbox/internal/server"
+	"github.com/googleapis/mcp-toolbox/internal/sources"
+	"github.com/googleapis/mcp-toolbox/internal/sources/serverlessspark"
+	"github.com/googleapis/mcp-toolbox/internal/testutils"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/googleapis/mcp-toolbox/internal/tools/serverlessspark/serverlesssparkgetbatchloghistogram"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+)
+
+func TestParseFromYaml(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ToolConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			kind: tool
+			name: example_tool
+			type: serverless-spark-get-batch-log-histogram
+			source: my-instance
+			description: some description
+			`,
+			want: server.ToolConfigs{
+				"example_tool": serverlesssparkgetbatchloghistogram.Config{
+					ConfigBase: tools.ConfigBase{
+						Name:         "example_tool",
+						Description:  "some description",
+						AuthRequired: []string{},
+					},
+					Type:   "serverless-spark-get-batch-log-histogram",
+					Source: "my-instance",
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, _, _, got, _, _, err := server.UnmarshalResourceConfig(ctx, testutils.FormatYaml(tc.in))
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+type mockSource struct {
+	sources.Source
+	called         bool
+	gotName        string
+	gotRange       serverlessspark.LogTimeRange
+	gotByHour      bool
+	gotLogsProject string
+}
+
+func (m *mockSource) GetProject() string {
+	return "my-project"
+}
+
+func (m *mockSource) GetLocation() string {
+	return "us-central1"
+}
+
+func (m *mockSource) IsLocationAllowed(location string) bool {
+	return location != "asia-east1"
+}
+
+func (m *mockSource) BatchLogHistogram(ctx context.Context, name string, r serverlessspark.LogTimeRange, byHour bool, logsProject string) (map[string]any, error) {
+	m.called = true
+	m.gotLogsProject = logsProject
+	m.gotName = name
+	m.gotRange = r
+	m.gotByHour = byHour
+	return map[string]any{"total": 3, "counts": map[string]int{"ERROR": 3}, "filter": `resource.type="cloud_dataproc_batch"`}, nil
+}
+
+type mockSourceProvider struct {
+	tools.SourceProvider
+	source *mockSource
+}
+
+func (m *mockSourceProvider) GetSource(name string) (sources.Source, bool) {
+	return m.source, true
+}
+
+func TestInvoke(t *testing.T) {
+	cfg := serverlesssparkgetbatchloghistogram.Config{
+		ConfigBase: tools.ConfigBase{Name: "example_tool"},
+		Type:       "serverless-spark-get-batch-log-histogram",
+		Source:     "my-instance",
+	}
+	tool, err := cfg.Initialize(context.Background())
+	if err != nil {
+		t.Fatalf("failed to initialize tool: %v", err)
+	}
+
+	start := time.Date(2026, 1, 2, 3, 0, 0, 0, time.UTC)
+	end := time.Date(2026, 1, 2, 4, 0, 0, 0, time.UTC)
+	tcs := []struct {
+		desc       string
+		params     parameters.ParamValues
+		wantName   string
+		wantRange  serverlessspark.LogTimeRange
+		wantByHour bool
+		wantFilter bool
+		wantLogs   string
+		wantSubstr string
+	}{
+		{
+			desc:   "defaults",
+			params: parameters.ParamValues{{Name: "name", Value: "my-batch"}},
+		},
+		{
+			desc:       "time range by hour",
+			params:     parameters.ParamValues{{Name: "name", Value: "my-batch"}, {Name: "startTime", Value: start}, {Name: "endTime", Value: end}, {Name: "byHour", Value: true}},
+			wantRange:  serverlessspark.LogTimeRange{Start: start, End: end},
+			wantByHour: true,
+		},
+		{
+			desc:       "include filter",
+			params:     parameters.ParamValues{{Name: "name", Value: "my-batch"}, {Name: "includeFilter", Value: true}},
+			wantFilter: true,
+		},
+		{
+			desc:     "logs project",
+			params:   parameters.ParamValues{{Name: "name", Value: "my-batch"}, {Name: "logsProject", Value: "central-logs"}},
+			wantLogs: "central-logs",
+		},
+		{
+			desc:       "invalid logs project",
+			params:     parameters.ParamValues{{Name: "name", Value: "my-batch"}, {Name: "logsProject", Value: "projects/central-logs"}},
+			wantSubstr: `invalid project "projects/central-logs"`,
+		},
+		{
+			desc:     "batch name",
+			params:   parameters.ParamValues{{Name: "batchName", Value: "projects/other-project/locations/europe-west4/batches/my-batch"}},
+			wantName: "projects/other-project/locations/europe-west4/batches/my-batch",
+		},
+		{
+			desc:       "neither name nor batch name",
+			params:     parameters.ParamValues{},
+			wantSubstr: "one of name or batchName is required",
+		},
+		{
+			desc:       "both name and batch name",
+			params:     parameters.ParamValues{{Name: "name", Value: "my-batch"}, {Name: "batchName", Value: "projects/my-project/locations/us-central1/batches/my-batch"}},
+			wantSubstr: "mutually exclusive",
+		},
+		{
+			desc:       "batch name in disallowed location",
+			params:     parameters.ParamValues{{Name: "batchName", Value: "projects/my-project/locations/asia-east1/batches/my-batch"}},
+			wantSubstr: "access denied to location \"asia-east1\"",
+		},
+		{
+			desc:       "short batch name",
+			params:     parameters.ParamValues{{Name: "batchName", Value: "my-batch"}},
+			wantSubstr: "batchName must be a full batch name",
+		},
+		{
+			desc:       "end before start",
+			params:     parameters.ParamValues{{Name: "name", Value: "my-batch"}, {Name: "startTime", Value: end}, {Name: "endTime", Value: start}},
+			wantSubstr: "must not be before startTime",
+		},
+		{
+			desc:       "full name",
+			params:     parameters.ParamValues{{Name: "name", Value: "projects/my-project/locations/us-central1/batches/my-batch"}},
+			wantSubstr: "must be a short batch name",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			src := &mockSource{}
+			got, toolErr := tool.Invoke(context.Background(), &mockSourceProvider{source: src}, tc.params, "")
+			if tc.wantSubstr != "" {
+				if toolErr == nil || !strings.Contains(toolErr.Error(), tc.wantSubstr) {
+					t.Fatalf("expected error containing %q, got %v", tc.wantSubstr, toolErr)
+				}
+				if src.called {
+					t.Errorf("expected source not to be called on validation failure")
+				}
+				return
+			}
+			if toolErr != nil {
+				t.Fatalf("unexpected error: %v", toolErr)
+			}
+			wantName := tc.wantName
+			if wantName == "" {
+				wantName = "projects/my-project/locations/us-central1/batches/my-batch"
+			}
+			if src.gotName != wantName {
+				t.Errorf("got name %q, want %q", src.gotName, wantName)
+			}
+			if diff := cmp.Diff(tc.wantRange, src.gotRange); diff != "" {
+				t.Errorf("time range mismatch (-want +got):\n%s", diff)
+			}
+			if src.gotByHour != tc.wantByHour {
+				t.Errorf("got byHour %t, want %t", src.gotByHour, tc.wantByHour)
+			}
+			if src.gotLogsProject != tc.wantLogs {
+				t.Errorf("got logsProject %q, want %q", src.gotLogsProject, tc.wantLogs)
+			}
+			resp, _ := got.(map[string]any)
+			if resp["total"] != 3 {
+				t.Errorf("got %v, want total 3", got)
+			}
+			if _, ok := resp["filter"]; ok != tc.wantFilter {
+				t.Errorf("got filter in result %t, want %t", ok, tc.wantFilter)
+			}
+		})
+	}
+}
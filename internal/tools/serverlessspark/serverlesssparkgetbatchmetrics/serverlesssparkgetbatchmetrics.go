@@ -0,0 +1,150 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serverlesssparkgetbatchmetrics
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/goccy/go-yaml"
+	"github.com/googleapis/mcp-toolbox/internal/sources/serverlessspark"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/googleapis/mcp-toolbox/internal/util"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const resourceType = "serverless-spark-get-batch-metrics"
+
+func init() {
+	if !tools.Register(resourceType, newConfig) {
+		panic(fmt.Sprintf("tool type %q already registered", resourceType))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{ConfigBase: tools.ConfigBase{Name: name}}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type compatibleSource interface {
+	GetProject() string
+	GetLocation() string
+	BatchMetrics(context.Context, string, string, time.Duration) (map[string]any, error)
+}
+
+type Config struct {
+	tools.ConfigBase `yaml:",inline"`
+	Type             string                 `yaml:"type" validate:"required"`
+	Source           string                 `yaml:"source" validate:"required"`
+	Annotations      *tools.ToolAnnotations `yaml:"annotations,omitempty"`
+}
+
+// validate interface
+var _ tools.ToolConfig = Config{}
+
+// ToolConfigType returns the unique name for this tool.
+func (cfg Config) ToolConfigType() string {
+	return resourceType
+}
+
+// Initialize creates a new Tool instance.
+func (cfg Config) Initialize(context.Context) (tools.Tool, error) {
+	desc := cfg.Description
+	if desc == "" {
+		desc = "Gets a Cloud Monitoring metric of a Serverless Spark (aka Dataproc Serverless) batch over the time it ran, such as its executor count or shuffle bytes, down-sampled to one point per window."
+	}
+
+	metrics := serverlessspark.BatchMetricNames()
+	allowedMetrics := make([]any, 0, len(metrics))
+	for _, m := range metrics {
+		allowedMetrics = append(allowedMetrics, m)
+	}
+	allParameters := parameters.Parameters{
+		parameters.NewStringParameter("name", "The short name of the batch, e.g. for \"projects/my-project/locations/us-central1/batches/my-batch\", pass \"my-batch\" (the project and location are inherited from the source)"),
+		parameters.NewStringParameter("metric", fmt.Sprintf("The metric to get, one of %v. executors is the number of Spark executors; the shuffle metrics are the bytes read or written in each window.", metrics), parameters.WithStringAllowedValues(allowedMetrics)),
+		parameters.NewStringParameter("window", fmt.Sprintf("The duration of the windows that the series are down-sampled to, e.g. \"5m\", between %s and %s (default %s). It is widened if the batch ran for too many windows.", serverlessspark.MinMetricsWindow, serverlessspark.MaxMetricsWindow, serverlessspark.DefaultMetricsWindow), parameters.WithStringRequired(false)),
+	}
+
+	return Tool{
+		BaseTool: tools.NewBaseTool(
+			cfg,
+			tools.GetAnnotationsOrDefault(cfg.Annotations, tools.NewReadOnlyAnnotations),
+			tools.Manifest{Description: desc, Parameters: allParameters.Manifest(), AuthRequired: cfg.AuthRequired},
+			allParameters,
+		),
+	}, nil
+}
+
+// validate interface
+var _ tools.Tool = Tool{}
+
+// Tool is the implementation of the tool.
+type Tool struct {
+	tools.BaseTool[Config]
+}
+
+// Invoke executes the tool's operation.
+func (t Tool) Invoke(ctx context.Context, resourceMgr tools.SourceProvider, params parameters.ParamValues, accessToken tools.AccessToken) (_ any, toolErr util.ToolboxError) {
+	ctx, span := tools.StartInvokeSpan(ctx, t.Cfg.Type, t.Cfg.Name)
+	defer func() { tools.EndInvokeSpan(span, toolErr) }()
+
+	source, err := tools.GetCompatibleSource[compatibleSource](resourceMgr, t.Cfg.Source, t.Cfg.Name, t.Cfg.Type)
+	if err != nil {
+		return nil, util.NewClientServerError("source used is not compatible with the tool", http.StatusInternalServerError, err)
+	}
+	span.SetAttributes(attribute.String("project", source.GetProject()), attribute.String("location", source.GetLocation()))
+	paramMap := params.AsMap()
+	name, ok := paramMap["name"].(string)
+	if !ok {
+		return nil, util.NewAgentError("missing required parameter: name", nil)
+	}
+	resourceName, err := serverlessspark.BatchResourceName(source.GetProject(), source.GetLocation(), name)
+	if err != nil {
+		return nil, util.NewAgentError(fmt.Sprintf("name %v", err), err)
+	}
+	span.SetAttributes(attribute.String("batch_id", name))
+
+	metric, _ := paramMap["metric"].(string)
+	if !slices.Contains(serverlessspark.BatchMetricNames(), metric) {
+		return nil, util.NewAgentError(fmt.Sprintf("metric must be one of %v: %q", serverlessspark.BatchMetricNames(), metric), nil)
+	}
+	window := serverlessspark.DefaultMetricsWindow
+	if s, _ := paramMap["window"].(string); s != "" {
+		window, err = time.ParseDuration(s)
+		if err != nil {
+			return nil, util.NewAgentError(fmt.Sprintf("window must be a duration like 5m: %q", s), err)
+		}
+		if err := serverlessspark.ValidateMetricsWindow(window); err != nil {
+			return nil, util.NewAgentError(fmt.Sprintf("window %v", err), err)
+		}
+	}
+
+	resp, err := source.BatchMetrics(ctx, resourceName, metric, window)
+	if err != nil {
+		return nil, serverlessspark.ProcessError(err)
+	}
+	return resp, nil
+}
+
+func (t Tool) ToConfig() tools.ToolConfig {
+	return t.Cfg
+}
@@ -0,0 +1,186 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serverlesssparkgetbatchmetrics_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/mcp-toolbox/internal/server"
+	"github.com/googleapis/mcp-toolbox/internal/sources"
+	"github.com/googleapis/mcp-toolbox/internal/testutils"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/googleapis/mcp-toolbox/internal/tools/serverlessspark/serverlesssparkgetbatchmetrics"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+)
+
+func TestParseFromYaml(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ToolConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			kind: tool
+			name: example_tool
+			type: serverless-spark-get-batch-metrics
+			source: my-instance
+			description: some description
+			`,
+			want: server.ToolConfigs{
+				"example_tool": serverlesssparkgetbatchmetrics.Config{
+					ConfigBase: tools.ConfigBase{
+						Name:         "example_tool",
+						Description:  "some description",
+						AuthRequired: []string{},
+					},
+					Type:   "serverless-spark-get-batch-metrics",
+					Source: "my-instance",
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, _, _, got, _, _, err := server.UnmarshalResourceConfig(ctx, testutils.FormatYaml(tc.in))
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+type mockSource struct {
+	sources.Source
+	called    bool
+	gotName   string
+	gotMetric string
+	gotWindow time.Duration
+}
+
+func (m *mockSource) GetProject() string {
+	return "my-project"
+}
+
+func (m *mockSource) GetLocation() string {
+	return "us-central1"
+}
+
+func (m *mockSource) BatchMetrics(ctx context.Context, name, metric string, window time.Duration) (map[string]any, error) {
+	m.called = true
+	m.gotName = name
+	m.gotMetric = metric
+	m.gotWindow = window
+	return map[string]any{}, nil
+}
+
+type mockSourceProvider struct {
+	tools.SourceProvider
+	source *mockSource
+}
+
+func (m *mockSourceProvider) GetSource(name string) (sources.Source, bool) {
+	return m.source, true
+}
+
+func TestInvoke(t *testing.T) {
+	cfg := serverlesssparkgetbatchmetrics.Config{
+		ConfigBase: tools.ConfigBase{Name: "example_tool"},
+		Type:       "serverless-spark-get-batch-metrics",
+		Source:     "my-instance",
+	}
+	tool, err := cfg.Initialize(context.Background())
+	if err != nil {
+		t.Fatalf("failed to initialize tool: %v", err)
+	}
+
+	tcs := []struct {
+		desc       string
+		params     parameters.ParamValues
+		wantWindow time.Duration
+		wantSubstr string
+	}{
+		{
+			desc:       "default window",
+			params:     parameters.ParamValues{{Name: "name", Value: "my-batch"}, {Name: "metric", Value: "executors"}},
+			wantWindow: 5 * time.Minute,
+		},
+		{
+			desc:       "window",
+			params:     parameters.ParamValues{{Name: "name", Value: "my-batch"}, {Name: "metric", Value: "executors"}, {Name: "window", Value: "1h"}},
+			wantWindow: time.Hour,
+		},
+		{
+			desc:       "unknown metric",
+			params:     parameters.ParamValues{{Name: "name", Value: "my-batch"}, {Name: "metric", Value: "cpu"}},
+			wantSubstr: "metric must be one of",
+		},
+		{
+			desc:       "invalid window",
+			params:     parameters.ParamValues{{Name: "name", Value: "my-batch"}, {Name: "metric", Value: "executors"}, {Name: "window", Value: "five minutes"}},
+			wantSubstr: "window must be a duration",
+		},
+		{
+			desc:       "window too small",
+			params:     parameters.ParamValues{{Name: "name", Value: "my-batch"}, {Name: "metric", Value: "executors"}, {Name: "window", Value: "10s"}},
+			wantSubstr: "window must be between 1m0s and 24h0m0s",
+		},
+		{
+			desc:       "full batch name",
+			params:     parameters.ParamValues{{Name: "name", Value: "projects/my-project/locations/us-central1/batches/my-batch"}, {Name: "metric", Value: "executors"}},
+			wantSubstr: "name must be a short batch name without '/'",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			src := &mockSource{}
+			_, toolErr := tool.Invoke(context.Background(), &mockSourceProvider{source: src}, tc.params, "")
+			if tc.wantSubstr != "" {
+				if toolErr == nil || !strings.Contains(toolErr.Error(), tc.wantSubstr) {
+					t.Fatalf("expected error containing %q, got %v", tc.wantSubstr, toolErr)
+				}
+				if src.called {
+					t.Errorf("expected source not to be called on validation failure")
+				}
+				return
+			}
+			if toolErr != nil {
+				t.Fatalf("unexpected error: %v", toolErr)
+			}
+			if want := "projects/my-project/locations/us-central1/batches/my-batch"; src.gotName != want {
+				t.Errorf("got name %q, want %q", src.gotName, want)
+			}
+			if src.gotMetric != "executors" {
+				t.Errorf("got metric %q, want %q", src.gotMetric, "executors")
+			}
+			if src.gotWindow != tc.wantWindow {
+				t.Errorf("got window %s, want %s", src.gotWindow, tc.wantWindow)
+			}
+		})
+	}
+}
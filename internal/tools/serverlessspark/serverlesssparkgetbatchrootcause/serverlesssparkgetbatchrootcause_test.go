@@ -0,0 +1,189 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serverlesssparkgetbatchrootcause_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/mcp-toolbox/internal/server"
+	"github.com/googleapis/mcp-toolbox/internal/sources"
+	"github.com/googleapis/mcp-toolbox/internal/testutils"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/googleapis/mcp-toolbox/internal/tools/serverlessspark/serverlesssparkgetbatchrootcause"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+)
+
+func TestParseFromYaml(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ToolConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			kind: tool
+			name: example_tool
+			type: serverless-spark-get-batch-root-cause
+			source: my-instance
+			description: some description
+			`,
+			want: server.ToolConfigs{
+				"example_tool": serverlesssparkgetbatchrootcause.Config{
+					ConfigBase: tools.ConfigBase{
+						Name:         "example_tool",
+						Description:  "some description",
+						AuthRequired: []string{},
+					},
+					Type:   "serverless-spark-get-batch-root-cause",
+					Source: "my-instance",
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, _, _, got, _, _, err := server.UnmarshalResourceConfig(ctx, testutils.FormatYaml(tc.in))
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+type mockSource struct {
+	sources.Source
+	called         bool
+	gotName        string
+	gotLogsProject string
+}
+
+func (m *mockSource) GetProject() string {
+	return "my-project"
+}
+
+func (m *mockSource) GetLocation() string {
+	return "us-central1"
+}
+
+func (m *mockSource) IsLocationAllowed(location string) bool {
+	return location != "asia-east1"
+}
+
+func (m *mockSource) BatchRootCause(ctx context.Context, name, logsProject string) (map[string]any, error) {
+	m.called = true
+	m.gotName = name
+	m.gotLogsProject = logsProject
+	return map[string]any{"rootCause": map[string]any{"summary": "java.io.IOException: disk full"}, "heuristic": "exception"}, nil
+}
+
+type mockSourceProvider struct {
+	tools.SourceProvider
+	source *mockSource
+}
+
+func (m *mockSourceProvider) GetSource(name string) (sources.Source, bool) {
+	return m.source, true
+}
+
+func TestInvoke(t *testing.T) {
+	cfg := serverlesssparkgetbatchrootcause.Config{
+		ConfigBase: tools.ConfigBase{Name: "example_tool"},
+		Type:       "serverless-spark-get-batch-root-cause",
+		Source:     "my-instance",
+	}
+	tool, err := cfg.Initialize(context.Background())
+	if err != nil {
+		t.Fatalf("failed to initialize tool: %v", err)
+	}
+
+	tcs := []struct {
+		desc       string
+		params     parameters.ParamValues
+		wantName   string
+		wantLogs   string
+		wantSubstr string
+	}{
+		{
+			desc:     "name",
+			params:   parameters.ParamValues{{Name: "name", Value: "my-batch"}},
+			wantName: "projects/my-project/locations/us-central1/batches/my-batch",
+		},
+		{
+			desc:     "batch name in logs project",
+			params:   parameters.ParamValues{{Name: "batchName", Value: "projects/other-project/locations/europe-west4/batches/my-batch"}, {Name: "logsProject", Value: "central-logs"}},
+			wantName: "projects/other-project/locations/europe-west4/batches/my-batch",
+			wantLogs: "central-logs",
+		},
+		{
+			desc:       "neither name nor batch name",
+			params:     parameters.ParamValues{},
+			wantSubstr: "one of name or batchName is required",
+		},
+		{
+			desc:       "both name and batch name",
+			params:     parameters.ParamValues{{Name: "name", Value: "my-batch"}, {Name: "batchName", Value: "projects/my-project/locations/us-central1/batches/my-batch"}},
+			wantSubstr: "mutually exclusive",
+		},
+		{
+			desc:       "batch name in disallowed location",
+			params:     parameters.ParamValues{{Name: "batchName", Value: "projects/my-project/locations/asia-east1/batches/my-batch"}},
+			wantSubstr: "access denied to location \"asia-east1\"",
+		},
+		{
+			desc:       "invalid logs project",
+			params:     parameters.ParamValues{{Name: "name", Value: "my-batch"}, {Name: "logsProject", Value: "projects/central-logs"}},
+			wantSubstr: `invalid project "projects/central-logs"`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			src := &mockSource{}
+			got, toolErr := tool.Invoke(context.Background(), &mockSourceProvider{source: src}, tc.params, "")
+			if tc.wantSubstr != "" {
+				if toolErr == nil || !strings.Contains(toolErr.Error(), tc.wantSubstr) {
+					t.Fatalf("expected error containing %q, got %v", tc.wantSubstr, toolErr)
+				}
+				if src.called {
+					t.Errorf("expected source not to be called on validation failure")
+				}
+				return
+			}
+			if toolErr != nil {
+				t.Fatalf("unexpected error: %v", toolErr)
+			}
+			if src.gotName != tc.wantName {
+				t.Errorf("got name %q, want %q", src.gotName, tc.wantName)
+			}
+			if src.gotLogsProject != tc.wantLogs {
+				t.Errorf("got logsProject %q, want %q", src.gotLogsProject, tc.wantLogs)
+			}
+			if resp, _ := got.(map[string]any); resp["heuristic"] != "exception" {
+				t.Errorf("got %v, want heuristic exception", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,181 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serverlesssparkgetlogentry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/goccy/go-yaml"
+	"github.com/googleapis/mcp-toolbox/internal/sources/serverlessspark"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/googleapis/mcp-toolbox/internal/util"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const resourceType = "serverless-spark-get-log-entry"
+
+func init() {
+	if !tools.Register(resourceType, newConfig) {
+		panic(fmt.Sprintf("tool type %q already registered", resourceType))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{ConfigBase: tools.ConfigBase{Name: name}}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type compatibleSource interface {
+	GetProject() string
+	GetLocation() string
+	IsLocationAllowed(string) bool
+	GetLogEntry(context.Context, string, string, string) (map[string]any, error)
+}
+
+type Config struct {
+	tools.ConfigBase `yaml:",inline"`
+	Type             string                 `yaml:"type" validate:"required"`
+	Source           string                 `yaml:"source" validate:"required"`
+	Annotations      *tools.ToolAnnotations `yaml:"annotations,omitempty"`
+}
+
+// validate interface
+var _ tools.ToolConfig = Config{}
+
+// ToolConfigType returns the unique name for this tool.
+func (cfg Config) ToolConfigType() string {
+	return resourceType
+}
+
+// Initialize creates a new Tool instance.
+func (cfg Config) Initialize(context.Context) (tools.Tool, error) {
+	desc := cfg.Description
+	if desc == "" {
+		desc = "Gets a single log entry of a Serverless Spark (aka Dataproc Serverless) batch or session by its insertId, with all of its details."
+	}
+
+	allParameters := parameters.Parameters{
+		parameters.NewStringParameter("batch", "The short name of the batch whose logs contain the entry, e.g. \"my-batch\". Exactly one of batch, batchName, or session must be set.", parameters.WithStringRequired(false)),
+		parameters.NewStringParameter("batchName", "The full resource name of the batch whose logs contain the entry, e.g. \"projects/my-project/locations/us-central1/batches/my-batch\" as returned by other tools. Unlike batch, the project and location are taken from the name. Exactly one of batch, batchName, or session must be set.", parameters.WithStringRequired(false)),
+		parameters.NewStringParameter("session", "The short name of the session whose logs contain the entry, e.g. \"my-session\". Exactly one of batch, batchName, or session must be set.", parameters.WithStringRequired(false)),
+		parameters.NewStringParameter("insertId", "The insertId of the log entry, e.g. from a logs query."),
+		parameters.NewStringParameter("logsProject", "Optional. The project to read the logs from, if they are routed to a different project than the batch's or session's, e.g. a central logging project. Defaults to the batch's or session's project.", parameters.WithStringRequired(false)),
+	}
+
+	return Tool{
+		BaseTool: tools.NewBaseTool(
+			cfg,
+			tools.GetAnnotationsOrDefault(cfg.Annotations, tools.NewReadOnlyAnnotations),
+			tools.Manifest{Description: desc, Parameters: allParameters.Manifest(), AuthRequired: cfg.AuthRequired},
+			allParameters,
+		),
+	}, nil
+}
+
+// validate interface
+var _ tools.Tool = Tool{}
+
+// Tool is the implementation of the tool.
+type Tool struct {
+	tools.BaseTool[Config]
+}
+
+// Invoke executes the tool's operation.
+func (t Tool) Invoke(ctx context.Context, resourceMgr tools.SourceProvider, params parameters.ParamValues, accessToken tools.AccessToken) (_ any, toolErr util.ToolboxError) {
+	ctx, span := tools.StartInvokeSpan(ctx, t.Cfg.Type, t.Cfg.Name)
+	defer func() { tools.EndInvokeSpan(span, toolErr) }()
+
+	source, err := tools.GetCompatibleSource[compatibleSource](resourceMgr, t.Cfg.Source, t.Cfg.Name, t.Cfg.Type)
+	if err != nil {
+		return nil, util.NewClientServerError("source used is not compatible with the tool", http.StatusInternalServerError, err)
+	}
+	span.SetAttributes(attribute.String("project", source.GetProject()), attribute.String("location", source.GetLocation()))
+
+	paramMap := params.AsMap()
+	batch, _ := paramMap["batch"].(string)
+	batchName, _ := paramMap["batchName"].(string)
+	session, _ := paramMap["session"].(string)
+	var resourceName string
+	switch {
+	case countSet(batch, batchName, session) > 1:
+		return nil, util.NewAgentError("batch, batchName, and session are mutually exclusive", nil)
+	case batch != "":
+		resourceName, err = serverlessspark.BatchResourceName(source.GetProject(), source.GetLocation(), batch)
+		if err != nil {
+			return nil, util.NewAgentError(fmt.Sprintf("batch %v", err), err)
+		}
+		span.SetAttributes(attribute.String("batch_id", batch))
+	case batchName != "":
+		if err := serverlessspark.ValidateBatchName(batchName); err != nil {
+			return nil, util.NewAgentError(fmt.Sprintf("batchName %v", err), err)
+		}
+		_, location, batchID, _ := serverlessspark.ExtractBatchDetails(batchName)
+		if !source.IsLocationAllowed(location) {
+			return nil, util.NewAgentError(fmt.Sprintf("access denied to location %q because it is not in the configured list of allowed locations", location), nil)
+		}
+		resourceName = batchName
+		span.SetAttributes(attribute.String("batch_id", batchID))
+	case session != "":
+		resourceName, err = serverlessspark.SessionResourceName(source.GetProject(), source.GetLocation(), session)
+		if err != nil {
+			return nil, util.NewAgentError(fmt.Sprintf("session %v", err), err)
+		}
+		span.SetAttributes(attribute.String("session_id", session))
+	default:
+		return nil, util.NewAgentError("one of batch, batchName, or session is required", nil)
+	}
+
+	insertID, _ := paramMap["insertId"].(string)
+	if insertID == "" {
+		return nil, util.NewAgentError("missing required parameter: insertId", nil)
+	}
+	logsProject, _ := paramMap["logsProject"].(string)
+	if logsProject != "" {
+		if err := serverlessspark.ValidateProjectID(logsProject); err != nil {
+			return nil, util.NewAgentError(err.Error(), nil)
+		}
+	}
+
+	resp, err := source.GetLogEntry(ctx, resourceName, insertID, logsProject)
+	if errors.Is(err, serverlessspark.ErrLogEntryNotFound) {
+		return nil, util.NewAgentError(err.Error(), err)
+	}
+	if err != nil {
+		return nil, serverlessspark.ProcessError(err)
+	}
+	return resp, nil
+}
+
+// countSet returns the number of non-empty values.
+func countSet(values ...string) int {
+	n := 0
+	for _, v := range values {
+		if v != "" {
+			n++
+		}
+	}
+	return n
+}
+
+func (t Tool) ToConfig() tools.ToolConfig {
+	return t.Cfg
+}
@@ -0,0 +1,245 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serverlesssparkgetlogentry_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/mcp-toolbox/internal/server"
+	"github.com/googleapis/mcp-toolbox/internal/sources"
+	"github.com/googleapis/mcp-toolbox/internal/sources/serverlessspark"
+	"github.com/googleapis/mcp-toolbox/internal/testutils"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/googleapis/mcp-toolbox/internal/tools/serverlessspark/serverlesssparkgetlogentry"
+	"github.com/googleapis/mcp-toolbox/internal/util"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+)
+
+func TestParseFromYaml(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ToolConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			kind: tool
+			name: example_tool
+			type: serverless-spark-get-log-entry
+			source: my-instance
+			description: some description
+			`,
+			want: server.ToolConfigs{
+				"example_tool": serverlesssparkgetlogentry.Config{
+					ConfigBase: tools.ConfigBase{
+						Name:         "example_tool",
+						Description:  "some description",
+						AuthRequired: []string{},
+					},
+					Type:   "serverless-spark-get-log-entry",
+					Source: "my-instance",
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, _, _, got, _, _, err := server.UnmarshalResourceConfig(ctx, testutils.FormatYaml(tc.in))
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+type mockSource struct {
+	sources.Source
+	called         bool
+	gotName        string
+	gotInsertID    string
+	gotLogsProject string
+	notFound       bool
+}
+
+func (m *mockSource) GetProject() string {
+	return "my-project"
+}
+
+func (m *mockSource) GetLocation() string {
+	return "us-central1"
+}
+
+func (m *mockSource) IsLocationAllowed(location string) bool {
+	return location != "asia-east1"
+}
+
+func (m *mockSource) GetLogEntry(ctx context.Context, name, insertID, logsProject string) (map[string]any, error) {
+	m.called = true
+	m.gotLogsProject = logsProject
+	m.gotName = name
+	m.gotInsertID = insertID
+	if m.notFound {
+		return nil, fmt.Errorf("%w: insertId %q", serverlessspark.ErrLogEntryNotFound, insertID)
+	}
+	return map[string]any{"insertId": insertID}, nil
+}
+
+type mockSourceProvider struct {
+	tools.SourceProvider
+	source *mockSource
+}
+
+func (m *mockSourceProvider) GetSource(name string) (sources.Source, bool) {
+	return m.source, true
+}
+
+func TestInvoke(t *testing.T) {
+	cfg := serverlesssparkgetlogentry.Config{
+		ConfigBase: tools.ConfigBase{Name: "example_tool"},
+		Type:       "serverless-spark-get-log-entry",
+		Source:     "my-instance",
+	}
+	tool, err := cfg.Initialize(context.Background())
+	if err != nil {
+		t.Fatalf("failed to initialize tool: %v", err)
+	}
+
+	tcs := []struct {
+		desc       string
+		params     parameters.ParamValues
+		notFound   bool
+		wantName   string
+		wantLogs   string
+		wantSubstr string
+		wantCalled bool
+	}{
+		{
+			desc:     "batch",
+			params:   parameters.ParamValues{{Name: "batch", Value: "my-batch"}, {Name: "insertId", Value: "abc123"}},
+			wantName: "projects/my-project/locations/us-central1/batches/my-batch",
+		},
+		{
+			desc:     "session",
+			params:   parameters.ParamValues{{Name: "session", Value: "my-session"}, {Name: "insertId", Value: "abc123"}},
+			wantName: "projects/my-project/locations/us-central1/sessions/my-session",
+		},
+		{
+			desc:     "logs project",
+			params:   parameters.ParamValues{{Name: "batch", Value: "my-batch"}, {Name: "insertId", Value: "abc123"}, {Name: "logsProject", Value: "central-logs"}},
+			wantName: "projects/my-project/locations/us-central1/batches/my-batch",
+			wantLogs: "central-logs",
+		},
+		{
+			desc:       "invalid logs project",
+			params:     parameters.ParamValues{{Name: "batch", Value: "my-batch"}, {Name: "insertId", Value: "abc123"}, {Name: "logsProject", Value: "Central_Logs"}},
+			wantSubstr: `invalid project "Central_Logs"`,
+		},
+		{
+			desc:     "batch name",
+			params:   parameters.ParamValues{{Name: "batchName", Value: "projects/other-project/locations/europe-west4/batches/my-batch"}, {Name: "insertId", Value: "abc123"}},
+			wantName: "projects/other-project/locations/europe-west4/batches/my-batch",
+		},
+		{
+			desc:       "neither batch nor session",
+			params:     parameters.ParamValues{{Name: "insertId", Value: "abc123"}},
+			wantSubstr: "one of batch, batchName, or session is required",
+		},
+		{
+			desc:       "both batch and batch name",
+			params:     parameters.ParamValues{{Name: "batch", Value: "my-batch"}, {Name: "batchName", Value: "projects/my-project/locations/us-central1/batches/my-batch"}, {Name: "insertId", Value: "abc123"}},
+			wantSubstr: "mutually exclusive",
+		},
+		{
+			desc:       "batch name in disallowed location",
+			params:     parameters.ParamValues{{Name: "batchName", Value: "projects/my-project/locations/asia-east1/batches/my-batch"}, {Name: "insertId", Value: "abc123"}},
+			wantSubstr: "access denied to location \"asia-east1\"",
+		},
+		{
+			desc:       "short batch name",
+			params:     parameters.ParamValues{{Name: "batchName", Value: "my-batch"}, {Name: "insertId", Value: "abc123"}},
+			wantSubstr: "batchName must be a full batch name",
+		},
+		{
+			desc:       "both batch and session",
+			params:     parameters.ParamValues{{Name: "batch", Value: "my-batch"}, {Name: "session", Value: "my-session"}, {Name: "insertId", Value: "abc123"}},
+			wantSubstr: "mutually exclusive",
+		},
+		{
+			desc:       "full batch name",
+			params:     parameters.ParamValues{{Name: "batch", Value: "projects/my-project/locations/us-central1/batches/my-batch"}, {Name: "insertId", Value: "abc123"}},
+			wantSubstr: "must be a short batch name",
+		},
+		{
+			desc:       "empty insertId",
+			params:     parameters.ParamValues{{Name: "batch", Value: "my-batch"}, {Name: "insertId", Value: ""}},
+			wantSubstr: "missing required parameter: insertId",
+		},
+		{
+			desc:       "not found",
+			params:     parameters.ParamValues{{Name: "batch", Value: "my-batch"}, {Name: "insertId", Value: "abc123"}},
+			notFound:   true,
+			wantSubstr: "log entry not found",
+			wantCalled: true,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			src := &mockSource{notFound: tc.notFound}
+			got, toolErr := tool.Invoke(context.Background(), &mockSourceProvider{source: src}, tc.params, "")
+			if tc.wantSubstr != "" {
+				if toolErr == nil || !strings.Contains(toolErr.Error(), tc.wantSubstr) {
+					t.Fatalf("expected error containing %q, got %v", tc.wantSubstr, toolErr)
+				}
+				var agentErr *util.AgentError
+				if !errors.As(toolErr, &agentErr) {
+					t.Errorf("expected agent error, got %T", toolErr)
+				}
+				if src.called != tc.wantCalled {
+					t.Errorf("got source called %t, want %t", src.called, tc.wantCalled)
+				}
+				return
+			}
+			if toolErr != nil {
+				t.Fatalf("unexpected error: %v", toolErr)
+			}
+			if src.gotName != tc.wantName {
+				t.Errorf("got name %q, want %q", src.gotName, tc.wantName)
+			}
+			if src.gotLogsProject != tc.wantLogs {
+				t.Errorf("got logsProject %q, want %q", src.gotLogsProject, tc.wantLogs)
+			}
+			if src.gotInsertID != "abc123" {
+				t.Errorf("got insertId %q, want %q", src.gotInsertID, "abc123")
+			}
+			if resp, _ := got.(map[string]any); resp["insertId"] != "abc123" {
+				t.Errorf("got %v, want insertId abc123", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,152 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serverlesssparkgetsessiondetailswithlogs
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/goccy/go-yaml"
+	"github.com/googleapis/mcp-toolbox/internal/sources/serverlessspark"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/googleapis/mcp-toolbox/internal/util"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const (
+	resourceType = "serverless-spark-get-session-details-with-logs"
+
+	defaultErrorLogLimit = 10
+	maxErrorLogLimit     = 100
+)
+
+func init() {
+	if !tools.Register(resourceType, newConfig) {
+		panic(fmt.Sprintf("tool type %q already registered", resourceType))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{ConfigBase: tools.ConfigBase{Name: name}}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type compatibleSource interface {
+	GetProject() string
+	GetLocation() string
+	GetSessionWithErrorLogs(context.Context, string, int, string, bool, string) (map[string]any, error)
+}
+
+type Config struct {
+	tools.ConfigBase `yaml:",inline"`
+	Type             string                 `yaml:"type" validate:"required"`
+	Source           string                 `yaml:"source" validate:"required"`
+	Annotations      *tools.ToolAnnotations `yaml:"annotations,omitempty"`
+}
+
+// validate interface
+var _ tools.ToolConfig = Config{}
+
+// ToolConfigType returns the unique name for this tool.
+func (cfg Config) ToolConfigType() string {
+	return resourceType
+}
+
+// Initialize creates a new Tool instance.
+func (cfg Config) Initialize(context.Context) (tools.Tool, error) {
+	desc := cfg.Description
+	if desc == "" {
+		desc = "Gets a Serverless Spark (aka Dataproc Serverless) session and, if it failed, its most recent error logs"
+	}
+
+	allParameters := parameters.Parameters{
+		parameters.NewStringParameter("name", "The short name of the session, e.g. for \"projects/my-project/locations/us-central1/sessions/my-session\", pass \"my-session\" (the project and location are inherited from the source)"),
+		parameters.NewBoundedIntParameter("errorLogLimit", 1, maxErrorLogLimit, defaultErrorLogLimit, fmt.Sprintf("The maximum number of ERROR log entries to return if the session failed, newest first, between 1 and %d. Defaults to %d.", maxErrorLogLimit, defaultErrorLogLimit)),
+		parameters.NewStringParameter("filter", `An additional Cloud Logging filter the error log entries must match, e.g. textPayload:"OutOfMemoryError". It is combined with the session's resource, time range, and severity clauses with AND.`, parameters.WithStringRequired(false)),
+		parameters.NewBooleanParameter("rawFilterOnly", "Set to true to match the error log entries against only filter, severity ERROR or higher, and the session's time range, without the generated resource.type and resource.labels.* clauses, e.g. to find entries the session's resource labels do not cover. Requires filter. Defaults to false.", parameters.WithBooleanRequired(false)),
+		parameters.NewStringParameter("logsProject", "Optional. The project to read the session's logs from, if they are routed to a different project than the session's, e.g. a central logging project. Defaults to the session's project.", parameters.WithStringRequired(false)),
+	}
+
+	return Tool{
+		BaseTool: tools.NewBaseTool(
+			cfg,
+			tools.GetAnnotationsOrDefault(cfg.Annotations, tools.NewReadOnlyAnnotations),
+			tools.Manifest{Description: desc, Parameters: allParameters.Manifest(), AuthRequired: cfg.AuthRequired},
+			allParameters,
+		),
+	}, nil
+}
+
+// validate interface
+var _ tools.Tool = Tool{}
+
+// Tool is the implementation of the tool.
+type Tool struct {
+	tools.BaseTool[Config]
+}
+
+// Invoke executes the tool's operation.
+func (t Tool) Invoke(ctx context.Context, resourceMgr tools.SourceProvider, params parameters.ParamValues, accessToken tools.AccessToken) (_ any, toolErr util.ToolboxError) {
+	ctx, span := tools.StartInvokeSpan(ctx, t.Cfg.Type, t.Cfg.Name)
+	defer func() { tools.EndInvokeSpan(span, toolErr) }()
+
+	source, err := tools.GetCompatibleSource[compatibleSource](resourceMgr, t.Cfg.Source, t.Cfg.Name, t.Cfg.Type)
+	if err != nil {
+		return nil, util.NewClientServerError("source used is not compatible with the tool", http.StatusInternalServerError, err)
+	}
+	span.SetAttributes(attribute.String("project", source.GetProject()), attribute.String("location", source.GetLocation()))
+	paramMap := params.AsMap()
+	name, ok := paramMap["name"].(string)
+	if !ok {
+		return nil, util.NewAgentError("missing required parameter: name", nil)
+	}
+	limit := defaultErrorLogLimit
+	if v, ok := paramMap["errorLogLimit"].(int); ok {
+		limit = v
+	}
+	filter, _ := paramMap["filter"].(string)
+	if err := util.ValidateFilterSyntax(filter); err != nil {
+		return nil, util.NewAgentError(err.Error(), nil)
+	}
+	rawFilterOnly, _ := paramMap["rawFilterOnly"].(bool)
+	if rawFilterOnly && filter == "" {
+		return nil, util.NewAgentError("rawFilterOnly requires filter", nil)
+	}
+	logsProject, _ := paramMap["logsProject"].(string)
+	if logsProject != "" {
+		if err := serverlessspark.ValidateProjectID(logsProject); err != nil {
+			return nil, util.NewAgentError(err.Error(), nil)
+		}
+	}
+	resourceName, err := serverlessspark.SessionResourceName(source.GetProject(), source.GetLocation(), name)
+	if err != nil {
+		return nil, util.NewAgentError(fmt.Sprintf("name %v", err), err)
+	}
+	span.SetAttributes(attribute.String("session_id", name))
+	res, err := source.GetSessionWithErrorLogs(ctx, resourceName, limit, filter, rawFilterOnly, logsProject)
+	if err != nil {
+		return nil, serverlessspark.ProcessError(err)
+	}
+	return res, nil
+}
+
+func (t Tool) ToConfig() tools.ToolConfig {
+	return t.Cfg
+}
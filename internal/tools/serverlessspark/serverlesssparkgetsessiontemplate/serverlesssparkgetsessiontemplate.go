@@ -22,9 +22,11 @@ import (
 
 	dataproc "cloud.google.com/go/dataproc/v2/apiv1"
 	"github.com/goccy/go-yaml"
+	"github.com/googleapis/mcp-toolbox/internal/sources/serverlessspark"
 	"github.com/googleapis/mcp-toolbox/internal/tools"
 	"github.com/googleapis/mcp-toolbox/internal/util"
 	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 const resourceType = "serverless-spark-get-session-template"
@@ -44,6 +46,8 @@ func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.T
 }
 
 type compatibleSource interface {
+	GetProject() string
+	GetLocation() string
 	GetSessionTemplateControllerClient() *dataproc.SessionTemplateControllerClient
 	GetSessionTemplate(context.Context, string) (map[string]any, error)
 }
@@ -93,11 +97,15 @@ type Tool struct {
 }
 
 // Invoke executes the tool's operation.
-func (t Tool) Invoke(ctx context.Context, resourceMgr tools.SourceProvider, params parameters.ParamValues, accessToken tools.AccessToken) (any, util.ToolboxError) {
+func (t Tool) Invoke(ctx context.Context, resourceMgr tools.SourceProvider, params parameters.ParamValues, accessToken tools.AccessToken) (_ any, toolErr util.ToolboxError) {
+	ctx, span := tools.StartInvokeSpan(ctx, t.Cfg.Type, t.Cfg.Name)
+	defer func() { tools.EndInvokeSpan(span, toolErr) }()
+
 	source, err := tools.GetCompatibleSource[compatibleSource](resourceMgr, t.Cfg.Source, t.Cfg.Name, t.Cfg.Type)
 	if err != nil {
 		return nil, util.NewClientServerError("source used is not compatible with the tool", http.StatusInternalServerError, err)
 	}
+	span.SetAttributes(attribute.String("project", source.GetProject()), attribute.String("location", source.GetLocation()))
 	paramMap := params.AsMap()
 	name, ok := paramMap["name"].(string)
 	if !ok {
@@ -106,9 +114,10 @@ func (t Tool) Invoke(ctx context.Context, resourceMgr tools.SourceProvider, para
 	if strings.Contains(name, "/") {
 		return nil, util.NewAgentError(fmt.Sprintf("name must be a short session template name without '/': %s", name), nil)
 	}
+	span.SetAttributes(attribute.String("session_template_id", name))
 	res, err := source.GetSessionTemplate(ctx, name)
 	if err != nil {
-		return nil, util.ProcessGcpError(err)
+		return nil, serverlessspark.ProcessError(err)
 	}
 	return res, nil
 }
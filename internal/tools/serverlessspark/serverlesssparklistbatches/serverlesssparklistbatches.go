@@ -16,18 +16,27 @@ package serverlesssparklistbatches
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"time"
 
 	dataproc "cloud.google.com/go/dataproc/v2/apiv1"
 	"github.com/goccy/go-yaml"
+	"github.com/googleapis/mcp-toolbox/internal/sources/serverlessspark"
 	"github.com/googleapis/mcp-toolbox/internal/tools"
 	"github.com/googleapis/mcp-toolbox/internal/util"
 	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 const resourceType = "serverless-spark-list-batches"
 
+const defaultPageSize = 20
+
+// maxTimeout bounds the timeout parameter.
+const maxTimeout = 5 * time.Minute
+
 func init() {
 	if !tools.Register(resourceType, newConfig) {
 		panic(fmt.Sprintf("tool type %q already registered", resourceType))
@@ -43,8 +52,12 @@ func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.T
 }
 
 type compatibleSource interface {
+	GetProject() string
+	GetLocation() string
 	GetBatchControllerClient() *dataproc.BatchControllerClient
-	ListBatches(context.Context, *int, string, string) (any, error)
+	ListBatches(context.Context, string, *int, string, string, bool) (any, error)
+	IsLocationAllowed(string) bool
+	ListBatchesInLocations(context.Context, string, []string, int, string, bool) (any, error)
 }
 
 type Config struct {
@@ -71,8 +84,13 @@ func (cfg Config) Initialize(context.Context) (tools.Tool, error) {
 
 	allParameters := parameters.Parameters{
 		parameters.NewStringParameter("filter", `Filter expression to limit the batches. Filters are case sensitive, and may contain multiple clauses combined with logical operators (AND/OR, case sensitive). Supported fields are batch_id, batch_uuid, state, create_time, and labels. e.g. state = RUNNING AND create_time < "2023-01-01T00:00:00Z" filters for batches in state RUNNING that were created before 2023-01-01. state = RUNNING AND labels.environment=production filters for batches in state in a RUNNING state that have a production environment label. Valid states are STATE_UNSPECIFIED, PENDING, RUNNING, CANCELLING, CANCELLED, SUCCEEDED, FAILED. Valid operators are < > <= >= = !=, and : as "has" for labels, meaning any non-empty value)`, parameters.WithStringRequired(false)),
-		parameters.NewIntParameter("pageSize", "The maximum number of batches to return in a single page (default 20)", parameters.WithIntDefault(20)),
+		parameters.NewBoundedIntParameter("pageSize", 1, parameters.MaxAPIPageSize, defaultPageSize, fmt.Sprintf("The maximum number of batches to return in a single page, between 1 and %d (default %d). With locations, the maximum number of batches to return across all locations.", parameters.MaxAPIPageSize, defaultPageSize)),
 		parameters.NewStringParameter("pageToken", "A page token, received from a previous `ListBatches` call", parameters.WithStringRequired(false)),
+		parameters.NewStringParameter("project", "The ID of the project to list batches in. Defaults to the source's project.", parameters.WithStringRequired(false)),
+		parameters.NewArrayParameter("locations", "Locations to list batches in concurrently, e.g. [\"us-central1\", \"europe-west4\"], instead of the source's location. The newest batches across all locations are returned, each with its location, and locations that fail are reported in errors. Cannot be combined with pageToken.", parameters.NewStringParameter("location", "A location, e.g. us-central1"), parameters.WithArrayRequired(false)),
+		parameters.NewBooleanParameter("includeLabels", "Set to true to include each batch's labels in the response, e.g. to confirm matches of a label filter. Defaults to false.", parameters.WithBooleanDefault(false)),
+		parameters.NewBooleanParameter("alwaysIncludePageToken", "Set to true to include nextPageToken as an empty string on the last page, instead of omitting it. Has no effect with locations, which does not page. Defaults to false.", parameters.WithBooleanDefault(false)),
+		parameters.NewStringParameter("timeout", fmt.Sprintf("How long to wait for the list, as a duration (e.g., 10s, 1m), at most %s. If unset, the list may take as long as the overall request allows.", maxTimeout), parameters.WithStringRequired(false)),
 	}
 	return Tool{
 		BaseTool: tools.NewBaseTool(
@@ -93,41 +111,104 @@ type Tool struct {
 }
 
 // Invoke executes the tool's operation.
-func (t Tool) Invoke(ctx context.Context, resourceMgr tools.SourceProvider, params parameters.ParamValues, accessToken tools.AccessToken) (any, util.ToolboxError) {
+func (t Tool) Invoke(ctx context.Context, resourceMgr tools.SourceProvider, params parameters.ParamValues, accessToken tools.AccessToken) (_ any, toolErr util.ToolboxError) {
+	ctx, span := tools.StartInvokeSpan(ctx, t.Cfg.Type, t.Cfg.Name)
+	defer func() { tools.EndInvokeSpan(span, toolErr) }()
+
 	source, err := tools.GetCompatibleSource[compatibleSource](resourceMgr, t.Cfg.Source, t.Cfg.Name, t.Cfg.Type)
 	if err != nil {
 		return nil, util.NewClientServerError("source used is not compatible with the tool", http.StatusInternalServerError, err)
 	}
+	span.SetAttributes(attribute.String("project", source.GetProject()), attribute.String("location", source.GetLocation()))
 
 	paramMap := params.AsMap()
 	var pageSize *int
-	if ps, ok := paramMap["pageSize"]; ok && ps != nil {
-		pageSizeV, ok := ps.(int)
-		if !ok {
-			// Handle float64 case if unmarshaled from JSON usually
-			if f, ok := ps.(float64); ok {
-				pageSizeV = int(f)
-			} else {
-				return nil, util.NewAgentError("pageSize must be an integer", nil)
-			}
+	if ps, ok := paramMap["pageSize"].(int); ok {
+		pageSize = &ps
+	}
+
+	pt, _ := paramMap["pageToken"].(string)
+	filter, _ := paramMap["filter"].(string)
+	if err := util.ValidateFilterSyntax(filter); err != nil {
+		return nil, util.NewAgentError(err.Error(), nil)
+	}
+	project, _ := paramMap["project"].(string)
+	if project != "" {
+		if err := serverlessspark.ValidateProjectID(project); err != nil {
+			return nil, util.NewAgentError(err.Error(), nil)
 		}
+		span.SetAttributes(attribute.String("project", project))
+	}
+
+	includeLabels, _ := paramMap["includeLabels"].(bool)
+	alwaysIncludePageToken, _ := paramMap["alwaysIncludePageToken"].(bool)
 
-		if pageSizeV <= 0 {
-			return nil, util.NewAgentError(fmt.Sprintf("pageSize must be positive: %d", pageSizeV), nil)
+	var timeout time.Duration
+	if s, _ := paramMap["timeout"].(string); s != "" {
+		timeout, err = time.ParseDuration(s)
+		if err != nil {
+			return nil, util.NewAgentError(fmt.Sprintf("timeout must be a duration like 30s: %q", s), err)
 		}
-		pageSize = &pageSizeV
+		if timeout <= 0 || timeout > maxTimeout {
+			return nil, util.NewAgentError(fmt.Sprintf("timeout must be positive and at most %s: %s", maxTimeout, timeout), nil)
+		}
+	}
+	listCtx := ctx
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		listCtx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
 	}
 
-	pt, _ := paramMap["pageToken"].(string)
-	filter, _ := paramMap["filter"].(string)
+	if rawLocations, _ := paramMap["locations"].([]any); len(rawLocations) > 0 {
+		if pt != "" {
+			return nil, util.NewAgentError("pageToken cannot be combined with locations", nil)
+		}
+		locations := make([]string, 0, len(rawLocations))
+		for _, l := range rawLocations {
+			location, ok := l.(string)
+			if !ok {
+				return nil, util.NewAgentError(fmt.Sprintf("locations must be strings: %v", l), nil)
+			}
+			if err := serverlessspark.ValidateLocation(location); err != nil {
+				return nil, util.NewAgentError(err.Error(), nil)
+			}
+			if !source.IsLocationAllowed(location) {
+				return nil, util.NewAgentError(fmt.Sprintf("access denied to location %q because it is not in the configured list of allowed locations", location), nil)
+			}
+			locations = append(locations, location)
+		}
+		limit := defaultPageSize
+		if pageSize != nil {
+			limit = *pageSize
+		}
+		resp, err := source.ListBatchesInLocations(listCtx, project, locations, limit, filter, includeLabels)
+		if err != nil {
+			return nil, listError(ctx, listCtx, timeout, err)
+		}
+		return resp, nil
+	}
 
-	resp, err := source.ListBatches(ctx, pageSize, pt, filter)
+	resp, err := source.ListBatches(listCtx, project, pageSize, pt, filter, includeLabels)
 	if err != nil {
-		return nil, util.ProcessGcpError(err)
+		return nil, listError(ctx, listCtx, timeout, err)
+	}
+	if alwaysIncludePageToken {
+		return serverlessspark.WithAlwaysPageToken(resp), nil
 	}
 	return resp, nil
 }
 
+// listError converts an error from listing batches with listCtx, derived from
+// ctx, into a ToolboxError. If the timeout parameter expired, rather than ctx,
+// the agent is told so that it may retry with a longer timeout.
+func listError(ctx, listCtx context.Context, timeout time.Duration, err error) util.ToolboxError {
+	if errors.Is(listCtx.Err(), context.DeadlineExceeded) && ctx.Err() == nil {
+		return util.NewAgentError(fmt.Sprintf("listing batches did not finish within the %s timeout", timeout), err)
+	}
+	return serverlessspark.ProcessError(err)
+}
+
 func (t Tool) ToConfig() tools.ToolConfig {
 	return t.Cfg
 }
@@ -15,13 +15,21 @@
 package serverlesssparklistbatches_test
 
 import (
+	"context"
+	"encoding/json"
+	"slices"
+	"strings"
 	"testing"
 
+	dataproc "cloud.google.com/go/dataproc/v2/apiv1"
 	"github.com/google/go-cmp/cmp"
 	"github.com/googleapis/mcp-toolbox/internal/server"
+	"github.com/googleapis/mcp-toolbox/internal/sources"
+	"github.com/googleapis/mcp-toolbox/internal/sources/serverlessspark"
 	"github.com/googleapis/mcp-toolbox/internal/testutils"
 	"github.com/googleapis/mcp-toolbox/internal/tools"
 	"github.com/googleapis/mcp-toolbox/internal/tools/serverlessspark/serverlesssparklistbatches"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
 )
 
 func TestParseFromYaml(t *testing.T) {
@@ -69,3 +77,357 @@ func TestParseFromYaml(t *testing.T) {
 		})
 	}
 }
+
+func TestAuthorized(t *testing.T) {
+	cfg := serverlesssparklistbatches.Config{
+		ConfigBase: tools.ConfigBase{
+			Name:         "example_tool",
+			AuthRequired: []string{"my-google-auth"},
+		},
+		Type:   "serverless-spark-list-batches",
+		Source: "my-instance",
+	}
+	tool, err := cfg.Initialize(context.Background())
+	if err != nil {
+		t.Fatalf("failed to initialize tool: %v", err)
+	}
+
+	tcs := []struct {
+		desc     string
+		services []string
+		want     bool
+	}{
+		{desc: "required service verified", services: []string{"my-google-auth"}, want: true},
+		{desc: "other service verified", services: []string{"other-auth"}, want: false},
+		{desc: "no service verified", services: nil, want: false},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := tool.Authorized(tc.services); got != tc.want {
+				t.Errorf("Authorized(%v) = %v, want %v", tc.services, got, tc.want)
+			}
+		})
+	}
+}
+
+type mockSource struct {
+	sources.Source
+	called           bool
+	gotProject       string
+	gotIncludeLabels bool
+	gotLocations     []string
+	gotLimit         int
+	allowedLocations []string
+	// block makes the list calls wait until their context is done.
+	block bool
+}
+
+func (m *mockSource) GetProject() string {
+	return "my-project"
+}
+
+func (m *mockSource) GetLocation() string {
+	return "us-central1"
+}
+
+func (m *mockSource) IsLocationAllowed(location string) bool {
+	return len(m.allowedLocations) == 0 || slices.Contains(m.allowedLocations, location)
+}
+
+func (m *mockSource) GetBatchControllerClient() *dataproc.BatchControllerClient {
+	return nil
+}
+
+func (m *mockSource) ListBatches(ctx context.Context, project string, ps *int, pt, filter string, includeLabels bool) (any, error) {
+	m.called = true
+	if m.block {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	m.gotProject = project
+	m.gotIncludeLabels = includeLabels
+	return serverlessspark.ListBatchesResponse{Batches: []serverlessspark.Batch{}}, nil
+}
+
+func (m *mockSource) ListBatchesInLocations(ctx context.Context, project string, locations []string, limit int, filter string, includeLabels bool) (any, error) {
+	m.called = true
+	m.gotProject = project
+	m.gotLocations = locations
+	m.gotLimit = limit
+	return map[string]any{}, nil
+}
+
+type mockSourceProvider struct {
+	tools.SourceProvider
+	source *mockSource
+}
+
+func (m *mockSourceProvider) GetSource(name string) (sources.Source, bool) {
+	return m.source, true
+}
+
+func TestInvokeProject(t *testing.T) {
+	cfg := serverlesssparklistbatches.Config{
+		ConfigBase: tools.ConfigBase{Name: "example_tool"},
+		Type:       "serverless-spark-list-batches",
+		Source:     "my-instance",
+	}
+	tool, err := cfg.Initialize(context.Background())
+	if err != nil {
+		t.Fatalf("failed to initialize tool: %v", err)
+	}
+
+	tcs := []struct {
+		desc       string
+		project    string
+		wantSubstr string
+	}{
+		{desc: "default", project: ""},
+		{desc: "override", project: "other-project"},
+		{desc: "domain scoped", project: "example.com:other-project"},
+		{desc: "resource name", project: "projects/other-project", wantSubstr: "invalid project"},
+		{desc: "uppercase", project: "Other-Project", wantSubstr: "invalid project"},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			src := &mockSource{}
+			resourceMgr := &mockSourceProvider{source: src}
+			params := parameters.ParamValues{{Name: "pageSize", Value: 20}}
+			if tc.project != "" {
+				params = append(params, parameters.ParamValue{Name: "project", Value: tc.project})
+			}
+			_, toolErr := tool.Invoke(context.Background(), resourceMgr, params, "")
+			if tc.wantSubstr != "" {
+				if toolErr == nil || !strings.Contains(toolErr.Error(), tc.wantSubstr) {
+					t.Fatalf("expected error containing %q, got %v", tc.wantSubstr, toolErr)
+				}
+				if src.called {
+					t.Errorf("expected source not to be called on validation failure")
+				}
+				return
+			}
+			if toolErr != nil {
+				t.Fatalf("unexpected error: %v", toolErr)
+			}
+			if src.gotProject != tc.project {
+				t.Errorf("got project %q, want %q", src.gotProject, tc.project)
+			}
+		})
+	}
+}
+
+func TestInvokeMalformedFilter(t *testing.T) {
+	cfg := serverlesssparklistbatches.Config{
+		ConfigBase: tools.ConfigBase{Name: "example_tool"},
+		Type:       "serverless-spark-list-batches",
+		Source:     "my-instance",
+	}
+	tool, err := cfg.Initialize(context.Background())
+	if err != nil {
+		t.Fatalf("failed to initialize tool: %v", err)
+	}
+
+	src := &mockSource{}
+	params := parameters.ParamValues{{Name: "pageSize", Value: 20}, {Name: "filter", Value: `state = RUNNING AND create_time < "2023-01-01`}}
+	_, toolErr := tool.Invoke(context.Background(), &mockSourceProvider{source: src}, params, "")
+	if toolErr == nil || !strings.Contains(toolErr.Error(), "malformed filter") {
+		t.Fatalf("expected malformed filter error, got %v", toolErr)
+	}
+	if src.called {
+		t.Errorf("expected source not to be called on validation failure")
+	}
+}
+
+func TestInvokeIncludeLabels(t *testing.T) {
+	cfg := serverlesssparklistbatches.Config{
+		ConfigBase: tools.ConfigBase{Name: "example_tool"},
+		Type:       "serverless-spark-list-batches",
+		Source:     "my-instance",
+	}
+	tool, err := cfg.Initialize(context.Background())
+	if err != nil {
+		t.Fatalf("failed to initialize tool: %v", err)
+	}
+
+	for _, want := range []bool{false, true} {
+		src := &mockSource{}
+		params := parameters.ParamValues{{Name: "pageSize", Value: 20}, {Name: "includeLabels", Value: want}}
+		if _, toolErr := tool.Invoke(context.Background(), &mockSourceProvider{source: src}, params, ""); toolErr != nil {
+			t.Fatalf("unexpected error: %v", toolErr)
+		}
+		if src.gotIncludeLabels != want {
+			t.Errorf("got includeLabels %v, want %v", src.gotIncludeLabels, want)
+		}
+	}
+}
+
+func TestInvokeAlwaysIncludePageToken(t *testing.T) {
+	cfg := serverlesssparklistbatches.Config{
+		ConfigBase: tools.ConfigBase{Name: "example_tool"},
+		Type:       "serverless-spark-list-batches",
+		Source:     "my-instance",
+	}
+	tool, err := cfg.Initialize(context.Background())
+	if err != nil {
+		t.Fatalf("failed to initialize tool: %v", err)
+	}
+
+	tcs := []struct {
+		desc   string
+		params parameters.ParamValues
+		want   string
+	}{
+		{
+			desc:   "default",
+			params: parameters.ParamValues{{Name: "pageSize", Value: 20}},
+			want:   `{"batches":[]}`,
+		},
+		{
+			desc:   "always",
+			params: parameters.ParamValues{{Name: "pageSize", Value: 20}, {Name: "alwaysIncludePageToken", Value: true}},
+			want:   `{"batches":[],"nextPageToken":""}`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, toolErr := tool.Invoke(context.Background(), &mockSourceProvider{source: &mockSource{}}, tc.params, "")
+			if toolErr != nil {
+				t.Fatalf("unexpected error: %v", toolErr)
+			}
+			b, err := json.Marshal(got)
+			if err != nil {
+				t.Fatalf("failed to marshal result: %v", err)
+			}
+			if string(b) != tc.want {
+				t.Errorf("got %s, want %s", b, tc.want)
+			}
+		})
+	}
+}
+
+func TestInvokeLocations(t *testing.T) {
+	cfg := serverlesssparklistbatches.Config{
+		ConfigBase: tools.ConfigBase{Name: "example_tool"},
+		Type:       "serverless-spark-list-batches",
+		Source:     "my-instance",
+	}
+	tool, err := cfg.Initialize(context.Background())
+	if err != nil {
+		t.Fatalf("failed to initialize tool: %v", err)
+	}
+
+	tcs := []struct {
+		desc             string
+		allowedLocations []string
+		params           parameters.ParamValues
+		wantLocations    []string
+		wantLimit        int
+		wantSubstr       string
+	}{
+		{
+			desc:          "locations",
+			params:        parameters.ParamValues{{Name: "pageSize", Value: 5}, {Name: "locations", Value: []any{"us-central1", "europe-west4"}}},
+			wantLocations: []string{"us-central1", "europe-west4"},
+			wantLimit:     5,
+		},
+		{
+			desc:          "default limit",
+			params:        parameters.ParamValues{{Name: "locations", Value: []any{"us-central1"}}},
+			wantLocations: []string{"us-central1"},
+			wantLimit:     20,
+		},
+		{
+			desc:       "invalid location",
+			params:     parameters.ParamValues{{Name: "locations", Value: []any{"us-central1", "locations/europe-west4"}}},
+			wantSubstr: "invalid location",
+		},
+		{
+			desc:             "allowed locations",
+			allowedLocations: []string{"us-central1", "europe-west4"},
+			params:           parameters.ParamValues{{Name: "locations", Value: []any{"europe-west4"}}},
+			wantLocations:    []string{"europe-west4"},
+			wantLimit:        20,
+		},
+		{
+			desc:             "location not allowed",
+			allowedLocations: []string{"us-central1"},
+			params:           parameters.ParamValues{{Name: "locations", Value: []any{"us-central1", "europe-west4"}}},
+			wantSubstr:       "access denied to location \"europe-west4\"",
+		},
+		{
+			desc:       "page token",
+			params:     parameters.ParamValues{{Name: "pageToken", Value: "token"}, {Name: "locations", Value: []any{"us-central1"}}},
+			wantSubstr: "pageToken cannot be combined with locations",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			src := &mockSource{allowedLocations: tc.allowedLocations}
+			_, toolErr := tool.Invoke(context.Background(), &mockSourceProvider{source: src}, tc.params, "")
+			if tc.wantSubstr != "" {
+				if toolErr == nil || !strings.Contains(toolErr.Error(), tc.wantSubstr) {
+					t.Fatalf("expected error containing %q, got %v", tc.wantSubstr, toolErr)
+				}
+				if src.called {
+					t.Errorf("expected source not to be called on validation failure")
+				}
+				return
+			}
+			if toolErr != nil {
+				t.Fatalf("unexpected error: %v", toolErr)
+			}
+			if !slices.Equal(src.gotLocations, tc.wantLocations) {
+				t.Errorf("got locations %v, want %v", src.gotLocations, tc.wantLocations)
+			}
+			if src.gotLimit != tc.wantLimit {
+				t.Errorf("got limit %d, want %d", src.gotLimit, tc.wantLimit)
+			}
+		})
+	}
+}
+
+func TestInvokeTimeout(t *testing.T) {
+	cfg := serverlesssparklistbatches.Config{
+		ConfigBase: tools.ConfigBase{Name: "example_tool"},
+		Type:       "serverless-spark-list-batches",
+		Source:     "my-instance",
+	}
+	tool, err := cfg.Initialize(context.Background())
+	if err != nil {
+		t.Fatalf("failed to initialize tool: %v", err)
+	}
+
+	tcs := []struct {
+		desc       string
+		timeout    string
+		block      bool
+		wantCalled bool
+		wantSubstr string
+	}{
+		{desc: "no timeout", wantCalled: true},
+		{desc: "finishes in time", timeout: "1m", wantCalled: true},
+		{desc: "expires", timeout: "10ms", block: true, wantCalled: true, wantSubstr: "listing batches did not finish within the 10ms timeout"},
+		{desc: "malformed", timeout: "soon", wantSubstr: `timeout must be a duration like 30s: "soon"`},
+		{desc: "zero", timeout: "0s", wantSubstr: "timeout must be positive and at most 5m0s: 0s"},
+		{desc: "too long", timeout: "10m", wantSubstr: "timeout must be positive and at most 5m0s: 10m0s"},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			src := &mockSource{block: tc.block}
+			params := parameters.ParamValues{{Name: "pageSize", Value: 20}, {Name: "timeout", Value: tc.timeout}}
+			_, toolErr := tool.Invoke(context.Background(), &mockSourceProvider{source: src}, params, "")
+			if src.called != tc.wantCalled {
+				t.Errorf("source called = %v, want %v", src.called, tc.wantCalled)
+			}
+			if tc.wantSubstr != "" {
+				if toolErr == nil || !strings.Contains(toolErr.Error(), tc.wantSubstr) {
+					t.Fatalf("expected error containing %q, got %v", tc.wantSubstr, toolErr)
+				}
+				return
+			}
+			if toolErr != nil {
+				t.Fatalf("unexpected error: %v", toolErr)
+			}
+		})
+	}
+}
@@ -21,9 +21,11 @@ import (
 
 	dataproc "cloud.google.com/go/dataproc/v2/apiv1"
 	"github.com/goccy/go-yaml"
+	"github.com/googleapis/mcp-toolbox/internal/sources/serverlessspark"
 	"github.com/googleapis/mcp-toolbox/internal/tools"
 	"github.com/googleapis/mcp-toolbox/internal/util"
 	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 const resourceType = "serverless-spark-list-sessions"
@@ -43,6 +45,8 @@ func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.T
 }
 
 type compatibleSource interface {
+	GetProject() string
+	GetLocation() string
 	GetSessionControllerClient() *dataproc.SessionControllerClient
 	ListSessions(context.Context, *int, string, string) (any, error)
 }
@@ -71,8 +75,9 @@ func (cfg Config) Initialize(context.Context) (tools.Tool, error) {
 
 	allParameters := parameters.Parameters{
 		parameters.NewStringParameter("filter", `A filter for the sessions to return in the response. A filter is a logical expression constraining the values of various fields in each session resource. Filters are case sensitive, and may contain multiple clauses combined with logical operators (AND, OR). Supported fields are session_id, session_uuid, state, create_time, and labels. Example: state = ACTIVE and create_time < "2023-01-01T00:00:00Z" is a filter for sessions in an ACTIVE state that were created before 2023-01-01. state = ACTIVE and labels.environment=production is a filter for sessions in an ACTIVE state that have a production environment label.`, parameters.WithStringRequired(false)),
-		parameters.NewIntParameter("pageSize", "The maximum number of sessions to return in a single page (default 20)", parameters.WithIntDefault(20)),
+		parameters.NewBoundedIntParameter("pageSize", 1, parameters.MaxAPIPageSize, 20, fmt.Sprintf("The maximum number of sessions to return in a single page, between 1 and %d (default 20)", parameters.MaxAPIPageSize)),
 		parameters.NewStringParameter("pageToken", "A page token, received from a previous `ListSessions` call", parameters.WithStringRequired(false)),
+		parameters.NewBooleanParameter("alwaysIncludePageToken", "Set to true to include nextPageToken as an empty string on the last page, instead of omitting it. Defaults to false.", parameters.WithBooleanDefault(false)),
 	}
 
 	return Tool{
@@ -94,25 +99,28 @@ type Tool struct {
 }
 
 // Invoke executes the tool's operation.
-func (t Tool) Invoke(ctx context.Context, resourceMgr tools.SourceProvider, params parameters.ParamValues, accessToken tools.AccessToken) (any, util.ToolboxError) {
+func (t Tool) Invoke(ctx context.Context, resourceMgr tools.SourceProvider, params parameters.ParamValues, accessToken tools.AccessToken) (_ any, toolErr util.ToolboxError) {
+	ctx, span := tools.StartInvokeSpan(ctx, t.Cfg.Type, t.Cfg.Name)
+	defer func() { tools.EndInvokeSpan(span, toolErr) }()
+
 	source, err := tools.GetCompatibleSource[compatibleSource](resourceMgr, t.Cfg.Source, t.Cfg.Name, t.Cfg.Type)
 	if err != nil {
 		return nil, util.NewClientServerError("source used is not compatible with the tool", http.StatusInternalServerError, err)
 	}
+	span.SetAttributes(attribute.String("project", source.GetProject()), attribute.String("location", source.GetLocation()))
 	paramMap := params.AsMap()
 	var pageSize *int
-	if ps, ok := paramMap["pageSize"]; ok && ps != nil {
-		pageSizeV := ps.(int)
-		if pageSizeV <= 0 {
-			return nil, util.NewAgentError(fmt.Sprintf("pageSize must be positive: %d", pageSizeV), nil)
-		}
-		pageSize = &pageSizeV
+	if ps, ok := paramMap["pageSize"].(int); ok {
+		pageSize = &ps
 	}
 	pt, _ := paramMap["pageToken"].(string)
 	filter, _ := paramMap["filter"].(string)
 	res, err := source.ListSessions(ctx, pageSize, pt, filter)
 	if err != nil {
-		return nil, util.ProcessGcpError(err)
+		return nil, serverlessspark.ProcessError(err)
+	}
+	if alwaysIncludePageToken, _ := paramMap["alwaysIncludePageToken"].(bool); alwaysIncludePageToken {
+		return serverlessspark.WithAlwaysPageToken(res), nil
 	}
 	return res, nil
 }
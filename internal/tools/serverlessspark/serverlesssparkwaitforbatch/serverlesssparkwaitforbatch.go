@@ -0,0 +1,161 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serverlesssparkwaitforbatch
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/goccy/go-yaml"
+	"github.com/googleapis/mcp-toolbox/internal/sources/serverlessspark"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/googleapis/mcp-toolbox/internal/util"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const resourceType = "serverless-spark-wait-for-batch"
+
+const (
+	defaultTimeout = 10 * time.Minute
+	maxTimeout     = 1 * time.Hour
+)
+
+// batchStates are the states that may be waited for.
+var batchStates = []any{"PENDING", "RUNNING", "CANCELLING", "CANCELLED", "SUCCEEDED", "FAILED"}
+
+func init() {
+	if !tools.Register(resourceType, newConfig) {
+		panic(fmt.Sprintf("tool type %q already registered", resourceType))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{ConfigBase: tools.ConfigBase{Name: name}}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type compatibleSource interface {
+	GetProject() string
+	GetLocation() string
+	WaitForBatch(context.Context, string, []string, time.Duration) (map[string]any, error)
+}
+
+type Config struct {
+	tools.ConfigBase `yaml:",inline"`
+	Type             string                 `yaml:"type" validate:"required"`
+	Source           string                 `yaml:"source" validate:"required"`
+	Annotations      *tools.ToolAnnotations `yaml:"annotations,omitempty"`
+}
+
+// validate interface
+var _ tools.ToolConfig = Config{}
+
+// ToolConfigType returns the unique name for this tool.
+func (cfg Config) ToolConfigType() string {
+	return resourceType
+}
+
+// Initialize creates a new Tool instance.
+func (cfg Config) Initialize(context.Context) (tools.Tool, error) {
+	desc := cfg.Description
+	if desc == "" {
+		desc = "Waits for a Serverless Spark (aka Dataproc Serverless) batch to reach a state, such as finishing"
+	}
+
+	allParameters := parameters.Parameters{
+		parameters.NewStringParameter("name", "The short name of the batch, e.g. for \"projects/my-project/locations/us-central1/batches/my-batch\", pass \"my-batch\" (the project and location are inherited from the source)"),
+		parameters.NewArrayParameter("targetStates", "The states to wait for, e.g. [\"RUNNING\"]. Defaults to any terminal state: SUCCEEDED, FAILED, or CANCELLED.", parameters.NewStringParameter("state", "A batch state", parameters.WithStringAllowedValues(batchStates)), parameters.WithArrayDefault([]any{})),
+		parameters.NewStringParameter("timeout", fmt.Sprintf("How long to wait, as a duration (e.g., 30s, 5m), at most %s. Defaults to %s.", maxTimeout, defaultTimeout), parameters.WithStringRequired(false)),
+	}
+
+	return Tool{
+		BaseTool: tools.NewBaseTool(
+			cfg,
+			tools.GetAnnotationsOrDefault(cfg.Annotations, tools.NewReadOnlyAnnotations),
+			tools.Manifest{Description: desc, Parameters: allParameters.Manifest(), AuthRequired: cfg.AuthRequired},
+			allParameters,
+		),
+	}, nil
+}
+
+// validate interface
+var _ tools.Tool = Tool{}
+
+// Tool is the implementation of the tool.
+type Tool struct {
+	tools.BaseTool[Config]
+}
+
+// Invoke executes the tool's operation.
+func (t Tool) Invoke(ctx context.Context, resourceMgr tools.SourceProvider, params parameters.ParamValues, accessToken tools.AccessToken) (_ any, toolErr util.ToolboxError) {
+	ctx, span := tools.StartInvokeSpan(ctx, t.Cfg.Type, t.Cfg.Name)
+	defer func() { tools.EndInvokeSpan(span, toolErr) }()
+
+	source, err := tools.GetCompatibleSource[compatibleSource](resourceMgr, t.Cfg.Source, t.Cfg.Name, t.Cfg.Type)
+	if err != nil {
+		return nil, util.NewClientServerError("source used is not compatible with the tool", http.StatusInternalServerError, err)
+	}
+	span.SetAttributes(attribute.String("project", source.GetProject()), attribute.String("location", source.GetLocation()))
+	paramMap := params.AsMap()
+	name, ok := paramMap["name"].(string)
+	if !ok {
+		return nil, util.NewAgentError("missing required parameter: name", nil)
+	}
+	resourceName, err := serverlessspark.BatchResourceName(source.GetProject(), source.GetLocation(), name)
+	if err != nil {
+		return nil, util.NewAgentError(fmt.Sprintf("name %v", err), err)
+	}
+	span.SetAttributes(attribute.String("batch_id", name))
+
+	targetStates := serverlessspark.TerminalBatchStates
+	if rawStates, _ := paramMap["targetStates"].([]any); len(rawStates) > 0 {
+		targetStates = make([]string, 0, len(rawStates))
+		for _, s := range rawStates {
+			state, ok := s.(string)
+			if !ok || !slices.Contains(batchStates, any(state)) {
+				return nil, util.NewAgentError(fmt.Sprintf("targetStates must be batch states such as %v: %v", batchStates, s), nil)
+			}
+			targetStates = append(targetStates, state)
+		}
+	}
+
+	timeout := defaultTimeout
+	if s, _ := paramMap["timeout"].(string); s != "" {
+		timeout, err = time.ParseDuration(s)
+		if err != nil {
+			return nil, util.NewAgentError(fmt.Sprintf("timeout must be a duration like 5m: %q", s), err)
+		}
+		if timeout <= 0 || timeout > maxTimeout {
+			return nil, util.NewAgentError(fmt.Sprintf("timeout must be positive and at most %s: %s", maxTimeout, timeout), nil)
+		}
+	}
+
+	resp, err := source.WaitForBatch(ctx, resourceName, targetStates, timeout)
+	if err != nil {
+		return nil, serverlessspark.ProcessError(err)
+	}
+	return resp, nil
+}
+
+func (t Tool) ToConfig() tools.ToolConfig {
+	return t.Cfg
+}
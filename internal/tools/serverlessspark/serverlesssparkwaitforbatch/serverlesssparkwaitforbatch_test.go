@@ -0,0 +1,190 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serverlesssparkwaitforbatch_test
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/mcp-toolbox/internal/server"
+	"github.com/googleapis/mcp-toolbox/internal/sources"
+	"github.com/googleapis/mcp-toolbox/internal/testutils"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/googleapis/mcp-toolbox/internal/tools/serverlessspark/serverlesssparkwaitforbatch"
+	"github.com/googleapis/mcp-toolbox/internal/util/parameters"
+)
+
+func TestParseFromYaml(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ToolConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			kind: tool
+			name: example_tool
+			type: serverless-spark-wait-for-batch
+			source: my-instance
+			description: some description
+			`,
+			want: server.ToolConfigs{
+				"example_tool": serverlesssparkwaitforbatch.Config{
+					ConfigBase: tools.ConfigBase{
+						Name:         "example_tool",
+						Description:  "some description",
+						AuthRequired: []string{},
+					},
+					Type:   "serverless-spark-wait-for-batch",
+					Source: "my-instance",
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, _, _, got, _, _, err := server.UnmarshalResourceConfig(ctx, testutils.FormatYaml(tc.in))
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+type mockSource struct {
+	sources.Source
+	called          bool
+	gotName         string
+	gotTargetStates []string
+	gotTimeout      time.Duration
+}
+
+func (m *mockSource) GetProject() string {
+	return "my-project"
+}
+
+func (m *mockSource) GetLocation() string {
+	return "us-central1"
+}
+
+func (m *mockSource) WaitForBatch(ctx context.Context, name string, targetStates []string, timeout time.Duration) (map[string]any, error) {
+	m.called = true
+	m.gotName = name
+	m.gotTargetStates = targetStates
+	m.gotTimeout = timeout
+	return map[string]any{}, nil
+}
+
+type mockSourceProvider struct {
+	tools.SourceProvider
+	source *mockSource
+}
+
+func (m *mockSourceProvider) GetSource(name string) (sources.Source, bool) {
+	return m.source, true
+}
+
+func TestInvoke(t *testing.T) {
+	cfg := serverlesssparkwaitforbatch.Config{
+		ConfigBase: tools.ConfigBase{Name: "example_tool"},
+		Type:       "serverless-spark-wait-for-batch",
+		Source:     "my-instance",
+	}
+	tool, err := cfg.Initialize(context.Background())
+	if err != nil {
+		t.Fatalf("failed to initialize tool: %v", err)
+	}
+
+	tcs := []struct {
+		desc             string
+		params           parameters.ParamValues
+		wantTargetStates []string
+		wantTimeout      time.Duration
+		wantSubstr       string
+	}{
+		{
+			desc:             "defaults",
+			params:           parameters.ParamValues{{Name: "name", Value: "my-batch"}},
+			wantTargetStates: []string{"SUCCEEDED", "FAILED", "CANCELLED"},
+			wantTimeout:      10 * time.Minute,
+		},
+		{
+			desc:             "target states and timeout",
+			params:           parameters.ParamValues{{Name: "name", Value: "my-batch"}, {Name: "targetStates", Value: []any{"RUNNING"}}, {Name: "timeout", Value: "90s"}},
+			wantTargetStates: []string{"RUNNING"},
+			wantTimeout:      90 * time.Second,
+		},
+		{
+			desc:       "invalid target state",
+			params:     parameters.ParamValues{{Name: "name", Value: "my-batch"}, {Name: "targetStates", Value: []any{"DONE"}}},
+			wantSubstr: "targetStates must be batch states",
+		},
+		{
+			desc:       "invalid timeout",
+			params:     parameters.ParamValues{{Name: "name", Value: "my-batch"}, {Name: "timeout", Value: "10"}},
+			wantSubstr: "timeout must be a duration",
+		},
+		{
+			desc:       "timeout too long",
+			params:     parameters.ParamValues{{Name: "name", Value: "my-batch"}, {Name: "timeout", Value: "2h"}},
+			wantSubstr: "timeout must be positive and at most 1h0m0s",
+		},
+		{
+			desc:       "full batch name",
+			params:     parameters.ParamValues{{Name: "name", Value: "projects/my-project/locations/us-central1/batches/my-batch"}},
+			wantSubstr: "name must be a short batch name without '/'",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			src := &mockSource{}
+			_, toolErr := tool.Invoke(context.Background(), &mockSourceProvider{source: src}, tc.params, "")
+			if tc.wantSubstr != "" {
+				if toolErr == nil || !strings.Contains(toolErr.Error(), tc.wantSubstr) {
+					t.Fatalf("expected error containing %q, got %v", tc.wantSubstr, toolErr)
+				}
+				if src.called {
+					t.Errorf("expected source not to be called on validation failure")
+				}
+				return
+			}
+			if toolErr != nil {
+				t.Fatalf("unexpected error: %v", toolErr)
+			}
+			if want := "projects/my-project/locations/us-central1/batches/my-batch"; src.gotName != want {
+				t.Errorf("got name %q, want %q", src.gotName, want)
+			}
+			if !slices.Equal(src.gotTargetStates, tc.wantTargetStates) {
+				t.Errorf("got targetStates %v, want %v", src.gotTargetStates, tc.wantTargetStates)
+			}
+			if src.gotTimeout != tc.wantTimeout {
+				t.Errorf("got timeout %v, want %v", src.gotTimeout, tc.wantTimeout)
+			}
+		})
+	}
+}
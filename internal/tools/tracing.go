@@ -0,0 +1,53 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"context"
+
+	"github.com/googleapis/mcp-toolbox/internal/util"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// StartInvokeSpan starts a span for an invocation of a tool, with attributes
+// for the tool's type and name. Tools may add attributes for the resources
+// they act on, and must end the span with EndInvokeSpan.
+//
+// The span is started with the tracer of the instrumentation in ctx. If ctx
+// has none, the span is not recorded.
+func StartInvokeSpan(ctx context.Context, toolType, toolName string) (context.Context, trace.Span) {
+	var tracer trace.Tracer = noop.NewTracerProvider().Tracer("")
+	if instrumentation, err := util.InstrumentationFromContext(ctx); err == nil {
+		tracer = instrumentation.Tracer
+	}
+	return tracer.Start(
+		ctx,
+		"toolbox/tool/invoke",
+		trace.WithAttributes(attribute.String("tool_type", toolType)),
+		trace.WithAttributes(attribute.String("tool_name", toolName)),
+	)
+}
+
+// EndInvokeSpan records err on span, if it is non-nil, and ends span.
+func EndInvokeSpan(span trace.Span, err util.ToolboxError) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
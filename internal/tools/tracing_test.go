@@ -0,0 +1,82 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/googleapis/mcp-toolbox/internal/telemetry"
+	"github.com/googleapis/mcp-toolbox/internal/tools"
+	"github.com/googleapis/mcp-toolbox/internal/util"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestInvokeSpan(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	ctx := util.WithInstrumentation(context.Background(), &telemetry.Instrumentation{Tracer: tp.Tracer(telemetry.TracerName)})
+
+	tcs := []struct {
+		desc       string
+		err        util.ToolboxError
+		wantStatus codes.Code
+	}{
+		{desc: "success", wantStatus: codes.Unset},
+		{desc: "error", err: util.NewAgentError("bad name", nil), wantStatus: codes.Error},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			recorder.Reset()
+			_, span := tools.StartInvokeSpan(ctx, "my-tool-type", "my_tool")
+			span.SetAttributes(attribute.String("batch_id", "my-batch"))
+			tools.EndInvokeSpan(span, tc.err)
+
+			spans := recorder.Ended()
+			if len(spans) != 1 {
+				t.Fatalf("got %d ended spans, want 1", len(spans))
+			}
+			if got, want := spans[0].Name(), "toolbox/tool/invoke"; got != want {
+				t.Errorf("got span name %q, want %q", got, want)
+			}
+			got := map[attribute.Key]string{}
+			for _, kv := range spans[0].Attributes() {
+				got[kv.Key] = kv.Value.Emit()
+			}
+			for k, want := range map[attribute.Key]string{"tool_type": "my-tool-type", "tool_name": "my_tool", "batch_id": "my-batch"} {
+				if got[k] != want {
+					t.Errorf("got attribute %s = %q, want %q", k, got[k], want)
+				}
+			}
+			if s := spans[0].Status().Code; s != tc.wantStatus {
+				t.Errorf("got status %v, want %v", s, tc.wantStatus)
+			}
+			if gotEvents := len(spans[0].Events()) > 0; gotEvents != (tc.err != nil) {
+				t.Errorf("got error events %v, want %v", spans[0].Events(), tc.err != nil)
+			}
+		})
+	}
+}
+
+func TestInvokeSpanWithoutInstrumentation(t *testing.T) {
+	_, span := tools.StartInvokeSpan(context.Background(), "my-tool-type", "my_tool")
+	if span.IsRecording() {
+		t.Errorf("got a recording span without instrumentation in the context")
+	}
+	tools.EndInvokeSpan(span, nil)
+}
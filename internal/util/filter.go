@@ -0,0 +1,54 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import "fmt"
+
+// ValidateFilterSyntax checks that a filter expression, e.g. a Cloud Logging
+// or Dataproc list filter, has balanced double quotes and parentheses, so that
+// the most common malformed filters are reported clearly rather than by the
+// API. Parentheses within quoted strings are ignored, and a backslash escapes
+// the character after it.
+func ValidateFilterSyntax(filter string) error {
+	depth := 0
+	inQuote := false
+	quoteStart := 0
+	for i := 0; i < len(filter); i++ {
+		switch c := filter[i]; {
+		case c == '\\':
+			i++
+		case c == '"':
+			if !inQuote {
+				quoteStart = i
+			}
+			inQuote = !inQuote
+		case inQuote:
+		case c == '(':
+			depth++
+		case c == ')':
+			if depth == 0 {
+				return fmt.Errorf("malformed filter: unmatched ')' at offset %d", i)
+			}
+			depth--
+		}
+	}
+	if inQuote {
+		return fmt.Errorf("malformed filter: unterminated quoted string starting at offset %d", quoteStart)
+	}
+	if depth > 0 {
+		return fmt.Errorf("malformed filter: %d unclosed '('", depth)
+	}
+	return nil
+}
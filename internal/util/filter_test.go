@@ -0,0 +1,51 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateFilterSyntax(t *testing.T) {
+	tcs := []struct {
+		desc    string
+		filter  string
+		wantErr string
+	}{
+		{desc: "empty", filter: ""},
+		{desc: "simple", filter: `state = RUNNING AND create_time < "2023-01-01T00:00:00Z"`},
+		{desc: "nested parens", filter: `severity>=ERROR AND (logName:"yarn" OR (textPayload:"OOM"))`},
+		{desc: "parens in quotes", filter: `textPayload:"failed (exit 1"`},
+		{desc: "escaped quote", filter: `textPayload:"say \"hi\""`},
+		{desc: "unterminated quote", filter: `textPayload:"oops`, wantErr: "malformed filter: unterminated quoted string starting at offset 12"},
+		{desc: "unclosed paren", filter: `(a OR (b AND c)`, wantErr: "malformed filter: 1 unclosed '('"},
+		{desc: "unmatched paren", filter: `a OR b)`, wantErr: "malformed filter: unmatched ')' at offset 6"},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := ValidateFilterSyntax(tc.filter)
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Errorf("ValidateFilterSyntax(%q) = %v, want nil", tc.filter, err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("ValidateFilterSyntax(%q) = %v, want error containing %q", tc.filter, err, tc.wantErr)
+			}
+		})
+	}
+}
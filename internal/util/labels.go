@@ -0,0 +1,51 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+)
+
+var (
+	labelKeyRegex   = regexp.MustCompile(`^[a-z][a-z0-9_-]{0,62}$`)
+	labelValueRegex = regexp.MustCompile(`^[a-z0-9_-]{0,63}$`)
+)
+
+// ValidateLabels checks that labels conform to the Google Cloud label
+// constraints: there are at most maxLabels of them, keys must start with a
+// lowercase letter, and keys and values may contain at most 63 lowercase
+// letters, digits, underscores, or dashes.
+func ValidateLabels(labels map[string]string, maxLabels int) error {
+	if len(labels) > maxLabels {
+		return fmt.Errorf("too many labels: got %d, at most %d are allowed", len(labels), maxLabels)
+	}
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	// Sort so the reported error is deterministic.
+	sort.Strings(keys)
+	for _, k := range keys {
+		if !labelKeyRegex.MatchString(k) {
+			return fmt.Errorf("invalid label key %q: keys must start with a lowercase letter and contain at most 63 lowercase letters, digits, underscores, or dashes", k)
+		}
+		if v := labels[k]; !labelValueRegex.MatchString(v) {
+			return fmt.Errorf("invalid value %q for label %q: values must contain at most 63 lowercase letters, digits, underscores, or dashes", v, k)
+		}
+	}
+	return nil
+}
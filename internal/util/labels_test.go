@@ -0,0 +1,90 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestValidateLabels(t *testing.T) {
+	tooMany := map[string]string{}
+	for i := 0; i < 33; i++ {
+		tooMany[fmt.Sprintf("key-%d", i)] = "value"
+	}
+
+	tcs := []struct {
+		desc    string
+		labels  map[string]string
+		wantErr string
+	}{
+		{
+			desc:   "nil labels",
+			labels: nil,
+		},
+		{
+			desc:   "valid labels",
+			labels: map[string]string{"team": "data-eng", "cost_center": "1234", "empty": ""},
+		},
+		{
+			desc:    "uppercase key",
+			labels:  map[string]string{"Team": "data"},
+			wantErr: `invalid label key "Team"`,
+		},
+		{
+			desc:    "key starting with digit",
+			labels:  map[string]string{"1team": "data"},
+			wantErr: `invalid label key "1team"`,
+		},
+		{
+			desc:    "key too long",
+			labels:  map[string]string{"a" + strings.Repeat("b", 63): "data"},
+			wantErr: "invalid label key",
+		},
+		{
+			desc:    "uppercase value",
+			labels:  map[string]string{"team": "Data"},
+			wantErr: `invalid value "Data" for label "team"`,
+		},
+		{
+			desc:    "value too long",
+			labels:  map[string]string{"team": strings.Repeat("a", 64)},
+			wantErr: `for label "team"`,
+		},
+		{
+			desc:    "too many labels",
+			labels:  tooMany,
+			wantErr: "too many labels",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := ValidateLabels(tc.labels, 32)
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected error containing %q, got nil", tc.wantErr)
+			}
+			if !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("expected error to contain %q, got %q", tc.wantErr, err)
+			}
+		})
+	}
+}
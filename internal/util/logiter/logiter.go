@@ -0,0 +1,40 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logiter
+
+import (
+	"context"
+	"errors"
+
+	"cloud.google.com/go/logging"
+	"cloud.google.com/go/logging/logadmin"
+)
+
+// Next returns the next entry of it like it.Next, but first checks ctx, which
+// the iterator only sees when it fetches a page. This stops loops over many
+// buffered entries promptly when ctx is done.
+func Next(ctx context.Context, it *logadmin.EntryIterator) (*logging.Entry, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return it.Next()
+}
+
+// DeadlineExceeded reports whether ctx's deadline has passed. Listing log
+// entries that stops because of it returns the entries read so far, rather
+// than an error, if there are any.
+func DeadlineExceeded(ctx context.Context) bool {
+	return errors.Is(ctx.Err(), context.DeadlineExceeded)
+}
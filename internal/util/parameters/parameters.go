@@ -25,6 +25,7 @@ import (
 	"slices"
 	"strings"
 	"text/template"
+	"time"
 
 	embeddingmodels "github.com/googleapis/mcp-toolbox/internal/embeddingmodels"
 	"github.com/googleapis/mcp-toolbox/internal/util"
@@ -704,6 +705,76 @@ func (p *StringParameter) Manifest() ParameterManifest {
 	}
 }
 
+// NewTimestampParameter is a convenience function for initializing a TimestampParameter.
+type TimestampParameterOption func(*TimestampParameter)
+
+func WithTimestampRequired(v bool) TimestampParameterOption {
+	return func(p *TimestampParameter) { p.Required = &v }
+}
+
+func NewTimestampParameter(name string, desc string, opts ...TimestampParameterOption) *TimestampParameter {
+	p := &TimestampParameter{
+		CommonParameter: CommonParameter{
+			Name:         name,
+			Type:         TypeString,
+			Desc:         desc,
+			AuthServices: nil,
+		},
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+var _ Parameter = &TimestampParameter{}
+
+// TimestampParameter is a "string" parameter holding an RFC3339 timestamp.
+// It is advertised to clients as a string, but its parsed value is a
+// time.Time so tools don't each have to validate the format themselves.
+type TimestampParameter struct {
+	CommonParameter `yaml:",inline"`
+}
+
+// Parse parses the value "v" as an RFC3339 timestamp and returns a
+// time.Time. An empty string is treated as if the value were omitted.
+func (p *TimestampParameter) Parse(v any) (any, error) {
+	newV, ok := v.(string)
+	if !ok {
+		return nil, &ParseTypeError{p.Name, p.Type, v}
+	}
+	if newV == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, newV)
+	if err != nil {
+		return nil, fmt.Errorf("%q is not an RFC3339 timestamp (e.g., 2025-12-09T00:00:00Z)", newV)
+	}
+	return t, nil
+}
+
+func (p *TimestampParameter) GetAuthServices() []ParamAuthService {
+	return p.AuthServices
+}
+
+func (p *TimestampParameter) GetDefault() any {
+	return nil
+}
+
+// Manifest returns the manifest for the TimestampParameter.
+func (p *TimestampParameter) Manifest() ParameterManifest {
+	// only list ParamAuthService names (without fields) in manifest
+	authServiceNames := getAuthServiceNames(p.AuthServices)
+	r := CheckParamRequired(p.GetRequired(), p.GetDefault())
+	return ParameterManifest{
+		Name:         p.Name,
+		Type:         p.Type,
+		Required:     r,
+		Description:  p.Desc,
+		AuthServices: authServiceNames,
+	}
+}
+
 // NewIntParameter is a convenience function for initializing a IntParameter.
 type IntParameterOption func(*IntParameter)
 
@@ -734,17 +805,42 @@ func NewIntParameter(name string, desc string, opts ...IntParameterOption) *IntP
 	return p
 }
 
+// MaxAPIPageSize is the largest pageSize the list tools accept, so that a
+// single call cannot fetch an unbounded page.
+const MaxAPIPageSize = 1000
+
+// NewBoundedIntParameter is a convenience function for initializing an
+// IntParameter that must be between min and max, inclusive, and defaults to
+// def, e.g. for page sizes and limits. A value outside the bounds fails with
+// an error naming the parameter and its range.
+func NewBoundedIntParameter(name string, min, max, def int, desc string, opts ...IntParameterOption) *IntParameter {
+	opts = append([]IntParameterOption{WithIntDefault(def), WithIntRange(min, max)}, opts...)
+	return NewIntParameter(name, desc, opts...)
+}
+
 var _ Parameter = &IntParameter{}
 
 func WithIntMinValue(v *int) IntParameterOption { return func(p *IntParameter) { p.MinValue = v } }
 func WithIntMaxValue(v *int) IntParameterOption { return func(p *IntParameter) { p.MaxValue = v } }
 
+// WithIntRange bounds the parameter to between min and max, inclusive, like
+// NewBoundedIntParameter, for parameters without a fixed default.
+func WithIntRange(min, max int) IntParameterOption {
+	return func(p *IntParameter) {
+		p.MinValue, p.MaxValue, p.Bounded = &min, &max, true
+	}
+}
+
 // IntParameter is a parameter representing the "int" type.
 type IntParameter struct {
 	CommonParameter `yaml:",inline"`
 	Default         *int `yaml:"default"`
 	MinValue        *int `yaml:"minValue"`
 	MaxValue        *int `yaml:"maxValue"`
+	// Bounded is set by WithIntRange, whose out-of-range values
+	// fail with "<name> must be between <min> and <max>" instead of the
+	// minimum and maximum value errors of configured parameters.
+	Bounded bool `yaml:"-"`
 }
 
 func (p *IntParameter) Parse(v any) (any, error) {
@@ -771,6 +867,9 @@ func (p *IntParameter) Parse(v any) (any, error) {
 	if p.IsExcludedValues(out) {
 		return nil, fmt.Errorf("%d is an excluded value", out)
 	}
+	if p.Bounded && (out < *p.MinValue || out > *p.MaxValue) {
+		return nil, fmt.Errorf("%s must be between %d and %d: %d", p.Name, *p.MinValue, *p.MaxValue, out)
+	}
 	if p.MinValue != nil && out < *p.MinValue {
 		return nil, fmt.Errorf("%d is under the minimum value", out)
 	}
@@ -17,11 +17,13 @@ package parameters_test
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"math"
 	"reflect"
 	"slices"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/goccy/go-yaml"
 	"github.com/google/go-cmp/cmp"
@@ -721,6 +723,34 @@ func TestParametersParse(t *testing.T) {
 				"my_string": 4,
 			},
 		},
+		{
+			name: "timestamp",
+			params: parameters.Parameters{
+				parameters.NewTimestampParameter("my_timestamp", "this param is a timestamp"),
+			},
+			in: map[string]any{
+				"my_timestamp": "2025-12-09T10:30:00+02:00",
+			},
+			want: parameters.ParamValues{parameters.ParamValue{Name: "my_timestamp", Value: time.Date(2025, 12, 9, 10, 30, 0, 0, time.FixedZone("", 2*60*60))}},
+		},
+		{
+			name: "timestamp not RFC3339",
+			params: parameters.Parameters{
+				parameters.NewTimestampParameter("my_timestamp", "this param is a timestamp"),
+			},
+			in: map[string]any{
+				"my_timestamp": "2025-12-09 10:30:00",
+			},
+		},
+		{
+			name: "not timestamp",
+			params: parameters.Parameters{
+				parameters.NewTimestampParameter("my_timestamp", "this param is a timestamp"),
+			},
+			in: map[string]any{
+				"my_timestamp": 4,
+			},
+		},
 		{
 			name: "string allowed",
 			params: parameters.Parameters{
@@ -1010,6 +1040,42 @@ func TestParametersParse(t *testing.T) {
 				"my_int": 3,
 			},
 		},
+		{
+			name: "bounded int",
+			params: parameters.Parameters{
+				parameters.NewBoundedIntParameter("my_int", 1, 100, 20, "this param is a bounded int"),
+			},
+			in: map[string]any{
+				"my_int": 100,
+			},
+			want: parameters.ParamValues{parameters.ParamValue{Name: "my_int", Value: 100}},
+		},
+		{
+			name: "bounded int default",
+			params: parameters.Parameters{
+				parameters.NewBoundedIntParameter("my_int", 1, 100, 20, "this param is a bounded int"),
+			},
+			in:   map[string]any{},
+			want: parameters.ParamValues{parameters.ParamValue{Name: "my_int", Value: 20}},
+		},
+		{
+			name: "bounded int under minimum",
+			params: parameters.Parameters{
+				parameters.NewBoundedIntParameter("my_int", 1, 100, 20, "this param is a bounded int"),
+			},
+			in: map[string]any{
+				"my_int": 0,
+			},
+		},
+		{
+			name: "bounded int over maximum",
+			params: parameters.Parameters{
+				parameters.NewBoundedIntParameter("my_int", 1, 100, 20, "this param is a bounded int"),
+			},
+			in: map[string]any{
+				"my_int": 101,
+			},
+		},
 		{
 			name: "float",
 			params: parameters.Parameters{
@@ -1423,6 +1489,46 @@ func TestParametersParse(t *testing.T) {
 	})
 }
 
+func TestBoundedIntParameterError(t *testing.T) {
+	p := parameters.NewBoundedIntParameter("pageSize", 1, 1000, 20, "The page size.")
+	for _, v := range []int{-1, 0, 1001} {
+		_, err := p.Parse(v)
+		want := fmt.Sprintf("pageSize must be between 1 and 1000: %d", v)
+		if err == nil || err.Error() != want {
+			t.Errorf("Parse(%d) error = %v, want %q", v, err, want)
+		}
+	}
+}
+
+func TestConfiguredIntParameterRangeError(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	data := []byte(`
+- name: my_int
+  type: integer
+  description: this param is an int with a range
+  minValue: 1
+  maxValue: 100
+`)
+	var params parameters.Parameters
+	if err := yaml.UnmarshalContext(ctx, data, &params); err != nil {
+		t.Fatalf("unable to unmarshal: %s", err)
+	}
+	// Parameters configured in YAML keep the minimum and maximum value
+	// errors; only NewBoundedIntParameter reports the range.
+	for v, want := range map[int]string{
+		0:   "0 is under the minimum value",
+		101: "101 is above the maximum value",
+	} {
+		_, err := params[0].Parse(v)
+		if err == nil || err.Error() != want {
+			t.Errorf("Parse(%d) error = %v, want %q", v, err, want)
+		}
+	}
+}
+
 func TestAuthParametersParse(t *testing.T) {
 	authServices := []parameters.ParamAuthService{
 		{
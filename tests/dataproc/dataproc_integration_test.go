@@ -262,14 +262,14 @@ func TestDataprocClustersToolEndpoints(t *testing.T) {
 					toolName: "list-clusters",
 					request:  map[string]any{"pageSize": 0},
 					wantCode: http.StatusOK,
-					wantMsg:  "pageSize must be positive: 0",
+					wantMsg:  "pageSize must be between 1 and 1000: 0",
 				},
 				{
 					name:     "negative page size",
 					toolName: "list-clusters",
 					request:  map[string]any{"pageSize": -1},
 					wantCode: http.StatusOK,
-					wantMsg:  "pageSize must be positive: -1",
+					wantMsg:  "pageSize must be between 1 and 1000: -1",
 				},
 			}
 			for _, tc := range tcs {
@@ -303,14 +303,14 @@ func TestDataprocClustersToolEndpoints(t *testing.T) {
 					toolName: "list-jobs",
 					request:  map[string]any{"pageSize": 0},
 					wantCode: http.StatusOK,
-					wantMsg:  "pageSize must be positive: 0",
+					wantMsg:  "pageSize must be between 1 and 1000: 0",
 				},
 				{
 					name:     "negative page size",
 					toolName: "list-jobs",
 					request:  map[string]any{"pageSize": -1},
 					wantCode: http.StatusOK,
-					wantMsg:  "pageSize must be positive: -1",
+					wantMsg:  "pageSize must be between 1 and 1000: -1",
 				},
 			}
 			for _, tc := range tcs {
@@ -104,6 +104,15 @@ func TestServerlessSparkToolEndpoints(t *testing.T) {
 				"source":       "my-spark",
 				"authRequired": []string{"my-google-auth"},
 			},
+			"list-session-templates": map[string]any{
+				"type":   "serverless-spark-list-session-templates",
+				"source": "my-spark",
+			},
+			"list-session-templates-with-auth": map[string]any{
+				"type":         "serverless-spark-list-session-templates",
+				"source":       "my-spark",
+				"authRequired": []string{"my-google-auth"},
+			},
 			"get-batch": map[string]any{
 				"type":   "serverless-spark-get-batch",
 				"source": "my-spark",
@@ -244,14 +253,14 @@ func TestServerlessSparkToolEndpoints(t *testing.T) {
 					toolName: "list-batches",
 					request:  map[string]any{"pageSize": 0},
 					wantCode: http.StatusOK,
-					wantMsg:  "pageSize must be positive: 0",
+					wantMsg:  "pageSize must be between 1 and 1000: 0",
 				},
 				{
 					name:     "negative page size",
 					toolName: "list-batches",
 					request:  map[string]any{"pageSize": -1},
 					wantCode: http.StatusOK,
-					wantMsg:  "pageSize must be positive: -1",
+					wantMsg:  "pageSize must be between 1 and 1000: -1",
 				},
 			}
 			for _, tc := range tcs {
@@ -286,14 +295,14 @@ func TestServerlessSparkToolEndpoints(t *testing.T) {
 					toolName: "list-sessions",
 					request:  map[string]any{"pageSize": 0},
 					wantCode: http.StatusOK,
-					wantMsg:  "pageSize must be positive: 0",
+					wantMsg:  "pageSize must be between 1 and 1000: 0",
 				},
 				{
 					name:     "negative page size",
 					toolName: "list-sessions",
 					request:  map[string]any{"pageSize": -1},
 					wantCode: http.StatusOK,
-					wantMsg:  "pageSize must be positive: -1",
+					wantMsg:  "pageSize must be between 1 and 1000: -1",
 				},
 			}
 			for _, tc := range tcs {
@@ -309,6 +318,17 @@ func TestServerlessSparkToolEndpoints(t *testing.T) {
 		})
 	})
 
+	t.Run("list-session-templates", func(t *testing.T) {
+		t.Run("errors", func(t *testing.T) {
+			t.Parallel()
+			testError(t, "list-session-templates", map[string]any{"pageSize": 0}, http.StatusOK, "pageSize must be between 1 and 1000: 0")
+		})
+		t.Run("auth", func(t *testing.T) {
+			t.Parallel()
+			runAuthTest(t, "list-session-templates-with-auth", map[string]any{"pageSize": 1}, http.StatusOK)
+		})
+	})
+
 	// The following tool tests are independent and can run in parallel with each other.
 	t.Run("parallel-tool-tests", func(t *testing.T) {
 		t.Run("get-batch", func(t *testing.T) {
@@ -331,9 +351,16 @@ func TestServerlessSparkToolEndpoints(t *testing.T) {
 					{
 						name:     "missing batch",
 						toolName: "get-batch",
+						request:  map[string]any{"name": "missing-batch"},
+						wantCode: http.StatusOK,
+						wantMsg:  fmt.Sprintf("error processing GCP request: failed to get batch: rpc error: code = NotFound desc = Not found: Batch projects/%s/locations/%s/batches/missing-batch", serverlessSparkProject, serverlessSparkLocation),
+					},
+					{
+						name:     "invalid batch ID",
+						toolName: "get-batch",
 						request:  map[string]any{"name": "INVALID_BATCH"},
 						wantCode: http.StatusOK,
-						wantMsg:  fmt.Sprintf("error processing GCP request: failed to get batch: rpc error: code = NotFound desc = Not found: Batch projects/%s/locations/%s/batches/INVALID_BATCH", serverlessSparkProject, serverlessSparkLocation),
+						wantMsg:  "name must be a batch ID of 4-63 lowercase letters, numbers, and hyphens, without uppercase letters: INVALID_BATCH",
 					},
 					{
 						name:     "full batch name",
@@ -385,9 +412,16 @@ func TestServerlessSparkToolEndpoints(t *testing.T) {
 					{
 						name:     "missing session",
 						toolName: "get-session",
+						request:  map[string]any{"name": "missing-session"},
+						wantCode: http.StatusOK,
+						wantMsg:  fmt.Sprintf("Not found: Session projects/%s/locations/%s/sessions/missing-session", serverlessSparkProject, serverlessSparkLocation),
+					},
+					{
+						name:     "invalid session ID",
+						toolName: "get-session",
 						request:  map[string]any{"name": "INVALID_SESSION"},
 						wantCode: http.StatusOK,
-						wantMsg:  fmt.Sprintf("Not found: Session projects/%s/locations/%s/sessions/INVALID_SESSION", serverlessSparkProject, serverlessSparkLocation),
+						wantMsg:  "name must be a session ID of 4-63 lowercase letters, numbers, and hyphens, without uppercase letters: INVALID_SESSION",
 					},
 					{
 						name:     "full session name",
@@ -1082,6 +1116,12 @@ func runGetBatchTest(t *testing.T, client *dataproc.BatchControllerClient, ctx c
 			if !ok || !strings.HasPrefix(logsURL, logsURLPrefix) {
 				t.Errorf("unexpected logsUrl: %v", logsURL)
 			}
+			if state := wrappedResult["state"]; state != tc.want.GetState().String() {
+				t.Errorf("unexpected state: got %v, want %s", state, tc.want.GetState())
+			}
+			if stateMessage := wrappedResult["stateMessage"]; stateMessage != tc.want.GetStateMessage() {
+				t.Errorf("unexpected stateMessage: got %v, want %q", stateMessage, tc.want.GetStateMessage())
+			}
 			batchJSON, err := json.Marshal(wrappedResult["batch"])
 			if err != nil {
 				t.Fatalf("failed to marshal batch: %v", err)